@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogMailer tidak mengirim email sungguhan. Pesan ditulis ke file (jika Path
+// diisi) atau ke log standar, cocok untuk menjalankan aplikasi secara lokal.
+type LogMailer struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewLogMailer(path string) Mailer {
+	return &LogMailer{Path: path}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	entry := fmt.Sprintf(
+		"[%s] To: %s\nSubject: %s\n\n%s\n----\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body,
+	)
+
+	if m.Path == "" {
+		log.Print("📧 " + entry)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(entry)
+	return err
+}
@@ -0,0 +1,15 @@
+package mailer
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer mengirim email keluar. Implementasi dipilih saat startup lewat
+// MAIL_DRIVER (lihat config.NewMailer).
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
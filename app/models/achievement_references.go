@@ -9,11 +9,12 @@ import (
 type AchievementStatus string
 
 const (
-	StatusDraft     AchievementStatus = "draft"
-	StatusSubmitted AchievementStatus = "submitted"
-	StatusVerified  AchievementStatus = "verified"
-	StatusRejected  AchievementStatus = "rejected"
-	StatusDeleted   AchievementStatus = "deleted"
+	StatusDraft             AchievementStatus = "draft"
+	StatusSubmitted         AchievementStatus = "submitted"
+	StatusVerified          AchievementStatus = "verified"
+	StatusRejected          AchievementStatus = "rejected"
+	StatusRevisionRequested AchievementStatus = "revision_requested"
+	StatusDeleted           AchievementStatus = "deleted"
 )
 
 type AchievementReference struct {
@@ -25,6 +26,10 @@ type AchievementReference struct {
 	VerifiedAt         *time.Time        `json:"verifiedAt" db:"verified_at"`
 	VerifiedBy         *uuid.UUID        `json:"verifiedBy" db:"verified_by"`
 	RejectionNote      *string           `json:"rejectionNote" db:"rejection_note"`
+	RevisionNote       *string           `json:"revisionNote" db:"revision_note"`
+	ApprovalWorkflowID *uuid.UUID        `json:"approvalWorkflowId" db:"approval_workflow_id"`
+	CurrentStep        *int              `json:"currentStep" db:"current_step"`
+	Version            int               `json:"version" db:"version"`
 	CreatedAt          time.Time         `json:"createdAt" db:"created_at"`
 	UpdatedAt          time.Time         `json:"updatedAt" db:"updated_at"`
 }
@@ -70,3 +75,12 @@ type StudentReportResponse struct {
 	StudentID  uuid.UUID `json:"studentId"`
 	TotalPoint float64   `json:"totalPoint"`
 }
+
+// AchievementActor adalah pemanggil yang bertindak atas prestasi; service
+// memakainya untuk membatasi akses ke prestasi milik sendiri atau milik
+// mahasiswa bimbingan.
+type AchievementActor struct {
+	UserID    uuid.UUID
+	Role      string
+	StudentID *uuid.UUID
+}
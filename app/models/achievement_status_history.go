@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AchievementStatusHistory adalah satu transisi status prestasi. FromStatus
+// kosong untuk entri pembuatan (draft).
+type AchievementStatusHistory struct {
+	ID                     uuid.UUID          `json:"id" db:"id"`
+	AchievementReferenceID uuid.UUID          `json:"achievementReferenceId" db:"achievement_reference_id"`
+	FromStatus             *AchievementStatus `json:"fromStatus" db:"from_status"`
+	ToStatus               AchievementStatus  `json:"toStatus" db:"to_status"`
+	ActorID                *uuid.UUID         `json:"actorId,omitempty" db:"actor_id"`
+	ActorName              string             `json:"actorName,omitempty" db:"actor_name"`
+	ActorRole              string             `json:"actorRole,omitempty" db:"actor_role"`
+	Note                   *string            `json:"note,omitempty" db:"note"`
+	Points                 *float64           `json:"points,omitempty" db:"points"`
+	CreatedAt              time.Time          `json:"createdAt" db:"created_at"`
+}
+
+type AchievementHistoryResponse struct {
+	Achievement AchievementReference       `json:"achievement"`
+	Timeline    []AchievementStatusHistory `json:"timeline"`
+	Overrides   []PointsOverride           `json:"pointsOverrides"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bagian prestasi yang fieldnya diatur skema.
+const (
+	SchemaSectionDetails      = "details"
+	SchemaSectionCustomFields = "customFields"
+)
+
+// Tipe nilai field skema. Tipe field details mengikuti DynamicDetails.
+const (
+	FieldTypeString      = "string"
+	FieldTypeInteger     = "integer"
+	FieldTypeNumber      = "number"
+	FieldTypeBoolean     = "boolean"
+	FieldTypeDate        = "date"
+	FieldTypeStringArray = "string[]"
+	FieldTypePeriod      = "period"
+)
+
+// AchievementTypeSchema menentukan field details dan customFields yang
+// boleh dan wajib diisi untuk satu achievementType.
+type AchievementTypeSchema struct {
+	AchievementType          string      `json:"achievementType" db:"achievement_type"`
+	Details                  []FieldSpec `json:"details"`
+	CustomFields             []FieldSpec `json:"customFields"`
+	AllowUnknownCustomFields bool        `json:"allowUnknownCustomFields" db:"allow_unknown_custom_fields"`
+	UpdatedBy                *uuid.UUID  `json:"updatedBy,omitempty" db:"updated_by"`
+	UpdatedAt                time.Time   `json:"updatedAt" db:"updated_at"`
+}
+
+// FieldSpec adalah aturan satu field. Min dan Max berlaku untuk nilai
+// angka, panjang string, atau jumlah item string[].
+type FieldSpec struct {
+	Name     string   `json:"name" db:"name" validate:"required,max=50"`
+	Label    string   `json:"label" db:"label" validate:"max=100"`
+	Type     string   `json:"type" db:"field_type" validate:"omitempty,oneof=string integer number boolean date string[] period"`
+	Required bool     `json:"required" db:"required"`
+	Enum     []string `json:"enum,omitempty" db:"enum_values"`
+	Format   string   `json:"format,omitempty" db:"format" validate:"omitempty,oneof=email url date issn"`
+	Min      *float64 `json:"min,omitempty" db:"min_value"`
+	Max      *float64 `json:"max,omitempty" db:"max_value"`
+}
+
+// AchievementTypeSchemaRequest mengganti seluruh skema satu tipe. Type
+// field details boleh dikosongkan karena ditentukan oleh DynamicDetails.
+type AchievementTypeSchemaRequest struct {
+	Details                  []FieldSpec `json:"details" validate:"dive"`
+	CustomFields             []FieldSpec `json:"customFields" validate:"dive"`
+	AllowUnknownCustomFields bool        `json:"allowUnknownCustomFields"`
+}
+
+// FieldError adalah satu pelanggaran skema, misalnya
+// {"field": "details.competitionLevel", "message": "is required"}.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// SchemaValidationError berisi semua pelanggaran skema sebuah prestasi.
+type SchemaValidationError struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
+
+func NewSchemaValidationError(errs []FieldError) *SchemaValidationError {
+	return &SchemaValidationError{Message: "achievement has invalid fields", Errors: errs}
+}
+
+func (e *SchemaValidationError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		parts[i] = fe.Field + " " + fe.Message
+	}
+	return e.Message + ": " + strings.Join(parts, "; ")
+}
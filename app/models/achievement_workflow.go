@@ -0,0 +1,104 @@
+package models
+
+import "fmt"
+
+// AchievementAction adalah aksi pada alur verifikasi prestasi.
+type AchievementAction string
+
+const (
+	ActionEdit            AchievementAction = "edit"
+	ActionSubmit          AchievementAction = "submit"
+	ActionApproveStep     AchievementAction = "approve_step"
+	ActionVerify          AchievementAction = "verify"
+	ActionReject          AchievementAction = "reject"
+	ActionRequestRevision AchievementAction = "request_revision"
+	ActionDelete          AchievementAction = "delete"
+)
+
+// AchievementTransition menyatakan dari status mana sebuah aksi boleh
+// dilakukan dan status hasilnya. To kosong berarti status tidak berubah.
+type AchievementTransition struct {
+	From []AchievementStatus
+	To   AchievementStatus
+}
+
+// AchievementTransitions adalah satu-satunya sumber aturan alur prestasi;
+// service dan repository memeriksa transisi lewat NextAchievementStatus.
+var AchievementTransitions = map[AchievementAction]AchievementTransition{
+	ActionEdit:            {From: []AchievementStatus{StatusDraft, StatusRevisionRequested}},
+	ActionSubmit:          {From: []AchievementStatus{StatusDraft, StatusRevisionRequested}, To: StatusSubmitted},
+	ActionApproveStep:     {From: []AchievementStatus{StatusSubmitted}},
+	ActionVerify:          {From: []AchievementStatus{StatusSubmitted}, To: StatusVerified},
+	ActionReject:          {From: []AchievementStatus{StatusSubmitted}, To: StatusRejected},
+	ActionRequestRevision: {From: []AchievementStatus{StatusSubmitted}, To: StatusRevisionRequested},
+	ActionDelete:          {From: []AchievementStatus{StatusDraft}, To: StatusDeleted},
+}
+
+// AchievementTransitionError dikembalikan saat aksi tidak diizinkan dari
+// status prestasi saat ini.
+type AchievementTransitionError struct {
+	Action AchievementAction
+	From   AchievementStatus
+}
+
+func (e *AchievementTransitionError) Error() string {
+	return fmt.Sprintf("cannot %s achievement with status %s", e.Action, e.From)
+}
+
+// NextAchievementStatus mengembalikan status setelah action dilakukan dari
+// status from, atau *AchievementTransitionError jika tidak diizinkan.
+func NextAchievementStatus(from AchievementStatus, action AchievementAction) (AchievementStatus, error) {
+	t, ok := AchievementTransitions[action]
+	if ok {
+		for _, st := range t.From {
+			if st == from {
+				if t.To == "" {
+					return from, nil
+				}
+				return t.To, nil
+			}
+		}
+	}
+	return "", &AchievementTransitionError{Action: action, From: from}
+}
+
+// BulkVerifyItem adalah satu prestasi dalam verifikasi massal.
+type BulkVerifyItem struct {
+	ID            string   `json:"id" validate:"required"`
+	Points        *float64 `json:"points" validate:"omitempty,gte=0"`
+	Justification string   `json:"justification"`
+	Note          string   `json:"note"`
+	IfMatch       string   `json:"ifMatch"`
+}
+
+type BulkVerifyRequest struct {
+	Items []BulkVerifyItem `json:"items" validate:"required,min=1,max=200,dive"`
+}
+
+// BulkRejectItem adalah satu prestasi dalam penolakan massal.
+type BulkRejectItem struct {
+	ID      string `json:"id" validate:"required"`
+	Note    string `json:"note" validate:"required"`
+	IfMatch string `json:"ifMatch"`
+}
+
+type BulkRejectRequest struct {
+	Items []BulkRejectItem `json:"items" validate:"required,min=1,max=200,dive"`
+}
+
+// BulkItemResult adalah hasil satu item aksi massal. Err diisi service;
+// Code dan Error diisi route dari Err.
+type BulkItemResult struct {
+	ID     string          `json:"id"`
+	OK     bool            `json:"ok"`
+	Code   int             `json:"code,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Result *ApprovalResult `json:"result,omitempty"`
+	Err    error           `json:"-"`
+}
+
+type BulkActionResponse struct {
+	Results   []BulkItemResult `json:"results"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -23,6 +24,7 @@ type DynamicDetails struct {
 	CompetitionLevel string `json:"competitionLevel,omitempty" bson:"competitionLevel,omitempty"`
 	Rank             int    `json:"rank,omitempty" bson:"rank,omitempty"`
 	MedalType        string `json:"medalType,omitempty" bson:"medalType,omitempty"`
+	TeamSize         int    `json:"teamSize,omitempty" bson:"teamSize,omitempty"`
 
 	PublicationType  string   `json:"publicationType,omitempty" bson:"publicationType,omitempty"`
 	PublicationTitle string   `json:"publicationTitle,omitempty" bson:"publicationTitle,omitempty"`
@@ -45,6 +47,12 @@ type DynamicDetails struct {
 	Score     float64   `json:"score,omitempty" bson:"score,omitempty"`
 }
 
+// Jenis patch untuk PUT/PATCH /achievements/:id.
+const (
+	PatchMerge = "merge" // application/merge-patch+json (RFC 7396)
+	PatchJSON  = "json"  // application/json-patch+json (RFC 6902)
+)
+
 // @Schema primitive.M map[string]interface{}
 type Achievement struct {
 	ID              primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
@@ -63,4 +71,15 @@ type Achievement struct {
 	UpdatedAt time.Time  `json:"updatedAt" bson:"updatedAt"`
 	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 	IsDelete  bool       `json:"is_deleted" bson:"is_deleted"`
+
+	// Version naik setiap dokumen ditulis; ETag diisi service untuk header
+	// respons dan tidak disimpan.
+	Version int64  `json:"version" bson:"version"`
+	ETag    string `json:"-" bson:"-"`
+}
+
+// AchievementETag menggabungkan versi dokumen MongoDB dan versi referensi
+// Postgres, sehingga perubahan isi maupun status mengubah ETag.
+func AchievementETag(documentVersion int64, referenceVersion int) string {
+	return fmt.Sprintf(`"%d-%d"`, documentVersion, referenceVersion)
 }
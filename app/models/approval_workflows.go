@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope penyetuju relatif terhadap mahasiswa pemilik prestasi.
+const (
+	ApproverScopeAdvisor = "advisor" // dosen wali mahasiswa
+	ApproverScopeProgram = "program" // dosen dengan department = program studi mahasiswa
+)
+
+// ApprovalWorkflow adalah rantai persetujuan untuk kombinasi achievementType
+// dan competitionLevel tertentu. Nilai kosong berarti berlaku untuk semua.
+type ApprovalWorkflow struct {
+	ID               uuid.UUID              `json:"id" db:"id"`
+	Name             string                 `json:"name" db:"name"`
+	AchievementType  *string                `json:"achievementType" db:"achievement_type"`
+	CompetitionLevel *string                `json:"competitionLevel" db:"competition_level"`
+	IsActive         bool                   `json:"isActive" db:"is_active"`
+	Steps            []ApprovalWorkflowStep `json:"steps"`
+	CreatedAt        time.Time              `json:"createdAt" db:"created_at"`
+	UpdatedAt        time.Time              `json:"updatedAt" db:"updated_at"`
+}
+
+// ApprovalWorkflowStep adalah satu langkah persetujuan; StepOrder dimulai
+// dari 1.
+type ApprovalWorkflowStep struct {
+	StepOrder     int     `json:"stepOrder" db:"step_order"`
+	Name          string  `json:"name" db:"name"`
+	ApproverRole  *string `json:"approverRole,omitempty" db:"approver_role"`
+	ApproverScope *string `json:"approverScope,omitempty" db:"approver_scope"`
+}
+
+type ApprovalWorkflowStepRequest struct {
+	Name          string  `json:"name" validate:"required,max=100"`
+	ApproverRole  *string `json:"approverRole" validate:"omitempty,max=50"`
+	ApproverScope *string `json:"approverScope" validate:"omitempty,oneof=advisor program"`
+}
+
+// ApprovalWorkflowRequest dipakai untuk membuat dan mengganti workflow;
+// urutan Steps menjadi urutan persetujuan.
+type ApprovalWorkflowRequest struct {
+	Name             string                        `json:"name" validate:"required,max=100"`
+	AchievementType  *string                       `json:"achievementType" validate:"omitempty,max=50"`
+	CompetitionLevel *string                       `json:"competitionLevel" validate:"omitempty,max=50"`
+	IsActive         *bool                         `json:"isActive"`
+	Steps            []ApprovalWorkflowStepRequest `json:"steps" validate:"required,min=1,dive"`
+}
+
+// ApprovalResult adalah hasil satu persetujuan: Finalized true jika langkah
+// terakhir sudah menyetujui dan poin sudah ditetapkan.
+type ApprovalResult struct {
+	Status      AchievementStatus     `json:"status"`
+	Finalized   bool                  `json:"finalized"`
+	CurrentStep *int                  `json:"currentStep,omitempty"`
+	NextStep    *ApprovalWorkflowStep `json:"nextStep,omitempty"`
+	Points      float64               `json:"points"`
+	Suggestion  *PointsSuggestion     `json:"suggestion,omitempty"`
+
+	// PointsSyncPending berarti prestasi sudah verified tetapi poin belum
+	// tertulis ke data prestasi; job sinkronisasi akan mengulanginya.
+	PointsSyncPending bool `json:"pointsSyncPending,omitempty"`
+}
+
+// PendingPointsSync adalah poin final yang belum berhasil ditulis ke
+// MongoDB setelah verifikasi.
+type PendingPointsSync struct {
+	AchievementReferenceID uuid.UUID `json:"achievementReferenceId"`
+	MongoAchievementID     string    `json:"mongoAchievementId"`
+	Points                 float64   `json:"points"`
+	Attempts               int       `json:"attempts"`
+	LastError              *string   `json:"lastError"`
+	CreatedAt              time.Time `json:"createdAt"`
+}
@@ -10,13 +10,22 @@ import (
 type LoginRequest struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+
+	// Diisi oleh handler dari request, bukan dari body.
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type RegisterRequest struct {
 	Username string `json:"username" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
 	FullName string `json:"fullName" validate:"required"`
+
+	// Dicocokkan dengan registration_allowlist.
+	StudentID    string `json:"studentId" validate:"required,max=20"`
+	ProgramStudy string `json:"programStudy" validate:"required,max=100"`
+	AcademicYear string `json:"academicYear" validate:"omitempty,max=10"`
 }
 
 type LogoutRequest struct {
@@ -61,6 +70,14 @@ type LogoutResponse struct {
 	Message string `json:"message"`
 }
 
+// Nilai JWTClaims.Purpose. Access token biasa tidak memiliki purpose;
+// token dengan purpose lain tidak boleh dipakai untuk mengakses API.
+const (
+	TokenPurposeRefresh   = "refresh"
+	TokenPurposeMFA       = "mfa"
+	TokenPurposeMFAEnroll = "mfa_enroll"
+)
+
 type JWTClaims struct {
 	UserID   uuid.UUID `json:"user_id"`
 	Username string    `json:"username"`
@@ -71,13 +88,40 @@ type JWTClaims struct {
 
 	Permissions []string `json:"permissions,omitempty"`
 
+	// SessionID adalah family refresh token tempat access token diterbitkan.
+	SessionID *uuid.UUID `json:"sid,omitempty"`
+
+	Purpose string `json:"purpose,omitempty"`
+
+	// Actor diisi pada token impersonasi: admin yang bertindak sebagai user
+	// ini (claim "act", RFC 8693).
+	Actor *TokenActor `json:"act,omitempty"`
+
+	// Versi otorisasi user dan role saat token diterbitkan (AuthzState).
+	UserVersion int64 `json:"uv,omitempty"`
+	RoleVersion int64 `json:"rv,omitempty"`
+
+	// APIKeyID hanya diisi untuk request service account; claims tersebut
+	// dibuat dari API key, bukan dari JWT.
+	APIKeyID *uuid.UUID `json:"-"`
+
 	jwt.RegisteredClaims
 }
 
+type TokenActor struct {
+	UserID   uuid.UUID `json:"sub"`
+	Username string    `json:"username"`
+}
+
 type RefreshToken struct {
-	ID        uuid.UUID `db:"id"`
-	UserID    uuid.UUID `db:"user_id"`
-	Token     string    `db:"token"`
-	ExpiresAt time.Time `db:"expires_at"`
-	CreatedAt time.Time `db:"created_at"`
+	ID         uuid.UUID  `db:"id"`
+	UserID     uuid.UUID  `db:"user_id"`
+	FamilyID   uuid.UUID  `db:"family_id"`
+	Token      string     `db:"token"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	ReplacedBy *uuid.UUID `db:"replaced_by"`
+	IPAddress  string     `db:"ip_address"`
+	UserAgent  string     `db:"user_agent"`
+	CreatedAt  time.Time  `db:"created_at"`
 }
@@ -0,0 +1,15 @@
+package models
+
+import "github.com/google/uuid"
+
+// AuthzState adalah keadaan otorisasi terkini seorang user, dibandingkan
+// dengan versi yang tertanam di access token.
+type AuthzState struct {
+	UserID      uuid.UUID
+	RoleID      uuid.UUID
+	RoleName    string
+	IsActive    bool
+	UserVersion int64
+	RoleVersion int64
+	Permissions []string
+}
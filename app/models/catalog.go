@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Nama katalog master data.
+const (
+	CatalogAchievementType  = "achievement_type"
+	CatalogCompetitionLevel = "competition_level"
+	CatalogMedalType        = "medal_type"
+	CatalogPublicationType  = "publication_type"
+)
+
+// DefaultCatalogLocale dipakai jika label untuk locale yang diminta tidak ada.
+const DefaultCatalogLocale = "id"
+
+// CatalogItem adalah satu nilai master data. Code adalah nilai yang
+// disimpan di prestasi; Label diisi sesuai locale yang diminta.
+type CatalogItem struct {
+	Catalog   string            `json:"catalog" db:"catalog"`
+	Code      string            `json:"code" db:"code"`
+	Label     string            `json:"label"`
+	Labels    map[string]string `json:"labels"`
+	Aliases   []string          `json:"aliases" db:"aliases"`
+	SortOrder int               `json:"sortOrder" db:"sort_order"`
+	IsActive  bool              `json:"isActive" db:"is_active"`
+	UpdatedAt time.Time         `json:"updatedAt" db:"updated_at"`
+}
+
+// CatalogItemRequest membuat atau mengganti satu nilai katalog. Nilai yang
+// dinonaktifkan tidak diterima lagi untuk prestasi baru/yang diubah.
+type CatalogItemRequest struct {
+	Labels    map[string]string `json:"labels" validate:"required,min=1,dive,keys,min=2,max=10,endkeys,required,max=100"`
+	Aliases   []string          `json:"aliases" validate:"dive,required,max=50"`
+	SortOrder int               `json:"sortOrder"`
+	IsActive  *bool             `json:"isActive"`
+}
+
+// CatalogNormalization adalah satu nilai lama yang dipetakan ke code.
+type CatalogNormalization struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int64  `json:"count"`
+}
+
+// CatalogUnmatchedValue adalah nilai lama yang tidak cocok dengan code atau
+// alias mana pun dan perlu ditangani manual.
+type CatalogUnmatchedValue struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type CatalogNormalizationReport struct {
+	DryRun    bool                    `json:"dryRun"`
+	Changes   []CatalogNormalization  `json:"changes"`
+	Unmatched []CatalogUnmatchedValue `json:"unmatched"`
+}
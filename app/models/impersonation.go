@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type ImpersonateRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+
+	// Diisi oleh handler dari request, bukan dari body.
+	IPAddress string `json:"-"`
+}
+
+type ImpersonationResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Token        string     `json:"token"`
+		ExpiresAt    time.Time  `json:"expiresAt"`
+		User         UserData   `json:"user"`
+		Impersonator TokenActor `json:"impersonator"`
+	} `json:"data"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoginAttempt struct {
+	ID        uuid.UUID `db:"id"`
+	Username  string    `db:"username"`
+	IPAddress string    `db:"ip_address"`
+	Success   bool      `db:"success"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserMFA struct {
+	UserID       uuid.UUID  `db:"user_id"`
+	Secret       string     `db:"secret"`
+	Enabled      bool       `db:"enabled"`
+	LastUsedStep int64      `db:"last_used_step"`
+	EnabledAt    *time.Time `db:"enabled_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+}
+
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	CodeHash  string     `db:"code_hash"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// MFAChallengeResponse dikembalikan oleh login saat password benar tetapi
+// user masih harus menyelesaikan langkah kedua. Jika EnrollmentRequired
+// bernilai true, user wajib mendaftarkan authenticator terlebih dahulu
+// (POST /auth/mfa/setup lalu /auth/mfa/enable dengan MFAToken sebagai bearer).
+type MFAChallengeResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		MFAToken           string    `json:"mfaToken"`
+		ExpiresAt          time.Time `json:"expiresAt"`
+		EnrollmentRequired bool      `json:"enrollmentRequired"`
+	} `json:"data"`
+}
+
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfaToken" validate:"required"`
+	// Code berisi kode TOTP 6 digit atau salah satu kode pemulihan.
+	Code string `json:"code" validate:"required"`
+
+	// Diisi oleh handler dari request, bukan dari body.
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MFASetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OIDCLoginState adalah satu percobaan login SSO yang menunggu callback.
+type OIDCLoginState struct {
+	ID           uuid.UUID  `db:"id"`
+	StateHash    string     `db:"state_hash"`
+	Nonce        string     `db:"nonce"`
+	CodeVerifier string     `db:"code_verifier"`
+	ExpiresAt    time.Time  `db:"expires_at"`
+	UsedAt       *time.Time `db:"used_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// UserIdentity menautkan subject di identity provider ke user lokal.
+type UserIdentity struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"userId" db:"user_id"`
+	Issuer      string     `json:"issuer" db:"issuer"`
+	Subject     string     `json:"subject" db:"subject"`
+	Email       string     `json:"email,omitempty" db:"email"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty" db:"last_login_at"`
+}
+
+type OIDCAuthorizeResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		AuthorizationURL string `json:"authorizationUrl"`
+		State            string `json:"state"`
+	} `json:"data"`
+
+	// StateExpiresAt dipakai route untuk masa berlaku cookie state.
+	StateExpiresAt time.Time `json:"-"`
+}
+
+// OIDCCallbackRequest dikirim front end setelah IdP me-redirect kembali
+// dengan ?code=...&state=...
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+
+	// BrowserState adalah state dari cookie HttpOnly yang dipasang saat
+	// authorize; harus sama dengan State agar callback terikat ke browser
+	// yang memulai login.
+	BrowserState string `json:"-"`
+
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
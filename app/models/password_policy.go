@@ -0,0 +1,16 @@
+package models
+
+// PasswordPolicy adalah aturan password yang berlaku; dikembalikan ke front
+// end agar aturan bisa ditampilkan di form.
+type PasswordPolicy struct {
+	MinLength     int  `json:"minLength"`
+	MaxLength     int  `json:"maxLength"`
+	RequireUpper  bool `json:"requireUppercase"`
+	RequireLower  bool `json:"requireLowercase"`
+	RequireDigit  bool `json:"requireDigit"`
+	RequireSymbol bool `json:"requireSymbol"`
+	HistorySize   int  `json:"historySize"`
+
+	// BannedPasswords tidak dikirim ke klien.
+	BannedPasswords []string `json:"-"`
+}
@@ -19,3 +19,17 @@ type PermissionResponse struct {
 	CreatedAt   string    `json:"created_at" db:"created_at"`
 	UpdatedAt   string    `json:"updated_at" db:"updated_at"`
 }
+
+// CreatePermissionRequest membuat permission baru; nama permission selalu
+// dibentuk dari "resource:action".
+type CreatePermissionRequest struct {
+	Resource    string `json:"resource" validate:"required,max=50"`
+	Action      string `json:"action" validate:"required,max=50"`
+	Description string `json:"description"`
+}
+
+type UpdatePermissionRequest struct {
+	Resource    string `json:"resource" validate:"required,max=50"`
+	Action      string `json:"action" validate:"required,max=50"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PointsRubric adalah satu versi rubrik poin; berlaku untuk prestasi yang
+// diajukan sejak EffectiveFrom sampai versi berikutnya berlaku.
+type PointsRubric struct {
+	ID            uuid.UUID          `json:"id" db:"id"`
+	Version       int                `json:"version" db:"version"`
+	Name          string             `json:"name" db:"name"`
+	EffectiveFrom time.Time          `json:"effectiveFrom" db:"effective_from"`
+	CreatedBy     *uuid.UUID         `json:"createdBy,omitempty" db:"created_by"`
+	CreatedAt     time.Time          `json:"createdAt" db:"created_at"`
+	Rules         []PointsRubricRule `json:"rules"`
+	TeamFactors   []TeamFactor       `json:"teamFactors"`
+}
+
+// PointsRubricRule memberi poin dasar untuk prestasi yang cocok dengan
+// semua kriteria yang terisi; kriteria kosong cocok dengan nilai apa pun.
+type PointsRubricRule struct {
+	ID               uuid.UUID `json:"id" db:"id"`
+	AchievementType  string    `json:"achievementType" db:"achievement_type" validate:"required,max=50"`
+	CompetitionLevel *string   `json:"competitionLevel,omitempty" db:"competition_level" validate:"omitempty,max=50"`
+	Rank             *int      `json:"rank,omitempty" db:"rank" validate:"omitempty,min=1"`
+	MedalType        *string   `json:"medalType,omitempty" db:"medal_type" validate:"omitempty,max=50"`
+	PublicationType  *string   `json:"publicationType,omitempty" db:"publication_type" validate:"omitempty,max=50"`
+	Points           float64   `json:"points" db:"points" validate:"gte=0"`
+}
+
+// TeamFactor adalah pengali poin untuk tim beranggota minimal MinTeamSize.
+type TeamFactor struct {
+	MinTeamSize int     `json:"minTeamSize" db:"min_team_size" validate:"min=1"`
+	Multiplier  float64 `json:"multiplier" db:"multiplier" validate:"gte=0"`
+}
+
+type CreatePointsRubricRequest struct {
+	Name          string             `json:"name" validate:"required,max=100"`
+	EffectiveFrom time.Time          `json:"effectiveFrom" validate:"required"`
+	Rules         []PointsRubricRule `json:"rules" validate:"required,min=1,dive"`
+	TeamFactors   []TeamFactor       `json:"teamFactors" validate:"dive"`
+}
+
+// PointsSuggestion adalah poin yang dihitung rubrik untuk sebuah prestasi.
+// Points nil jika tidak ada rubrik atau aturan yang cocok.
+type PointsSuggestion struct {
+	RubricID      *uuid.UUID `json:"rubricId,omitempty"`
+	RubricVersion int        `json:"rubricVersion,omitempty"`
+	RuleID        *uuid.UUID `json:"ruleId,omitempty"`
+	BasePoints    float64    `json:"basePoints"`
+	TeamSize      int        `json:"teamSize"`
+	Multiplier    float64    `json:"multiplier"`
+	Points        *float64   `json:"points"`
+}
+
+// PointsOverride dicatat setiap kali poin yang diberikan berbeda dari saran
+// rubrik.
+type PointsOverride struct {
+	ID                     uuid.UUID  `json:"id" db:"id"`
+	AchievementReferenceID uuid.UUID  `json:"achievementReferenceId" db:"achievement_reference_id"`
+	RubricID               *uuid.UUID `json:"rubricId,omitempty" db:"rubric_id"`
+	SuggestedPoints        *float64   `json:"suggestedPoints" db:"suggested_points"`
+	AwardedPoints          float64    `json:"awardedPoints" db:"awarded_points"`
+	Justification          string     `json:"justification" db:"justification"`
+	ActorID                uuid.UUID  `json:"actorId" db:"actor_id"`
+	CreatedAt              time.Time  `json:"createdAt" db:"created_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegistrationAllowlistEntry adalah NIM yang boleh mendaftar sendiri.
+type RegistrationAllowlistEntry struct {
+	StudentID        string     `json:"studentId" db:"student_id"`
+	ProgramStudy     string     `json:"programStudy" db:"program_study"`
+	AcademicYear     string     `json:"academicYear,omitempty" db:"academic_year"`
+	RegisteredUserID *uuid.UUID `json:"registeredUserId,omitempty" db:"registered_user_id"`
+	UploadedBy       *uuid.UUID `json:"uploadedBy,omitempty" db:"uploaded_by"`
+	CreatedAt        time.Time  `json:"createdAt" db:"created_at"`
+}
+
+type EmailVerificationToken struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// PendingRegistration adalah akun hasil registrasi mandiri yang belum
+// diaktifkan admin.
+type PendingRegistration struct {
+	UserID          uuid.UUID  `json:"userId"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	FullName        string     `json:"fullName"`
+	StudentID       string     `json:"studentId"`
+	ProgramStudy    string     `json:"programStudy"`
+	AcademicYear    string     `json:"academicYear"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+type AllowlistUploadResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Imported int      `json:"imported"`
+		Skipped  []string `json:"skipped"`
+	} `json:"data"`
+}
@@ -6,6 +6,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// Nama role bawaan yang dirujuk langsung oleh kode (middleware, service),
+// sehingga tidak boleh diganti nama atau dihapus.
+const (
+	RoleAdmin     = "Admin"
+	RoleMahasiswa = "Mahasiswa"
+	RoleDosenWali = "DosenWali"
+)
+
 type Role struct {
 	ID          uuid.UUID `json:"id" db:"id"`
 	Name        string    `json:"name" db:"name"` 
@@ -23,4 +31,14 @@ type CreateRoleRequest struct {
 type UpdateRoleRequest struct {
 	Name        string `json:"name" validate:"required,max=50"`
 	Description string `json:"description"`
-}
\ No newline at end of file
+}
+
+// RoleResponse adalah detail role beserta permission dan jumlah user-nya
+type RoleResponse struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+	UserCount   int          `json:"userCount"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
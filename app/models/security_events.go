@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SecurityEventRefreshTokenReuse    = "refresh_token_reuse"
+	SecurityEventPasswordReset        = "password_reset"
+	SecurityEventPasswordChanged      = "password_changed"
+	SecurityEventLoginFailed          = "login_failed"
+	SecurityEventAccountLocked        = "account_locked"
+	SecurityEventAccountUnlocked      = "account_unlocked"
+	SecurityEventMFAEnabled           = "mfa_enabled"
+	SecurityEventMFADisabled          = "mfa_disabled"
+	SecurityEventMFAFailed            = "mfa_failed"
+	SecurityEventRecoveryCodeUsed     = "mfa_recovery_code_used"
+	SecurityEventSessionRevoked       = "session_revoked"
+	SecurityEventSessionsRevoked      = "all_sessions_revoked"
+	SecurityEventImpersonation        = "impersonation_started"
+	SecurityEventImpersonatedCall     = "impersonated_action"
+	SecurityEventRegistration         = "registration_approved"
+	SecurityEventRegistrationRejected = "registration_rejected"
+	SecurityEventAPIKeyCreated        = "api_key_created"
+	SecurityEventAPIKeyRevoked        = "api_key_revoked"
+	SecurityEventSSOLinked            = "sso_identity_linked"
+	SecurityEventSSOProvisioned       = "sso_user_provisioned"
+)
+
+type SecurityEvent struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    *uuid.UUID `json:"userId" db:"user_id"`
+	EventType string     `json:"eventType" db:"event_type"`
+	Detail    string     `json:"detail" db:"detail"`
+	IPAddress string     `json:"ipAddress,omitempty" db:"ip_address"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoleServiceAccount adalah nilai RoleKey untuk request yang memakai API
+// key; bukan role di tabel roles.
+const RoleServiceAccount = "ServiceAccount"
+
+// APIKeyPrefix mengawali setiap API key, misalnya "spp_1a2b3c4d_<rahasia>".
+const APIKeyPrefix = "spp_"
+
+// APIKeyScopes adalah permission yang boleh dimuat scope API key: hanya
+// akses baca untuk dashboard dan integrasi. Permission lain (pengelolaan
+// user, role, prestasi) tetap hanya lewat login user.
+var APIKeyScopes = map[string]bool{
+	"student:read":     true,
+	"report:read":      true,
+	"achievement:read": true,
+}
+
+type ServiceAccount struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Description string     `json:"description" db:"description"`
+	IsActive    bool       `json:"isActive" db:"is_active"`
+	CreatedBy   *uuid.UUID `json:"createdBy,omitempty" db:"created_by"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+type APIKey struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	ServiceAccountID uuid.UUID  `json:"serviceAccountId" db:"service_account_id"`
+	Name             string     `json:"name" db:"name"`
+	Prefix           string     `json:"prefix" db:"prefix"`
+	KeyHash          string     `json:"-" db:"key_hash"`
+	Permissions      []string   `json:"permissions" db:"permissions"`
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty" db:"expires_at"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty" db:"revoked_at"`
+	LastUsedAt       *time.Time `json:"lastUsedAt,omitempty" db:"last_used_at"`
+	LastUsedIP       string     `json:"lastUsedIp,omitempty" db:"last_used_ip"`
+	CreatedBy        *uuid.UUID `json:"createdBy,omitempty" db:"created_by"`
+	CreatedAt        time.Time  `json:"createdAt" db:"created_at"`
+
+	// Diisi saat lookup untuk autentikasi.
+	ServiceAccountName   string `json:"-"`
+	ServiceAccountActive bool   `json:"-"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+}
+
+type UpdateServiceAccountRequest struct {
+	Description *string `json:"description"`
+	IsActive    *bool   `json:"isActive"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name" validate:"required,max=100"`
+	Permissions []string   `json:"permissions" validate:"required,min=1,dive,required"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
+
+// CreateAPIKeyResponse memuat key utuh; hanya ditampilkan sekali.
+type CreateAPIKeyResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Key    string `json:"key"`
+		APIKey APIKey `json:"apiKey"`
+	} `json:"data"`
+}
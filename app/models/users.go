@@ -17,6 +17,10 @@ type User struct {
 	IsActive     bool      `json:"isActive" db:"is_active"`
 	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
 	UpdatedAt    time.Time `json:"updatedAt" db:"updated_at"`
+
+	FailedLoginAttempts int        `json:"failedLoginAttempts" db:"failed_login_attempts"`
+	LastFailedLoginAt   *time.Time `json:"lastFailedLoginAt" db:"last_failed_login_at"`
+	LockedUntil         *time.Time `json:"lockedUntil" db:"locked_until"`
 }
 
 type UserResponse struct {
@@ -27,13 +31,16 @@ type UserResponse struct {
 	Role        string    `json:"role"`
 	Permissions []string  `json:"permissions"`
 	IsActive    bool      `json:"isActive"`
+
+	FailedLoginAttempts int        `json:"failedLoginAttempts"`
+	LockedUntil         *time.Time `json:"lockedUntil,omitempty"`
 }
 
 type CreateUserRequest struct {
 	Username string    `json:"username" validate:"required,max=50"`
 	Email    string    `json:"email" validate:"required,email,max=100"`
 	FullName string    `json:"fullName" validate:"required,max=100"`
-	Password string    `json:"password" validate:"required,min=6"`
+	Password string    `json:"password" validate:"required"`
 	RoleID   uuid.UUID `json:"roleId" validate:"required"`
 
 	StudentID    string `json:"studentId,omitempty"`
@@ -50,6 +57,7 @@ type UpdateUserRequest struct {
 	Email    *string    `json:"email" validate:"omitempty,email,max=100"`
 	RoleID   *uuid.UUID `json:"roleId"`
 	IsActive *bool      `json:"isActive"`
+	Password *string    `json:"password"`
 }
 
 type UpdateUserRoleRequest struct {
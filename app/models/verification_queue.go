@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kolom pengurutan antrean verifikasi dosen wali.
+const (
+	QueueSortSubmittedAt = "submittedAt"
+	QueueSortTitle       = "title"
+	QueueSortType        = "type"
+	QueueSortStudent     = "student"
+)
+
+// VerificationQueueFilter adalah filter, urutan dan posisi halaman antrean
+// verifikasi. Cursor diambil dari NextCursor halaman sebelumnya.
+type VerificationQueueFilter struct {
+	AchievementType  string
+	CompetitionLevel string
+	StudentID        *uuid.UUID
+	MinDays          *int
+	MaxDays          *int
+	Sort             string
+	Desc             bool
+	Cursor           string
+	Limit            int
+}
+
+// VerificationQueueItem adalah satu prestasi yang menunggu verifikasi.
+type VerificationQueueItem struct {
+	ID                 uuid.UUID `json:"id"`
+	MongoAchievementID string    `json:"mongoAchievementId"`
+	StudentID          uuid.UUID `json:"studentId"`
+	StudentNIM         string    `json:"studentNim"`
+	StudentName        string    `json:"studentName"`
+	Title              string    `json:"title"`
+	AchievementType    string    `json:"achievementType"`
+	CompetitionLevel   string    `json:"competitionLevel,omitempty"`
+	CurrentStep        *int      `json:"currentStep,omitempty"`
+	SubmittedAt        time.Time `json:"submittedAt"`
+	DaysWaiting        int       `json:"daysWaiting"`
+	Overdue            bool      `json:"overdue"`
+
+	// SortKey adalah nilai kunci urutan dari PostgreSQL, untuk cursor.
+	SortKey string `json:"-"`
+}
+
+// QueueCursor menandai item terakhir halaman sebelumnya: nilai kunci urutan
+// dan id sebagai pemecah seri (id referensi untuk urutan dari PostgreSQL,
+// id dokumen untuk urutan dari MongoDB).
+type QueueCursor struct {
+	Key string `json:"k"`
+	ID  string `json:"id"`
+}
+
+// AchievementSummaryQuery memilih ringkasan prestasi antrean di MongoDB.
+// SortField kosong berarti tanpa urutan, cursor dan limit.
+type AchievementSummaryQuery struct {
+	IDs              []string
+	AchievementType  string
+	CompetitionLevel string
+	SortField        string
+	Desc             bool
+	After            *QueueCursor
+	Limit            int
+}
+
+type VerificationQueueResponse struct {
+	Items      []VerificationQueueItem `json:"items"`
+	NextCursor *string                 `json:"nextCursor"`
+	SLADays    int                     `json:"slaDays"`
+}
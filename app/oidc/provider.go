@@ -0,0 +1,257 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims adalah claims ID token yang sudah diverifikasi.
+type Claims map[string]interface{}
+
+// String mengembalikan claim bertipe string, atau "" jika tidak ada.
+func (c Claims) String(name string) string {
+	if name == "" {
+		return ""
+	}
+	switch v := c[name].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	}
+	return ""
+}
+
+// Bool mengembalikan claim boolean; beberapa IdP mengirim "true" sebagai string.
+func (c Claims) Bool(name string) bool {
+	switch v := c[name].(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// Contains memeriksa apakah claim (string atau array string) memuat value.
+func (c Claims) Contains(name, value string) bool {
+	switch v := c[name].(type) {
+	case string:
+		return strings.EqualFold(v, value)
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && strings.EqualFold(s, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Provider menjalankan alur authorization code + PKCE terhadap satu
+// identity provider.
+type Provider interface {
+	// Issuer dipakai sebagai nama provider saat menautkan identitas.
+	Issuer() string
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	// Exchange menukar code dengan token lalu memverifikasi ID token
+	// (signature, issuer, audience, masa berlaku, nonce).
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (Claims, error)
+}
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type provider struct {
+	cfg Config
+
+	mu        sync.Mutex
+	discovery *discovery
+	jwks      *keyfunc.JWKS
+}
+
+// NewProvider tidak menghubungi IdP; dokumen discovery dan JWKS diambil saat
+// pertama kali dibutuhkan agar aplikasi tetap bisa start ketika IdP down.
+func NewProvider(cfg Config) Provider {
+	cfg.Issuer = strings.TrimRight(cfg.Issuer, "/")
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &provider{cfg: cfg}
+}
+
+func (p *provider) Issuer() string {
+	return p.cfg.Issuer
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	d, _, err := p.load(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (Claims, error) {
+	d, jwks, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.cfg.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc token endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, err
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("oidc token response has no id_token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokens.IDToken, claims, jwks.Keyfunc,
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "EdDSA"}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, errors.New("invalid id token: nonce mismatch")
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, errors.New("invalid id token: missing sub")
+	}
+
+	return Claims(claims), nil
+}
+
+// load mengambil dokumen discovery dan JWKS sekali lalu menyimpannya; JWKS
+// di-refresh di background dan saat kid tidak dikenal (rotasi key IdP).
+func (p *provider) load(ctx context.Context) (*discovery, *keyfunc.JWKS, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil && p.jwks != nil {
+		return p.discovery, p.jwks, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("oidc discovery returned %d", resp.StatusCode)
+	}
+
+	var d discovery
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&d); err != nil {
+		return nil, nil, err
+	}
+	if strings.TrimRight(d.Issuer, "/") != p.cfg.Issuer {
+		return nil, nil, fmt.Errorf("oidc discovery issuer mismatch: %s", d.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, nil, errors.New("oidc discovery document is incomplete")
+	}
+
+	jwks, err := keyfunc.Get(d.JWKSURI, keyfunc.Options{
+		Client:            p.cfg.HTTPClient,
+		RefreshInterval:   time.Hour,
+		RefreshRateLimit:  time.Minute,
+		RefreshUnknownKID: true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc jwks fetch failed: %w", err)
+	}
+
+	p.discovery = &d
+	p.jwks = jwks
+	return p.discovery, p.jwks, nil
+}
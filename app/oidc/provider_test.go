@@ -0,0 +1,117 @@
+package oidc_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"backend/app/oidc"
+	"backend/app/oidc/oidctest"
+)
+
+const testClientID = "sistem-prestasi"
+
+func newTestProvider(t *testing.T) (*oidctest.Server, oidc.Provider) {
+	t.Helper()
+	idp := oidctest.NewServer(t, testClientID)
+	return idp, oidc.NewProvider(oidc.Config{
+		Issuer:      idp.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:5173/auth/sso/callback",
+	})
+}
+
+func authorize(t *testing.T, idp *oidctest.Server, p oidc.Provider, nonce, verifier string, claims map[string]interface{}) string {
+	t.Helper()
+	authURL, err := p.AuthCodeURL(context.Background(), "state", nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	return idp.Authorize(t, authURL, claims)
+}
+
+func TestExchangeVerifiesIDToken(t *testing.T) {
+	idp, p := newTestProvider(t)
+	code := authorize(t, idp, p, "nonce-1", "verifier-1", map[string]interface{}{"nim": "2101"})
+
+	claims, err := p.Exchange(context.Background(), code, "verifier-1", "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if got := claims.String("sub"); got != "subject-1" {
+		t.Errorf("sub = %q, want subject-1", got)
+	}
+	if got := claims.String("nim"); got != "2101" {
+		t.Errorf("nim = %q, want 2101", got)
+	}
+}
+
+func TestExchangeRejectsWrongPKCEVerifier(t *testing.T) {
+	idp, p := newTestProvider(t)
+	code := authorize(t, idp, p, "nonce-1", "verifier-1", nil)
+
+	_, err := p.Exchange(context.Background(), code, "another-verifier", "nonce-1")
+	if err == nil || !strings.Contains(err.Error(), "pkce") {
+		t.Fatalf("Exchange with wrong verifier: err = %v, want pkce failure", err)
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+		nonce  string
+	}{
+		{"nonce mismatch", nil, "other-nonce"},
+		{"missing nonce", map[string]interface{}{"nonce": ""}, "nonce-1"},
+		{"wrong issuer", map[string]interface{}{"iss": "https://evil.example.com"}, "nonce-1"},
+		{"wrong audience", map[string]interface{}{"aud": "another-client"}, "nonce-1"},
+		{"expired", map[string]interface{}{"exp": 1}, "nonce-1"},
+		{"missing sub", map[string]interface{}{"sub": ""}, "nonce-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp, p := newTestProvider(t)
+			code := authorize(t, idp, p, "nonce-1", "verifier-1", tt.claims)
+
+			_, err := p.Exchange(context.Background(), code, "verifier-1", tt.nonce)
+			if err == nil || !strings.HasPrefix(err.Error(), "invalid id token") {
+				t.Fatalf("err = %v, want invalid id token", err)
+			}
+		})
+	}
+}
+
+func TestExchangeRejectsReusedCode(t *testing.T) {
+	idp, p := newTestProvider(t)
+	code := authorize(t, idp, p, "nonce-1", "verifier-1", nil)
+
+	if _, err := p.Exchange(context.Background(), code, "verifier-1", "nonce-1"); err != nil {
+		t.Fatalf("first Exchange: %v", err)
+	}
+	if _, err := p.Exchange(context.Background(), code, "verifier-1", "nonce-1"); err == nil {
+		t.Fatal("second Exchange with the same code succeeded")
+	}
+}
+
+func TestClaimsHelpers(t *testing.T) {
+	c := oidc.Claims{
+		"nim":            float64(2101),
+		"email_verified": "true",
+		"groups":         []interface{}{"staff", "Mahasiswa"},
+	}
+
+	if got := c.String("nim"); got != "2101" {
+		t.Errorf("String(nim) = %q, want 2101", got)
+	}
+	if !c.Bool("email_verified") {
+		t.Error(`Bool("true") = false`)
+	}
+	if !c.Contains("groups", "mahasiswa") {
+		t.Error("Contains(groups, mahasiswa) = false")
+	}
+	if c.Contains("groups", "dosen") {
+		t.Error("Contains(groups, dosen) = true")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"backend/app/models"
@@ -11,15 +12,30 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrApprovalStepChanged dikembalikan saat langkah persetujuan sudah
+// dilanjutkan oleh penyetuju lain sejak dibaca.
+var ErrApprovalStepChanged = errors.New("approval step has changed")
+
+// ErrVersionConflict dikembalikan saat versi prestasi yang diharapkan
+// pemanggil (dari If-Match) sudah berubah.
+var ErrVersionConflict = errors.New("achievement version has changed")
+
 type AchievementReferenceRepository interface {
-	Create(ctx context.Context, ref *models.AchievementReference) error
+	Create(ctx context.Context, ref *models.AchievementReference, actorID uuid.UUID) error
 	FindByID(ctx context.Context, id uuid.UUID) (*models.AchievementReference, error)
 	GetByMongoID(ctx context.Context, mongoID string) (*models.AchievementReference, error) // Added this to interface
 	GetByStudentID(ctx context.Context, studentID uuid.UUID, limit, offset int) ([]models.AchievementReference, error)
-	UpdateStatus(ctx context.Context, mongoID string, status models.AchievementStatus) error
-	Verify(ctx context.Context, mongoID string, verifierID uuid.UUID) error
-	Reject(ctx context.Context, mongoID string, note string) error
-	SoftDeleteByMongoID(ctx context.Context, mongoID string) error
+	Submit(ctx context.Context, mongoID string, actorID uuid.UUID, workflowID *uuid.UUID, expectedVersion *int) error
+	ApproveStep(ctx context.Context, mongoID string, step int, approverID uuid.UUID, note *string, points *float64, override *models.PointsOverride, expectedVersion *int) error
+	Verify(ctx context.Context, mongoID string, verifierID uuid.UUID, points float64, step *int, override *models.PointsOverride, expectedVersion *int) error
+	Reject(ctx context.Context, mongoID string, note string, actorID uuid.UUID, expectedVersion *int) error
+	RequestRevision(ctx context.Context, mongoID string, note string, actorID uuid.UUID, expectedVersion *int) error
+	ListPendingPointsSync(ctx context.Context, limit int) ([]models.PendingPointsSync, error)
+	CompletePointsSync(ctx context.Context, referenceID uuid.UUID) error
+	FailPointsSync(ctx context.Context, referenceID uuid.UUID, reason string) error
+	SoftDeleteByMongoID(ctx context.Context, mongoID string, actorID uuid.UUID) error
+	GetHistory(ctx context.Context, referenceID uuid.UUID) ([]models.AchievementStatusHistory, error)
+	GetVerificationQueue(ctx context.Context, advisorUserID uuid.UUID, role string, filter models.VerificationQueueFilter, after *models.QueueCursor, limit int) ([]models.VerificationQueueItem, error)
 }
 
 type achievementReferenceRepository struct {
@@ -30,7 +46,7 @@ func NewAchievementReferenceRepository(db *sql.DB) AchievementReferenceRepositor
 	return &achievementReferenceRepository{db: db}
 }
 
-func (r *achievementReferenceRepository) Create(ctx context.Context, ref *models.AchievementReference) error {
+func (r *achievementReferenceRepository) Create(ctx context.Context, ref *models.AchievementReference, actorID uuid.UUID) error {
 	query := `
         INSERT INTO achievement_references (
             id, student_id, mongo_achievement_id, status, created_at, updated_at
@@ -41,22 +57,37 @@ func (r *achievementReferenceRepository) Create(ctx context.Context, ref *models
 	now := time.Now()
 	ref.CreatedAt = now
 	ref.UpdatedAt = now
+	ref.Version = 1
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	_, err := r.db.ExecContext(ctx, query,
+	if _, err := tx.ExecContext(ctx, query,
 		ref.ID,
 		ref.StudentID,
 		ref.MongoAchievementID,
 		ref.Status,
 		ref.CreatedAt,
 		ref.UpdatedAt,
-	)
-	return err
+	); err != nil {
+		return err
+	}
+
+	if err := insertStatusHistoryTx(ctx, tx, ref.ID, nil, models.StatusDraft, &actorID, nil, nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *achievementReferenceRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.AchievementReference, error) {
 	query := `
         SELECT id, student_id, mongo_achievement_id, status, 
-               submitted_at, verified_at, verified_by, rejection_note, created_at, updated_at
+               submitted_at, verified_at, verified_by, rejection_note, revision_note,
+               approval_workflow_id, current_step, version, created_at, updated_at
         FROM achievement_references
         WHERE id = $1
     `
@@ -66,7 +97,8 @@ func (r *achievementReferenceRepository) FindByID(ctx context.Context, id uuid.U
 func (r *achievementReferenceRepository) GetByMongoID(ctx context.Context, mongoID string) (*models.AchievementReference, error) {
 	query := `
         SELECT id, student_id, mongo_achievement_id, status, 
-               submitted_at, verified_at, verified_by, rejection_note, created_at, updated_at
+               submitted_at, verified_at, verified_by, rejection_note, revision_note,
+               approval_workflow_id, current_step, version, created_at, updated_at
         FROM achievement_references
         WHERE mongo_achievement_id = $1
     `
@@ -77,11 +109,14 @@ func (r *achievementReferenceRepository) scanRow(ctx context.Context, query stri
 	var ref models.AchievementReference
 	var submittedAt, verifiedAt sql.NullTime
 	var verifiedBy sql.NullString
-	var rejectionNote sql.NullString
+	var rejectionNote, revisionNote sql.NullString
+	var workflowID uuid.NullUUID
+	var currentStep sql.NullInt64
 
 	err := r.db.QueryRowContext(ctx, query, arg).Scan(
 		&ref.ID, &ref.StudentID, &ref.MongoAchievementID, &ref.Status,
-		&submittedAt, &verifiedAt, &verifiedBy, &rejectionNote, &ref.CreatedAt, &ref.UpdatedAt,
+		&submittedAt, &verifiedAt, &verifiedBy, &rejectionNote, &revisionNote,
+		&workflowID, &currentStep, &ref.Version, &ref.CreatedAt, &ref.UpdatedAt,
 	)
 
 	if err != nil {
@@ -103,64 +138,280 @@ func (r *achievementReferenceRepository) scanRow(ctx context.Context, query stri
 	if rejectionNote.Valid {
 		ref.RejectionNote = &rejectionNote.String
 	}
+	if revisionNote.Valid {
+		ref.RevisionNote = &revisionNote.String
+	}
+	if workflowID.Valid {
+		ref.ApprovalWorkflowID = &workflowID.UUID
+	}
+	if currentStep.Valid {
+		step := int(currentStep.Int64)
+		ref.CurrentStep = &step
+	}
 
 	return &ref, nil
 }
 
-func (r *achievementReferenceRepository) UpdateStatus(
-	ctx context.Context,
-	mongoID string,
-	status models.AchievementStatus,
-) error {
-
-	var query string
+// Submit mengajukan prestasi dan memulai workflow persetujuan dari langkah
+// pertama; workflowID nil berarti verifikasi satu langkah.
+func (r *achievementReferenceRepository) Submit(ctx context.Context, mongoID string, actorID uuid.UUID, workflowID *uuid.UUID, expectedVersion *int) error {
+	query := `
+        UPDATE achievement_references
+        SET status = $2, submitted_at = NOW(), updated_at = NOW(),
+            approval_workflow_id = $3::uuid,
+            current_step = CASE WHEN $3::uuid IS NULL THEN NULL ELSE 1 END
+        WHERE id = $1
+    `
+	return r.transition(ctx, mongoID, models.ActionSubmit, &actorID, nil, nil, nil, expectedVersion,
+		query, models.StatusSubmitted, workflowID)
+}
 
-	if status == models.StatusSubmitted {
+// ApproveStep mencatat persetujuan langkah step dan memajukan prestasi ke
+// langkah berikutnya; status tetap submitted.
+func (r *achievementReferenceRepository) ApproveStep(ctx context.Context, mongoID string, step int, approverID uuid.UUID, note *string, points *float64, override *models.PointsOverride, expectedVersion *int) error {
+	query := `
+        UPDATE achievement_references
+        SET current_step = current_step + 1, updated_at = NOW()
+        WHERE id = $1 AND current_step = $2
+    `
+	return r.transition(ctx, mongoID, models.ActionApproveStep, &approverID, note, points, override, expectedVersion,
+		query, step)
+}
 
-		query = `
-            UPDATE achievement_references
-            SET 
-                status = $1,
-                submitted_at = NOW(),  
-                updated_at = NOW()
-            WHERE mongo_achievement_id = $2
-            AND status = 'draft'
-        `
-	} else {
-		query = `
+// Verify menyetujui langkah terakhir (step nil untuk verifikasi satu
+// langkah) sehingga prestasi berstatus verified. Poin final dicatat di
+// achievement_points_sync dalam transaksi yang sama sampai berhasil ditulis
+// ke MongoDB.
+func (r *achievementReferenceRepository) Verify(ctx context.Context, mongoID string, verifierID uuid.UUID, points float64, step *int, override *models.PointsOverride, expectedVersion *int) error {
+	query := `
+        WITH verified AS (
             UPDATE achievement_references
-            SET 
-                status = $1,
-                updated_at = NOW()
-            WHERE mongo_achievement_id = $2
-            AND status = 'draft'
-        `
+            SET status = 'verified', verified_at = NOW(), verified_by = $2, updated_at = NOW()
+            WHERE id = $1 AND current_step IS NOT DISTINCT FROM $3::int
+            RETURNING id, mongo_achievement_id
+        )
+        INSERT INTO achievement_points_sync (achievement_reference_id, mongo_achievement_id, points)
+        SELECT id, mongo_achievement_id, $4 FROM verified
+        ON CONFLICT (achievement_reference_id) DO UPDATE SET
+            points = EXCLUDED.points, attempts = 0, last_error = NULL, updated_at = NOW()
+    `
+	return r.transition(ctx, mongoID, models.ActionVerify, &verifierID, nil, &points, override, expectedVersion,
+		query, verifierID, step, points)
+}
+
+// ListPendingPointsSync mengembalikan poin yang belum berhasil ditulis ke
+// MongoDB, dari yang terlama.
+func (r *achievementReferenceRepository) ListPendingPointsSync(ctx context.Context, limit int) ([]models.PendingPointsSync, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT achievement_reference_id, mongo_achievement_id, points, attempts, last_error, created_at
+        FROM achievement_points_sync
+        ORDER BY created_at
+        LIMIT $1
+    `, limit)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
+
+	pending := []models.PendingPointsSync{}
+	for rows.Next() {
+		var p models.PendingPointsSync
+		var lastError sql.NullString
+		if err := rows.Scan(
+			&p.AchievementReferenceID, &p.MongoAchievementID, &p.Points, &p.Attempts, &lastError, &p.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if lastError.Valid {
+			p.LastError = &lastError.String
+		}
+		pending = append(pending, p)
+	}
+	return pending, rows.Err()
+}
 
-	_, err := r.db.ExecContext(ctx, query, status, mongoID)
+func (r *achievementReferenceRepository) CompletePointsSync(ctx context.Context, referenceID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM achievement_points_sync WHERE achievement_reference_id = $1`, referenceID)
 	return err
 }
 
-func (r *achievementReferenceRepository) Verify(ctx context.Context, mongoID string, verifierID uuid.UUID) error {
+func (r *achievementReferenceRepository) FailPointsSync(ctx context.Context, referenceID uuid.UUID, reason string) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE achievement_points_sync
+        SET attempts = attempts + 1, last_error = $2, updated_at = NOW()
+        WHERE achievement_reference_id = $1
+    `, referenceID, reason)
+	return err
+}
+
+func (r *achievementReferenceRepository) Reject(ctx context.Context, mongoID string, note string, actorID uuid.UUID, expectedVersion *int) error {
 	query := `
         UPDATE achievement_references 
-        SET status = 'verified', verified_at = NOW(), verified_by = $1, updated_at = NOW()
-        WHERE mongo_achievement_id = $2
+        SET status = 'rejected', rejection_note = $2, updated_at = NOW()
+        WHERE id = $1
     `
-	_, err := r.db.ExecContext(ctx, query, verifierID, mongoID)
-	return err
+	return r.transition(ctx, mongoID, models.ActionReject, &actorID, &note, nil, nil, expectedVersion,
+		query, note)
 }
 
-func (r *achievementReferenceRepository) Reject(ctx context.Context, mongoID string, note string) error {
+func (r *achievementReferenceRepository) RequestRevision(ctx context.Context, mongoID string, note string, actorID uuid.UUID, expectedVersion *int) error {
 	query := `
         UPDATE achievement_references 
-        SET status = 'rejected', rejection_note = $1, updated_at = NOW()
-        WHERE mongo_achievement_id = $2
+        SET status = 'revision_requested', revision_note = $2, updated_at = NOW()
+        WHERE id = $1
     `
-	_, err := r.db.ExecContext(ctx, query, note, mongoID)
+	return r.transition(ctx, mongoID, models.ActionRequestRevision, &actorID, &note, nil, nil, expectedVersion,
+		query, note)
+}
+
+// transition mengunci baris prestasi, memeriksa action terhadap tabel
+// transisi dengan status terkini, menjalankan update ($1 = id referensi),
+// menaikkan versi, lalu mencatat riwayat dan override poin (jika ada) dalam
+// transaksi yang sama. expectedVersion nil berarti tanpa pemeriksaan versi.
+func (r *achievementReferenceRepository) transition(
+	ctx context.Context,
+	mongoID string,
+	action models.AchievementAction,
+	actorID *uuid.UUID,
+	note *string,
+	points *float64,
+	override *models.PointsOverride,
+	expectedVersion *int,
+	update string,
+	args ...interface{},
+) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var refID uuid.UUID
+	var current models.AchievementStatus
+	var version int
+	err = tx.QueryRowContext(ctx,
+		`SELECT id, status, version FROM achievement_references WHERE mongo_achievement_id = $1 FOR UPDATE`,
+		mongoID,
+	).Scan(&refID, &current, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("achievement reference not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if expectedVersion != nil && *expectedVersion != version {
+		return ErrVersionConflict
+	}
+
+	to, err := models.NextAchievementStatus(current, action)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, update, append([]interface{}{refID}, args...)...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrApprovalStepChanged
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE achievement_references SET version = version + 1 WHERE id = $1`, refID,
+	); err != nil {
+		return err
+	}
+
+	if err := insertStatusHistoryTx(ctx, tx, refID, &current, to, actorID, note, points); err != nil {
+		return err
+	}
+
+	if override != nil {
+		override.AchievementReferenceID = refID
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO achievement_points_overrides (
+                id, achievement_reference_id, rubric_id, suggested_points, awarded_points,
+                justification, actor_id, created_at
+            ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        `, override.ID, refID, override.RubricID, override.SuggestedPoints, override.AwardedPoints,
+			override.Justification, override.ActorID, override.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertStatusHistoryTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	referenceID uuid.UUID,
+	from *models.AchievementStatus,
+	to models.AchievementStatus,
+	actorID *uuid.UUID,
+	note *string,
+	points *float64,
+) error {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO achievement_status_history (
+            id, achievement_reference_id, from_status, to_status, actor_id, note, points, created_at
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+    `, uuid.New(), referenceID, from, to, actorID, note, points)
 	return err
 }
 
+// GetHistory mengembalikan seluruh riwayat status, dari yang terlama.
+func (r *achievementReferenceRepository) GetHistory(ctx context.Context, referenceID uuid.UUID) ([]models.AchievementStatusHistory, error) {
+	query := `
+        SELECT h.id, h.achievement_reference_id, h.from_status, h.to_status, h.actor_id,
+               COALESCE(u.full_name, ''), COALESCE(r.name, ''), h.note, h.points, h.created_at
+        FROM achievement_status_history h
+        LEFT JOIN users u ON u.id = h.actor_id
+        LEFT JOIN roles r ON r.id = u.role_id
+        WHERE h.achievement_reference_id = $1
+        ORDER BY h.created_at, h.id
+    `
+	rows, err := r.db.QueryContext(ctx, query, referenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.AchievementStatusHistory{}
+	for rows.Next() {
+		var h models.AchievementStatusHistory
+		var from, note sql.NullString
+		var actorID uuid.NullUUID
+		var points sql.NullFloat64
+		if err := rows.Scan(
+			&h.ID, &h.AchievementReferenceID, &from, &h.ToStatus, &actorID,
+			&h.ActorName, &h.ActorRole, &note, &points, &h.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if from.Valid {
+			st := models.AchievementStatus(from.String)
+			h.FromStatus = &st
+		}
+		if actorID.Valid {
+			h.ActorID = &actorID.UUID
+		}
+		if note.Valid {
+			h.Note = &note.String
+		}
+		if points.Valid {
+			h.Points = &points.Float64
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
 func (r *achievementReferenceRepository) GetByStudentID(ctx context.Context, studentID uuid.UUID, limit, offset int) ([]models.AchievementReference, error) {
 	query := `
         SELECT id, mongo_achievement_id, status, updated_at
@@ -189,12 +440,109 @@ func (r *achievementReferenceRepository) GetByStudentID(ctx context.Context, stu
 func (r *achievementReferenceRepository) SoftDeleteByMongoID(
 	ctx context.Context,
 	mongoID string,
+	actorID uuid.UUID,
 ) error {
 	query := `
 		UPDATE achievement_references
 		SET status = 'deleted', updated_at = NOW()
-		WHERE mongo_achievement_id = $1
+		WHERE id = $1
 	`
-	_, err := r.db.ExecContext(ctx, query, mongoID)
-	return err
+	return r.transition(ctx, mongoID, models.ActionDelete, &actorID, nil, nil, nil, nil,
+		query)
+}
+
+// queueSortColumns memetakan urutan antrean yang kuncinya ada di PostgreSQL
+// ke ekspresi SQL dan tipe cursor-nya.
+var queueSortColumns = map[string]struct{ expr, cast string }{
+	models.QueueSortSubmittedAt: {"COALESCE(ar.submitted_at, ar.updated_at)", "timestamp"},
+	models.QueueSortStudent:     {"LOWER(u.full_name)", "text"},
+}
+
+// IsSQLQueueSort melaporkan apakah urutan antrean bisa dipaginasi di SQL.
+func IsSQLQueueSort(sort string) bool {
+	_, ok := queueSortColumns[sort]
+	return ok
+}
+
+// GetVerificationQueue mengembalikan prestasi submitted milik mahasiswa
+// bimbingan advisorUserID yang langkah persetujuannya menunggu dosen wali.
+// Untuk urutan submittedAt dan student, hasil diurutkan menurut (kunci, id),
+// dimulai setelah cursor after dan dibatasi limit. Urutan lain (kunci dari
+// MongoDB) mengembalikan semua baris tanpa urutan; limit <= 0 berarti tanpa
+// batas.
+func (r *achievementReferenceRepository) GetVerificationQueue(
+	ctx context.Context,
+	advisorUserID uuid.UUID,
+	role string,
+	filter models.VerificationQueueFilter,
+	after *models.QueueCursor,
+	limit int,
+) ([]models.VerificationQueueItem, error) {
+
+	sortExpr, cast := "''", "text"
+	col, sorted := queueSortColumns[filter.Sort]
+	if sorted {
+		sortExpr, cast = col.expr, col.cast
+	}
+
+	query := `
+        SELECT ar.id, ar.mongo_achievement_id, ar.student_id, s.student_id, u.full_name,
+               COALESCE(ar.submitted_at, ar.updated_at), ar.current_step, (` + sortExpr + `)::text
+        FROM achievement_references ar
+        JOIN students s ON s.id = ar.student_id
+        JOIN lecturers l ON l.id = s.advisor_id
+        JOIN users u ON u.id = s.user_id
+        LEFT JOIN approval_workflow_steps ws
+               ON ws.workflow_id = ar.approval_workflow_id AND ws.step_order = ar.current_step
+        WHERE l.user_id = $1
+          AND ar.status = 'submitted'
+          AND (ws.workflow_id IS NULL
+               OR ((ws.approver_role IS NULL OR ws.approver_role = $2)
+                   AND (ws.approver_scope IS NULL OR ws.approver_scope = 'advisor')))
+          AND ($3::uuid IS NULL OR ar.student_id = $3::uuid)
+          AND ($4::int IS NULL OR COALESCE(ar.submitted_at, ar.updated_at) <= NOW() - make_interval(days => $4::int))
+          AND ($5::int IS NULL OR COALESCE(ar.submitted_at, ar.updated_at) > NOW() - make_interval(days => $5::int + 1))
+    `
+	args := []interface{}{advisorUserID, role, filter.StudentID, filter.MinDays, filter.MaxDays}
+
+	if sorted {
+		cmp, dir := ">", "ASC"
+		if filter.Desc {
+			cmp, dir = "<", "DESC"
+		}
+		if after != nil {
+			args = append(args, after.Key, after.ID)
+			query += fmt.Sprintf(" AND (%s, ar.id) %s ($%d::%s, $%d::uuid)",
+				sortExpr, cmp, len(args)-1, cast, len(args))
+		}
+		query += fmt.Sprintf(" ORDER BY %s %s, ar.id %s", sortExpr, dir, dir)
+		if limit > 0 {
+			args = append(args, limit)
+			query += fmt.Sprintf(" LIMIT $%d", len(args))
+		}
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.VerificationQueueItem{}
+	for rows.Next() {
+		var item models.VerificationQueueItem
+		var currentStep sql.NullInt64
+		if err := rows.Scan(
+			&item.ID, &item.MongoAchievementID, &item.StudentID, &item.StudentNIM, &item.StudentName,
+			&item.SubmittedAt, &currentStep, &item.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		if currentStep.Valid {
+			step := int(currentStep.Int64)
+			item.CurrentStep = &step
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
 }
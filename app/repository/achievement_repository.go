@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"backend/app/models"
@@ -9,15 +10,22 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AchievementRepository interface {
 	Create(ctx context.Context, achievement *models.Achievement) (string, error)
 	FindByID(ctx context.Context, id string) (*models.Achievement, error)
+	FindSummaries(ctx context.Context, query models.AchievementSummaryQuery) ([]models.Achievement, error)
 	AddAttachment(ctx context.Context, id string, attachment models.Attachment) error
 	UpdatePoints(ctx context.Context, id string, points float64) error
-	Update(ctx context.Context, id string, achievement *models.Achievement) error
+	Update(ctx context.Context, id string, achievement *models.Achievement, expectedVersion *int64) error
+	ClaimVersion(ctx context.Context, id string, expectedVersion int64) error
+	ReleaseVersion(ctx context.Context, id string, claimedVersion int64) error
 	SoftDelete(ctx context.Context, id string) error
+	DistinctValues(ctx context.Context, field string) ([]string, error)
+	CountFieldValue(ctx context.Context, field, value string) (int64, error)
+	ReplaceFieldValue(ctx context.Context, field, from, to string) (int64, error)
 }
 
 type achievementRepository struct {
@@ -34,6 +42,7 @@ func (r *achievementRepository) Create(ctx context.Context, achievement *models.
 	achievement.ID = primitive.NewObjectID()
 	achievement.CreatedAt = time.Now()
 	achievement.UpdatedAt = time.Now()
+	achievement.Version = 1
 
 	if achievement.Points == 0 {
 		achievement.Points = 0
@@ -58,12 +67,86 @@ func (r *achievementRepository) FindByID(ctx context.Context, id string) (*model
 	return &achievement, err
 }
 
+// ErrInvalidQueueCursor dikembalikan saat cursor antrean tidak bisa dipakai.
+var ErrInvalidQueueCursor = errors.New("invalid cursor")
+
+// summaryCollation membuat filter tipe/tingkat dan urutan judul/tipe tidak
+// membedakan huruf besar-kecil.
+var summaryCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// FindSummaries mengambil ringkasan (judul, tipe, tingkat) prestasi dengan id
+// di query.IDs; id yang tidak valid atau sudah dihapus dilewati. Filter tipe
+// dan tingkat diterapkan di query. Jika SortField diisi, hasil diurutkan
+// menurut (SortField, _id) dan dipotong setelah cursor sebanyak Limit.
+func (r *achievementRepository) FindSummaries(ctx context.Context, query models.AchievementSummaryQuery) ([]models.Achievement, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(query.IDs))
+	for _, id := range query.IDs {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return []models.Achievement{}, nil
+	}
+
+	filter := bson.M{
+		"_id":        bson.M{"$in": objIDs},
+		"is_deleted": bson.M{"$ne": true},
+	}
+	if query.AchievementType != "" {
+		filter["achievementType"] = query.AchievementType
+	}
+	if query.CompetitionLevel != "" {
+		filter["details.competitionLevel"] = query.CompetitionLevel
+	}
+
+	opts := options.Find().SetCollation(summaryCollation).SetProjection(bson.M{
+		"title":                    1,
+		"achievementType":          1,
+		"studentId":                1,
+		"details.competitionLevel": 1,
+	})
+
+	if query.SortField != "" {
+		dir, cmp := 1, "$gt"
+		if query.Desc {
+			dir, cmp = -1, "$lt"
+		}
+		if query.After != nil {
+			afterID, err := primitive.ObjectIDFromHex(query.After.ID)
+			if err != nil {
+				return nil, ErrInvalidQueueCursor
+			}
+			filter["$or"] = bson.A{
+				bson.M{query.SortField: bson.M{cmp: query.After.Key}},
+				bson.M{query.SortField: query.After.Key, "_id": bson.M{cmp: afterID}},
+			}
+		}
+		opts.SetSort(bson.D{{Key: query.SortField, Value: dir}, {Key: "_id", Value: dir}})
+		if query.Limit > 0 {
+			opts.SetLimit(int64(query.Limit))
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	achievements := []models.Achievement{}
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
 func (r *achievementRepository) AddAttachment(ctx context.Context, id string, attachment models.Attachment) error {
 	objID, _ := primitive.ObjectIDFromHex(id)
 	filter := bson.M{"_id": objID}
 	update := bson.M{
 		"$push": bson.M{"attachments": attachment},
 		"$set":  bson.M{"updatedAt": time.Now()},
+		"$inc":  bson.M{"version": 1},
 	}
 	_, err := r.collection.UpdateOne(ctx, filter, update)
 	return err
@@ -80,16 +163,22 @@ func (r *achievementRepository) UpdatePoints(ctx context.Context, id string, poi
 			"points":    points,
 			"updatedAt": time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
 	return err
 }
 
+// Update menulis isi prestasi, menaikkan versinya dan mengisi
+// achievement.Version dengan versi baru. Jika expectedVersion diisi, dokumen
+// hanya ditulis bila versinya masih sama; dokumen lama tanpa field version
+// dianggap versi 0.
 func (r *achievementRepository) Update(
 	ctx context.Context,
 	id string,
 	achievement *models.Achievement,
+	expectedVersion *int64,
 ) error {
 
 	objID, err := primitive.ObjectIDFromHex(id)
@@ -98,22 +187,85 @@ func (r *achievementRepository) Update(
 	}
 
 	filter := bson.M{"_id": objID}
+	if expectedVersion != nil {
+		filter["version"] = versionFilter(*expectedVersion)
+	}
 
+	// Hanya field yang boleh diubah mahasiswa; poin, lampiran dan pemilik
+	// punya jalur update sendiri.
 	update := bson.M{
 		"$set": bson.M{
-			"title":       achievement.Title,
-			"description": achievement.Description,
-			"details":     achievement.Details,
-			"tags":        achievement.Tags,
-			"points":      achievement.Points,
-			"updatedAt":   achievement.UpdatedAt,
+			"achievementType": achievement.AchievementType,
+			"title":           achievement.Title,
+			"description":     achievement.Description,
+			"details":         achievement.Details,
+			"customFields":    achievement.CustomFields,
+			"tags":            achievement.Tags,
+			"updatedAt":       achievement.UpdatedAt,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"version": 1})
+
+	var saved models.Achievement
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved)
+	if errors.Is(err, mongo.ErrNoDocuments) && expectedVersion != nil {
+		return ErrVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+	achievement.Version = saved.Version
+	return nil
+}
+
+// ClaimVersion menaikkan versi dokumen hanya bila versinya masih
+// expectedVersion. Dipakai sebelum transisi status di Postgres agar edit
+// yang masuk setelah ETag diperiksa gagal dengan konflik versi.
+func (r *achievementRepository) ClaimVersion(ctx context.Context, id string, expectedVersion int64) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objID, "version": versionFilter(expectedVersion)}
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"version": 1}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// ReleaseVersion membatalkan ClaimVersion dari claimedVersion saat transisi
+// di Postgres gagal, agar ETag yang dipegang klien tetap berlaku. Jika
+// dokumen sudah ditulis lagi setelah diklaim, versinya dibiarkan.
+func (r *achievementRepository) ReleaseVersion(ctx context.Context, id string, claimedVersion int64) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objID, "version": claimedVersion + 1}
+	update := bson.M{"$set": bson.M{"version": claimedVersion}}
 	_, err = r.collection.UpdateOne(ctx, filter, update)
 	return err
 }
 
+// versionFilter mencocokkan versi dokumen; dokumen lama tanpa field version
+// dianggap versi 0.
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return version
+}
+
 func (r *achievementRepository) SoftDelete(
 	ctx context.Context,
 	id string,
@@ -132,8 +284,44 @@ func (r *achievementRepository) SoftDelete(
 			"deletedAt":  now,
 			"updatedAt":  now,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
 	return err
 }
+
+// DistinctValues mengembalikan semua nilai string sebuah field di seluruh
+// dokumen, termasuk yang sudah dihapus.
+func (r *achievementRepository) DistinctValues(ctx context.Context, field string) ([]string, error) {
+	raw, err := r.collection.Distinct(ctx, field, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values, nil
+}
+
+func (r *achievementRepository) CountFieldValue(ctx context.Context, field, value string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{field: value})
+}
+
+// ReplaceFieldValue mengganti nilai sebuah field di semua dokumen tanpa
+// mengubah updatedAt, karena bukan perubahan oleh pengguna. Versi tetap
+// dinaikkan agar ETag yang sudah dibagikan tidak lagi cocok.
+func (r *achievementRepository) ReplaceFieldValue(ctx context.Context, field, from, to string) (int64, error) {
+	res, err := r.collection.UpdateMany(ctx, bson.M{field: from}, bson.M{
+		"$set": bson.M{field: to},
+		"$inc": bson.M{"version": 1},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type AchievementTypeSchemaRepository interface {
+	FindAll(ctx context.Context) ([]models.AchievementTypeSchema, error)
+	FindByType(ctx context.Context, achievementType string) (*models.AchievementTypeSchema, error)
+	Save(ctx context.Context, schema *models.AchievementTypeSchema) error
+	Delete(ctx context.Context, achievementType string) error
+}
+
+type achievementTypeSchemaRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementTypeSchemaRepository(db *sql.DB) AchievementTypeSchemaRepository {
+	return &achievementTypeSchemaRepository{db: db}
+}
+
+const achievementTypeSchemaColumns = `
+        SELECT achievement_type, allow_unknown_custom_fields, updated_by, updated_at
+        FROM achievement_type_schemas`
+
+func (r *achievementTypeSchemaRepository) FindAll(ctx context.Context) ([]models.AchievementTypeSchema, error) {
+	rows, err := r.db.QueryContext(ctx, achievementTypeSchemaColumns+`
+        ORDER BY achievement_type`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schemas := []models.AchievementTypeSchema{}
+	for rows.Next() {
+		var s models.AchievementTypeSchema
+		if err := scanAchievementTypeSchema(rows, &s); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range schemas {
+		if err := r.loadFields(ctx, &schemas[i]); err != nil {
+			return nil, err
+		}
+	}
+	return schemas, nil
+}
+
+// FindByType mencari skema tanpa membedakan huruf besar/kecil; nil jika
+// tipe belum punya skema.
+func (r *achievementTypeSchemaRepository) FindByType(ctx context.Context, achievementType string) (*models.AchievementTypeSchema, error) {
+	var s models.AchievementTypeSchema
+	err := scanAchievementTypeSchema(r.db.QueryRowContext(ctx, achievementTypeSchemaColumns+`
+        WHERE achievement_type = LOWER($1)`, achievementType), &s)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadFields(ctx, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanAchievementTypeSchema(row interface{ Scan(...interface{}) error }, s *models.AchievementTypeSchema) error {
+	var updatedBy uuid.NullUUID
+	if err := row.Scan(&s.AchievementType, &s.AllowUnknownCustomFields, &updatedBy, &s.UpdatedAt); err != nil {
+		return err
+	}
+	if updatedBy.Valid {
+		s.UpdatedBy = &updatedBy.UUID
+	}
+	return nil
+}
+
+func (r *achievementTypeSchemaRepository) loadFields(ctx context.Context, s *models.AchievementTypeSchema) error {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT section, name, label, field_type, required, enum_values, format, min_value, max_value
+        FROM achievement_type_schema_fields
+        WHERE achievement_type = $1
+        ORDER BY section, sort_order, name`, s.AchievementType)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	s.Details = []models.FieldSpec{}
+	s.CustomFields = []models.FieldSpec{}
+	for rows.Next() {
+		var section string
+		var f models.FieldSpec
+		var format sql.NullString
+		var min, max sql.NullFloat64
+		if err := rows.Scan(&section, &f.Name, &f.Label, &f.Type, &f.Required, pq.Array(&f.Enum), &format, &min, &max); err != nil {
+			return err
+		}
+		f.Format = format.String
+		if min.Valid {
+			f.Min = &min.Float64
+		}
+		if max.Valid {
+			f.Max = &max.Float64
+		}
+
+		if section == models.SchemaSectionDetails {
+			s.Details = append(s.Details, f)
+		} else {
+			s.CustomFields = append(s.CustomFields, f)
+		}
+	}
+	return rows.Err()
+}
+
+// Save membuat atau mengganti skema beserta seluruh fieldnya dalam satu
+// transaksi.
+func (r *achievementTypeSchemaRepository) Save(ctx context.Context, s *models.AchievementTypeSchema) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO achievement_type_schemas (achievement_type, allow_unknown_custom_fields, updated_by, updated_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (achievement_type) DO UPDATE SET
+            allow_unknown_custom_fields = EXCLUDED.allow_unknown_custom_fields,
+            updated_by = EXCLUDED.updated_by,
+            updated_at = EXCLUDED.updated_at`,
+		s.AchievementType, s.AllowUnknownCustomFields, s.UpdatedBy, s.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        DELETE FROM achievement_type_schema_fields WHERE achievement_type = $1`, s.AchievementType); err != nil {
+		return err
+	}
+
+	for _, section := range []struct {
+		name   string
+		fields []models.FieldSpec
+	}{
+		{models.SchemaSectionDetails, s.Details},
+		{models.SchemaSectionCustomFields, s.CustomFields},
+	} {
+		for i, f := range section.fields {
+			var format *string
+			if f.Format != "" {
+				format = &f.Format
+			}
+			if _, err := tx.ExecContext(ctx, `
+                INSERT INTO achievement_type_schema_fields (
+                    achievement_type, section, name, label, field_type, required,
+                    enum_values, format, min_value, max_value, sort_order
+                ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+				s.AchievementType, section.name, f.Name, f.Label, f.Type, f.Required,
+				pq.Array(f.Enum), format, f.Min, f.Max, i+1,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *achievementTypeSchemaRepository) Delete(ctx context.Context, achievementType string) error {
+	_, err := r.db.ExecContext(ctx, `
+        DELETE FROM achievement_type_schemas WHERE achievement_type = LOWER($1)`, achievementType)
+	return err
+}
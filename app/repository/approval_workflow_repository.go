@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrApprovalWorkflowExists dikembalikan saat sudah ada workflow untuk
+// kombinasi achievementType dan competitionLevel yang sama.
+var ErrApprovalWorkflowExists = errors.New("approval workflow for this type and level already exists")
+
+// ErrApprovalWorkflowInUse dikembalikan saat workflow yang masih dipakai
+// prestasi berstatus submitted akan dihapus atau diubah langkahnya.
+var ErrApprovalWorkflowInUse = errors.New("approval workflow is in use by submitted achievements")
+
+type ApprovalWorkflowRepository interface {
+	FindAll(ctx context.Context) ([]models.ApprovalWorkflow, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.ApprovalWorkflow, error)
+	Match(ctx context.Context, achievementType, competitionLevel string) (*models.ApprovalWorkflow, error)
+	Save(ctx context.Context, workflow *models.ApprovalWorkflow) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CountInProgress(ctx context.Context, id uuid.UUID) (int, error)
+	InScope(ctx context.Context, scope string, userID, studentID uuid.UUID) (bool, error)
+}
+
+type approvalWorkflowRepository struct {
+	db *sql.DB
+}
+
+func NewApprovalWorkflowRepository(db *sql.DB) ApprovalWorkflowRepository {
+	return &approvalWorkflowRepository{db: db}
+}
+
+const approvalWorkflowColumns = `
+        SELECT id, name, achievement_type, competition_level, is_active, created_at, updated_at
+        FROM approval_workflows`
+
+func (r *approvalWorkflowRepository) FindAll(ctx context.Context) ([]models.ApprovalWorkflow, error) {
+	rows, err := r.db.QueryContext(ctx, approvalWorkflowColumns+`
+        ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workflows := []models.ApprovalWorkflow{}
+	for rows.Next() {
+		var w models.ApprovalWorkflow
+		if err := scanApprovalWorkflow(rows, &w); err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range workflows {
+		if workflows[i].Steps, err = r.steps(ctx, workflows[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return workflows, nil
+}
+
+func (r *approvalWorkflowRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.ApprovalWorkflow, error) {
+	return r.findOne(ctx, approvalWorkflowColumns+`
+        WHERE id = $1`, id)
+}
+
+// Match memilih workflow aktif yang paling spesifik untuk prestasi: cocok
+// type dan level, lalu type saja, lalu level saja, lalu workflow umum.
+// Mengembalikan nil jika tidak ada yang cocok.
+func (r *approvalWorkflowRepository) Match(ctx context.Context, achievementType, competitionLevel string) (*models.ApprovalWorkflow, error) {
+	return r.findOne(ctx, approvalWorkflowColumns+`
+        WHERE is_active
+          AND (achievement_type IS NULL OR LOWER(achievement_type) = LOWER($1))
+          AND (competition_level IS NULL OR LOWER(competition_level) = LOWER($2))
+        ORDER BY (achievement_type IS NOT NULL) DESC, (competition_level IS NOT NULL) DESC
+        LIMIT 1`, achievementType, competitionLevel)
+}
+
+func (r *approvalWorkflowRepository) findOne(ctx context.Context, query string, args ...interface{}) (*models.ApprovalWorkflow, error) {
+	var w models.ApprovalWorkflow
+	err := scanApprovalWorkflow(r.db.QueryRowContext(ctx, query, args...), &w)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if w.Steps, err = r.steps(ctx, w.ID); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func scanApprovalWorkflow(row interface{ Scan(...interface{}) error }, w *models.ApprovalWorkflow) error {
+	var achievementType, competitionLevel sql.NullString
+	if err := row.Scan(&w.ID, &w.Name, &achievementType, &competitionLevel, &w.IsActive, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return err
+	}
+	if achievementType.Valid {
+		w.AchievementType = &achievementType.String
+	}
+	if competitionLevel.Valid {
+		w.CompetitionLevel = &competitionLevel.String
+	}
+	return nil
+}
+
+func (r *approvalWorkflowRepository) steps(ctx context.Context, workflowID uuid.UUID) ([]models.ApprovalWorkflowStep, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT step_order, name, approver_role, approver_scope
+        FROM approval_workflow_steps
+        WHERE workflow_id = $1
+        ORDER BY step_order`, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	steps := []models.ApprovalWorkflowStep{}
+	for rows.Next() {
+		var st models.ApprovalWorkflowStep
+		var role, scope sql.NullString
+		if err := rows.Scan(&st.StepOrder, &st.Name, &role, &scope); err != nil {
+			return nil, err
+		}
+		if role.Valid {
+			st.ApproverRole = &role.String
+		}
+		if scope.Valid {
+			st.ApproverScope = &scope.String
+		}
+		steps = append(steps, st)
+	}
+	return steps, rows.Err()
+}
+
+// Save menyimpan workflow baru atau mengganti workflow yang ada beserta
+// seluruh langkahnya dalam satu transaksi.
+func (r *approvalWorkflowRepository) Save(ctx context.Context, w *models.ApprovalWorkflow) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO approval_workflows (id, name, achievement_type, competition_level, is_active, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (id) DO UPDATE SET
+            name = EXCLUDED.name,
+            achievement_type = EXCLUDED.achievement_type,
+            competition_level = EXCLUDED.competition_level,
+            is_active = EXCLUDED.is_active,
+            updated_at = EXCLUDED.updated_at`,
+		w.ID, w.Name, w.AchievementType, w.CompetitionLevel, w.IsActive, w.CreatedAt, w.UpdatedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrApprovalWorkflowExists
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM approval_workflow_steps WHERE workflow_id = $1`, w.ID); err != nil {
+		return err
+	}
+
+	for _, st := range w.Steps {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO approval_workflow_steps (workflow_id, step_order, name, approver_role, approver_scope)
+            VALUES ($1, $2, $3, $4, $5)`,
+			w.ID, st.StepOrder, st.Name, st.ApproverRole, st.ApproverScope,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete menghapus workflow yang tidak sedang dipakai prestasi submitted;
+// referensi yang sudah selesai tetap menyimpan riwayatnya tanpa workflow.
+func (r *approvalWorkflowRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked uuid.UUID
+	err = tx.QueryRowContext(ctx,
+		`SELECT id FROM approval_workflows WHERE id = $1 FOR UPDATE`, id,
+	).Scan(&locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("approval workflow not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	var inUse bool
+	if err := tx.QueryRowContext(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM achievement_references
+            WHERE approval_workflow_id = $1 AND status = 'submitted'
+        )`, id,
+	).Scan(&inUse); err != nil {
+		return err
+	}
+	if inUse {
+		return ErrApprovalWorkflowInUse
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM approval_workflows WHERE id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CountInProgress menghitung prestasi submitted yang sedang berjalan di
+// workflow id.
+func (r *approvalWorkflowRepository) CountInProgress(ctx context.Context, id uuid.UUID) (int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM achievement_references
+        WHERE approval_workflow_id = $1 AND status = 'submitted'`, id,
+	).Scan(&total)
+	return total, err
+}
+
+// InScope memeriksa apakah user termasuk scope penyetuju untuk mahasiswa.
+func (r *approvalWorkflowRepository) InScope(ctx context.Context, scope string, userID, studentID uuid.UUID) (bool, error) {
+	var query string
+	switch scope {
+	case models.ApproverScopeAdvisor:
+		query = `
+            SELECT EXISTS (
+                SELECT 1 FROM students s
+                JOIN lecturers l ON l.id = s.advisor_id
+                WHERE l.user_id = $1 AND s.id = $2
+            )`
+	case models.ApproverScopeProgram:
+		query = `
+            SELECT EXISTS (
+                SELECT 1 FROM students s
+                JOIN lecturers l ON LOWER(l.department) = LOWER(s.program_study)
+                WHERE l.user_id = $1 AND s.id = $2
+            )`
+	default:
+		return false, nil
+	}
+
+	var ok bool
+	err := r.db.QueryRowContext(ctx, query, userID, studentID).Scan(&ok)
+	return ok, err
+}
@@ -4,18 +4,42 @@ import (
 	"backend/app/models"
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrRefreshTokenRevoked dikembalikan saat token yang akan dirotasi ternyata
+// sudah dipakai (revoked) oleh request lain.
+var ErrRefreshTokenRevoked = errors.New("refresh token already revoked")
+
+// ErrPasswordResetTokenUsed dikembalikan saat token reset password sudah
+// dipakai oleh request lain.
+var ErrPasswordResetTokenUsed = errors.New("password reset token already used")
+
 type AuthRepository interface {
 	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	GetPermissionsByRoleID(ctx context.Context, roleID uuid.UUID) ([]string, error)
 	GetStudentIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
 	GetLecturerIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
 	StoreRefreshToken(ctx context.Context, token models.RefreshToken) error
 	GetRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error)
 	DeleteRefreshToken(ctx context.Context, token string) error
+	RotateRefreshToken(ctx context.Context, oldTokenID uuid.UUID, newToken models.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeUserSession(ctx context.Context, userID uuid.UUID, familyID uuid.UUID) (bool, error)
+	RevokeAllRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error)
+	RecordLoginAttempt(ctx context.Context, attempt models.LoginAttempt) error
+	CountFailedLoginsByIP(ctx context.Context, ip string, since time.Time) (int, error)
+	RegisterFailedLogin(ctx context.Context, userID uuid.UUID, maxAttempts int, lockDuration time.Duration) (*models.User, error)
+	ResetFailedLogins(ctx context.Context, userID uuid.UUID) error
+	CreatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, passwordHash string) error
+	RehashPassword(ctx context.Context, userID uuid.UUID, oldHash string, newHash string) error
 	FindByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 }
 
@@ -29,16 +53,43 @@ func NewAuthRepository(db *sql.DB) AuthRepository {
 
 func (r *authRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
 	query := `
-        SELECT u.id, u.username, u.email, u.password_hash, u.full_name, u.role_id, u.is_active, r.name as role_name
+        SELECT u.id, u.username, u.email, u.password_hash, u.full_name, u.role_id, u.is_active, r.name as role_name,
+               u.failed_login_attempts, u.last_failed_login_at, u.locked_until
         FROM users u
         JOIN roles r ON u.role_id = r.id
         WHERE u.username = $1 AND u.is_active = true
     `
 	var user models.User
+	var lastFailed, lockedUntil sql.NullTime
 	// Scan harus sesuai urutan query SELECT
 	err := r.db.QueryRowContext(ctx, query, username).Scan(
 		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
 		&user.FullName, &user.RoleID, &user.IsActive, &user.RoleName,
+		&user.FailedLoginAttempts, &lastFailed, &lockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if lastFailed.Valid {
+		user.LastFailedLoginAt = &lastFailed.Time
+	}
+	if lockedUntil.Valid {
+		user.LockedUntil = &lockedUntil.Time
+	}
+	return &user, nil
+}
+
+func (r *authRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	query := `
+        SELECT u.id, u.username, u.email, u.password_hash, u.full_name, u.role_id, u.is_active, r.name as role_name
+        FROM users u
+        JOIN roles r ON u.role_id = r.id
+        WHERE LOWER(u.email) = LOWER($1) AND u.is_active = true
+    `
+	var user models.User
+	err := r.db.QueryRowContext(ctx, query, email).Scan(
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
+		&user.FullName, &user.RoleID, &user.IsActive, &user.RoleName,
 	)
 	if err != nil {
 		return nil, err
@@ -93,21 +144,239 @@ func (r *authRepository) GetLecturerIDByUserID(
 }
 
 func (r *authRepository) StoreRefreshToken(ctx context.Context, token models.RefreshToken) error {
-	query := `INSERT INTO refresh_tokens (id, user_id, token, expires_at, created_at) VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.Token, token.ExpiresAt, token.CreatedAt)
+	query := `
+        INSERT INTO refresh_tokens (id, user_id, family_id, token, expires_at, ip_address, user_agent, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID, token.UserID, token.FamilyID, token.Token, token.ExpiresAt, token.IPAddress, token.UserAgent, token.CreatedAt,
+	)
 	return err
 }
 
 func (r *authRepository) GetRefreshToken(ctx context.Context, tokenStr string) (*models.RefreshToken, error) {
-	query := `SELECT id, user_id, token, expires_at FROM refresh_tokens WHERE token = $1`
+	query := `SELECT id, user_id, family_id, token, expires_at, revoked_at, replaced_by FROM refresh_tokens WHERE token = $1`
 	var token models.RefreshToken
-	err := r.db.QueryRowContext(ctx, query, tokenStr).Scan(&token.ID, &token.UserID, &token.Token, &token.ExpiresAt)
+	var revokedAt sql.NullTime
+	var replacedBy uuid.NullUUID
+	err := r.db.QueryRowContext(ctx, query, tokenStr).Scan(
+		&token.ID, &token.UserID, &token.FamilyID, &token.Token, &token.ExpiresAt, &revokedAt, &replacedBy,
+	)
 	if err != nil {
 		return nil, err
 	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	if replacedBy.Valid {
+		token.ReplacedBy = &replacedBy.UUID
+	}
 	return &token, nil
 }
 
+// RotateRefreshToken menandai token lama sebagai revoked dan menyimpan
+// penggantinya dalam satu transaksi. Jika token lama sudah revoked (misalnya
+// dua request refresh berjalan bersamaan), ErrRefreshTokenRevoked dikembalikan.
+func (r *authRepository) RotateRefreshToken(
+	ctx context.Context,
+	oldTokenID uuid.UUID,
+	newToken models.RefreshToken,
+) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insert := `
+        INSERT INTO refresh_tokens (id, user_id, family_id, token, expires_at, ip_address, user_agent, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	if _, err := tx.ExecContext(ctx, insert,
+		newToken.ID, newToken.UserID, newToken.FamilyID, newToken.Token, newToken.ExpiresAt,
+		newToken.IPAddress, newToken.UserAgent, newToken.CreatedAt,
+	); err != nil {
+		return err
+	}
+
+	revoke := `
+        UPDATE refresh_tokens
+        SET revoked_at = $1, replaced_by = $2
+        WHERE id = $3 AND revoked_at IS NULL
+    `
+	res, err := tx.ExecContext(ctx, revoke, time.Now(), newToken.ID, oldTokenID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrRefreshTokenRevoked
+	}
+
+	return tx.Commit()
+}
+
+func (r *authRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, time.Now(), familyID)
+	return err
+}
+
+// bumpAuthzVersionQuery membuat semua access token user yang sudah terbit
+// ditolak middleware sebagai outdated; sesi yang masih aktif cukup refresh.
+const bumpAuthzVersionQuery = `UPDATE users SET authz_version = authz_version + 1, updated_at = NOW() WHERE id = $1`
+
+// RevokeUserSession mencabut satu family milik userID dan menaikkan
+// authz_version user agar access token sesi itu ikut ditolak. Hasil false
+// berarti sesi tidak ditemukan, bukan milik user, atau sudah tidak aktif.
+func (r *authRepository) RevokeUserSession(ctx context.Context, userID uuid.UUID, familyID uuid.UUID) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND family_id = $3 AND revoked_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, time.Now(), userID, familyID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, bumpAuthzVersionQuery, userID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// RevokeAllRefreshTokens mencabut semua sesi user dan menaikkan
+// authz_version-nya, sehingga access token yang sudah terbit ikut ditolak.
+func (r *authRepository) RevokeAllRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, time.Now(), userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bumpAuthzVersionQuery, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListActiveSessions mengembalikan satu baris per family yang masih punya
+// refresh token aktif. Token aktif terbit saat rotasi terakhir, sehingga
+// created_at-nya dipakai sebagai waktu terakhir sesi digunakan.
+func (r *authRepository) ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	query := `
+        SELECT rt.family_id, f.started_at, rt.created_at, rt.expires_at,
+               COALESCE(rt.ip_address, ''), COALESCE(rt.user_agent, '')
+        FROM refresh_tokens rt
+        JOIN (
+            SELECT family_id, MIN(created_at) AS started_at
+            FROM refresh_tokens
+            WHERE user_id = $1
+            GROUP BY family_id
+        ) f ON f.family_id = rt.family_id
+        WHERE rt.user_id = $1 AND rt.revoked_at IS NULL AND rt.expires_at > NOW()
+        ORDER BY rt.created_at DESC
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		var sess models.Session
+		if err := rows.Scan(
+			&sess.ID, &sess.CreatedAt, &sess.LastUsedAt, &sess.ExpiresAt, &sess.IPAddress, &sess.UserAgent,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *authRepository) RecordLoginAttempt(ctx context.Context, attempt models.LoginAttempt) error {
+	query := `INSERT INTO login_attempts (id, username, ip_address, success, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.ExecContext(ctx, query, attempt.ID, attempt.Username, attempt.IPAddress, attempt.Success, attempt.CreatedAt)
+	return err
+}
+
+func (r *authRepository) CountFailedLoginsByIP(ctx context.Context, ip string, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM login_attempts WHERE ip_address = $1 AND success = false AND created_at >= $2`
+	var total int
+	err := r.db.QueryRowContext(ctx, query, ip, since).Scan(&total)
+	return total, err
+}
+
+// RegisterFailedLogin menaikkan counter gagal login secara atomik dan
+// mengunci akun bila counter mencapai maxAttempts. Jika kunci sebelumnya
+// sudah kedaluwarsa, counter mulai lagi dari 1. Nilai terbaru dikembalikan
+// agar service tahu apakah akun baru saja terkunci.
+func (r *authRepository) RegisterFailedLogin(
+	ctx context.Context,
+	userID uuid.UUID,
+	maxAttempts int,
+	lockDuration time.Duration,
+) (*models.User, error) {
+
+	now := time.Now()
+	query := `
+        UPDATE users
+        SET failed_login_attempts = CASE
+                WHEN locked_until IS NOT NULL AND locked_until <= $1 THEN 1
+                ELSE failed_login_attempts + 1
+            END,
+            last_failed_login_at = $1,
+            locked_until = CASE
+                WHEN locked_until IS NOT NULL AND locked_until <= $1 THEN
+                    CASE WHEN 1 >= $2 THEN $3 ELSE NULL END
+                WHEN failed_login_attempts + 1 >= $2 THEN $3
+                ELSE locked_until
+            END
+        WHERE id = $4
+        RETURNING failed_login_attempts, last_failed_login_at, locked_until
+    `
+	var user models.User
+	var lastFailed, lockedUntil sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, now, maxAttempts, now.Add(lockDuration), userID).Scan(
+		&user.FailedLoginAttempts, &lastFailed, &lockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	user.ID = userID
+	if lastFailed.Valid {
+		user.LastFailedLoginAt = &lastFailed.Time
+	}
+	if lockedUntil.Valid {
+		user.LockedUntil = &lockedUntil.Time
+	}
+	return &user, nil
+}
+
+func (r *authRepository) ResetFailedLogins(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE users SET failed_login_attempts = 0, last_failed_login_at = NULL, locked_until = NULL WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
 func (r *authRepository) DeleteRefreshToken(ctx context.Context, token string) error {
 	query := `DELETE FROM refresh_tokens WHERE token = $1`
 	_, err := r.db.ExecContext(ctx, query, token)
@@ -128,3 +397,96 @@ func (r *authRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Us
 	}
 	return &user, nil
 }
+
+func (r *authRepository) CreatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error {
+	query := `INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+func (r *authRepository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	query := `SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM password_reset_tokens WHERE token_hash = $1`
+	var token models.PasswordResetToken
+	var usedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &usedAt, &token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return &token, nil
+}
+
+// RehashPassword mengganti hash password yang sama dengan cost baru. Hash
+// hanya diganti jika belum berubah sejak dibaca, dan tidak masuk riwayat.
+func (r *authRepository) RehashPassword(ctx context.Context, userID uuid.UUID, oldHash string, newHash string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE users SET password_hash = $1 WHERE id = $2 AND password_hash = $3`,
+		newHash, userID, oldHash,
+	)
+	return err
+}
+
+// ResetPassword memakai token reset, mengganti password, membatalkan token
+// reset lain milik user, dan mencabut semua refresh token user dalam satu
+// transaksi.
+func (r *authRepository) ResetPassword(
+	ctx context.Context,
+	tokenID uuid.UUID,
+	userID uuid.UUID,
+	passwordHash string,
+) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE password_reset_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`,
+		now, tokenID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrPasswordResetTokenUsed
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE password_reset_tokens SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL`,
+		now, userID,
+	); err != nil {
+		return err
+	}
+
+	if err := archivePasswordTx(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET password_hash = $1, updated_at = $2 WHERE id = $3`,
+		passwordHash, now, userID,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`,
+		now, userID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
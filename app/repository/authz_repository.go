@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+)
+
+type AuthzRepository interface {
+	// GetState mengembalikan nil jika user tidak ditemukan.
+	GetState(ctx context.Context, userID uuid.UUID) (*models.AuthzState, error)
+}
+
+type authzRepository struct {
+	db *sql.DB
+}
+
+func NewAuthzRepository(db *sql.DB) AuthzRepository {
+	return &authzRepository{db: db}
+}
+
+func (r *authzRepository) GetState(ctx context.Context, userID uuid.UUID) (*models.AuthzState, error) {
+	query := `
+        SELECT u.id, u.role_id, r.name, u.is_active, u.authz_version, r.permissions_version
+        FROM users u
+        JOIN roles r ON r.id = u.role_id
+        WHERE u.id = $1
+    `
+	var state models.AuthzState
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&state.UserID, &state.RoleID, &state.RoleName, &state.IsActive,
+		&state.UserVersion, &state.RoleVersion,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT p.name
+        FROM role_permissions rp
+        JOIN permissions p ON p.id = rp.permission_id
+        WHERE rp.role_id = $1
+        ORDER BY p.name
+    `, state.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	state.Permissions = []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		state.Permissions = append(state.Permissions, name)
+	}
+
+	return &state, rows.Err()
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"backend/app/models"
+
+	"github.com/lib/pq"
+)
+
+type CatalogRepository interface {
+	FindAll(ctx context.Context) ([]models.CatalogItem, error)
+	FindByCatalog(ctx context.Context, catalog string) ([]models.CatalogItem, error)
+	Save(ctx context.Context, item *models.CatalogItem) error
+}
+
+type catalogRepository struct {
+	db *sql.DB
+}
+
+func NewCatalogRepository(db *sql.DB) CatalogRepository {
+	return &catalogRepository{db: db}
+}
+
+// FindAll mengembalikan nilai semua katalog, termasuk yang nonaktif.
+func (r *catalogRepository) FindAll(ctx context.Context) ([]models.CatalogItem, error) {
+	return r.find(ctx, "", nil)
+}
+
+func (r *catalogRepository) FindByCatalog(ctx context.Context, catalog string) ([]models.CatalogItem, error) {
+	return r.find(ctx, "WHERE i.catalog = $1", catalog)
+}
+
+func (r *catalogRepository) find(ctx context.Context, where string, arg interface{}) ([]models.CatalogItem, error) {
+	args := []interface{}{}
+	if arg != nil {
+		args = append(args, arg)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT i.catalog, i.code, i.aliases, i.sort_order, i.is_active, i.updated_at, l.locale, l.label
+        FROM catalog_items i
+        LEFT JOIN catalog_item_labels l ON l.catalog = i.catalog AND l.code = i.code
+        `+where+`
+        ORDER BY i.catalog, i.sort_order, i.code`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.CatalogItem{}
+	for rows.Next() {
+		var item models.CatalogItem
+		var locale, label sql.NullString
+		if err := rows.Scan(&item.Catalog, &item.Code, pq.Array(&item.Aliases), &item.SortOrder,
+			&item.IsActive, &item.UpdatedAt, &locale, &label); err != nil {
+			return nil, err
+		}
+
+		// Satu baris per label; gabungkan ke item sebelumnya jika sama.
+		if n := len(items); n > 0 && items[n-1].Catalog == item.Catalog && items[n-1].Code == item.Code {
+			if locale.Valid {
+				items[n-1].Labels[locale.String] = label.String
+			}
+			continue
+		}
+
+		item.Labels = map[string]string{}
+		if locale.Valid {
+			item.Labels[locale.String] = label.String
+		}
+		if item.Aliases == nil {
+			item.Aliases = []string{}
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Save membuat atau mengganti satu nilai katalog beserta seluruh labelnya.
+func (r *catalogRepository) Save(ctx context.Context, item *models.CatalogItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO catalog_items (catalog, code, aliases, sort_order, is_active, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (catalog, code) DO UPDATE SET
+            aliases = EXCLUDED.aliases,
+            sort_order = EXCLUDED.sort_order,
+            is_active = EXCLUDED.is_active,
+            updated_at = EXCLUDED.updated_at`,
+		item.Catalog, item.Code, pq.Array(item.Aliases), item.SortOrder, item.IsActive, item.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        DELETE FROM catalog_item_labels WHERE catalog = $1 AND code = $2`, item.Catalog, item.Code); err != nil {
+		return err
+	}
+
+	for locale, label := range item.Labels {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO catalog_item_labels (catalog, code, locale, label)
+            VALUES ($1, $2, $3, $4)`,
+			item.Catalog, item.Code, locale, label,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
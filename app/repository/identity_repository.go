@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"backend/app/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrIdentityAccountExists dikembalikan ProvisionStudent jika username,
+// email atau NIM sudah dipakai akun lain.
+var ErrIdentityAccountExists = errors.New("account already exists")
+
+type IdentityRepository interface {
+	CreateLoginState(ctx context.Context, state models.OIDCLoginState) error
+	// ConsumeLoginState menandai state terpakai dan mengembalikannya; nil
+	// jika state tidak ada, kedaluwarsa, atau sudah dipakai.
+	ConsumeLoginState(ctx context.Context, stateHash string) (*models.OIDCLoginState, error)
+
+	FindUsernameByIdentity(ctx context.Context, issuer, subject string) (string, error)
+	FindUsernameByEmail(ctx context.Context, email string) (string, error)
+	FindUsernameByStudentID(ctx context.Context, studentID string) (string, error)
+
+	LinkIdentity(ctx context.Context, identity models.UserIdentity) error
+	TouchIdentity(ctx context.Context, issuer, subject string) error
+	ProvisionStudent(ctx context.Context, user *models.User, student *models.Student, identity models.UserIdentity) error
+}
+
+type identityRepository struct {
+	db *sql.DB
+}
+
+func NewIdentityRepository(db *sql.DB) IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+func (r *identityRepository) CreateLoginState(ctx context.Context, s models.OIDCLoginState) error {
+	// State lama dibersihkan sekalian agar tabel tidak terus membesar.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM oidc_login_states WHERE expires_at < NOW() - INTERVAL '1 day'`); err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO oidc_login_states (id, state_hash, nonce, code_verifier, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	_, err := r.db.ExecContext(ctx, query, s.ID, s.StateHash, s.Nonce, s.CodeVerifier, s.ExpiresAt, s.CreatedAt)
+	return err
+}
+
+func (r *identityRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*models.OIDCLoginState, error) {
+	query := `
+        UPDATE oidc_login_states
+        SET used_at = NOW()
+        WHERE state_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+        RETURNING id, state_hash, nonce, code_verifier, expires_at, used_at, created_at
+    `
+	var s models.OIDCLoginState
+	var usedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, stateHash).Scan(
+		&s.ID, &s.StateHash, &s.Nonce, &s.CodeVerifier, &s.ExpiresAt, &usedAt, &s.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if usedAt.Valid {
+		s.UsedAt = &usedAt.Time
+	}
+	return &s, nil
+}
+
+func (r *identityRepository) FindUsernameByIdentity(ctx context.Context, issuer, subject string) (string, error) {
+	return r.findUsername(ctx, `
+        SELECT u.username
+        FROM user_identities i
+        JOIN users u ON u.id = i.user_id
+        WHERE i.issuer = $1 AND i.subject = $2
+    `, issuer, subject)
+}
+
+func (r *identityRepository) FindUsernameByEmail(ctx context.Context, email string) (string, error) {
+	return r.findUsername(ctx, `SELECT username FROM users WHERE LOWER(email) = LOWER($1)`, email)
+}
+
+func (r *identityRepository) FindUsernameByStudentID(ctx context.Context, studentID string) (string, error) {
+	return r.findUsername(ctx, `
+        SELECT u.username
+        FROM students s
+        JOIN users u ON u.id = s.user_id
+        WHERE s.student_id = $1
+    `, studentID)
+}
+
+func (r *identityRepository) findUsername(ctx context.Context, query string, args ...interface{}) (string, error) {
+	var username string
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return username, err
+}
+
+func (r *identityRepository) LinkIdentity(ctx context.Context, i models.UserIdentity) error {
+	query := `
+        INSERT INTO user_identities (id, user_id, issuer, subject, email, created_at, last_login_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $6)
+        ON CONFLICT (issuer, subject) DO NOTHING
+    `
+	_, err := r.db.ExecContext(ctx, query, i.ID, i.UserID, i.Issuer, i.Subject, i.Email, i.CreatedAt)
+	return err
+}
+
+func (r *identityRepository) TouchIdentity(ctx context.Context, issuer, subject string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE user_identities SET last_login_at = NOW() WHERE issuer = $1 AND subject = $2`, issuer, subject)
+	return err
+}
+
+// ProvisionStudent membuat akun mahasiswa aktif (email sudah diverifikasi
+// oleh IdP), data mahasiswa, dan tautan identitasnya dalam satu transaksi.
+func (r *identityRepository) ProvisionStudent(
+	ctx context.Context,
+	user *models.User,
+	student *models.Student,
+	identity models.UserIdentity,
+) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO users (
+            id, username, email, password_hash, full_name, role_id, is_active, email_verified_at, created_at, updated_at
+        )
+        SELECT $1, $2, $3, $4, $5, r.id, true, $7, $7, $7
+        FROM roles r
+        WHERE r.name = $6
+    `, user.ID, user.Username, user.Email, user.PasswordHash, user.FullName, models.RoleMahasiswa, user.CreatedAt); err != nil {
+		return uniqueViolation(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO students (
+            id, user_id, student_id, program_study, academic_year, created_at, updated_at
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+    `, student.ID, student.UserID, student.StudentID, student.ProgramStudy, student.AcademicYear, student.CreatedAt); err != nil {
+		return uniqueViolation(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO user_identities (id, user_id, issuer, subject, email, created_at, last_login_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $6)
+    `, identity.ID, identity.UserID, identity.Issuer, identity.Subject, identity.Email, identity.CreatedAt); err != nil {
+		return uniqueViolation(err)
+	}
+
+	return tx.Commit()
+}
+
+func uniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrIdentityAccountExists
+	}
+	return err
+}
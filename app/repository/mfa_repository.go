@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+)
+
+type MFARepository interface {
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error)
+	UpsertPending(ctx context.Context, userID uuid.UUID, secret string) error
+	Enable(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error
+	MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type mfaRepository struct {
+	db *sql.DB
+}
+
+func NewMFARepository(db *sql.DB) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	query := `
+        SELECT user_id, secret, enabled, last_used_step, enabled_at, created_at, updated_at
+        FROM user_mfa
+        WHERE user_id = $1
+    `
+	var mfa models.UserMFA
+	var enabledAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID, &mfa.Secret, &mfa.Enabled, &mfa.LastUsedStep, &enabledAt, &mfa.CreatedAt, &mfa.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if enabledAt.Valid {
+		mfa.EnabledAt = &enabledAt.Time
+	}
+	return &mfa, nil
+}
+
+// UpsertPending menyimpan secret baru yang belum aktif. Secret yang sudah
+// aktif tidak pernah ditimpa.
+func (r *mfaRepository) UpsertPending(ctx context.Context, userID uuid.UUID, secret string) error {
+	query := `
+        INSERT INTO user_mfa (user_id, secret, enabled, created_at, updated_at)
+        VALUES ($1, $2, false, NOW(), NOW())
+        ON CONFLICT (user_id) DO UPDATE
+        SET secret = EXCLUDED.secret, last_used_step = 0, updated_at = NOW()
+        WHERE user_mfa.enabled = false
+    `
+	_, err := r.db.ExecContext(ctx, query, userID, secret)
+	return err
+}
+
+func (r *mfaRepository) Enable(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+        UPDATE user_mfa
+        SET enabled = true, enabled_at = NOW(), last_used_step = $1, updated_at = NOW()
+        WHERE user_id = $2 AND enabled = false
+    `, step, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("mfa already enabled")
+	}
+
+	if err := replaceRecoveryCodesTx(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MarkStepUsed mencatat time step TOTP yang baru dipakai. Hasil false berarti
+// kode untuk step tersebut (atau yang lebih baru) sudah pernah dipakai.
+func (r *mfaRepository) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE user_mfa SET last_used_step = $1, updated_at = NOW()
+        WHERE user_id = $2 AND last_used_step < $1
+    `, step, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE mfa_recovery_codes SET used_at = NOW()
+        WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+    `, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodesTx(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func replaceRecoveryCodesTx(ctx context.Context, tx *sql.Tx, userID uuid.UUID, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, hash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at)
+            VALUES ($1, $2, $3, $4)
+        `, uuid.New(), userID, hash, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type PasswordRepository interface {
+	// GetIdentifiers mengembalikan username, email dan NIM (kosong jika
+	// bukan mahasiswa) yang tidak boleh dipakai sebagai password.
+	GetIdentifiers(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// RecentHashes mengembalikan hash password saat ini diikuti riwayat
+	// terbaru, total paling banyak limit.
+	RecentHashes(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	// ReplacePasswordTx mengganti password di dalam transaksi pemanggil dan
+	// mencabut semua refresh token user, seperti reset password.
+	ReplacePasswordTx(ctx context.Context, tx *sql.Tx, userID uuid.UUID, passwordHash string) error
+}
+
+type passwordRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordRepository(db *sql.DB) PasswordRepository {
+	return &passwordRepository{db: db}
+}
+
+func (r *passwordRepository) GetIdentifiers(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	query := `
+        SELECT u.username, u.email, COALESCE(s.student_id, '')
+        FROM users u
+        LEFT JOIN students s ON s.user_id = u.id
+        WHERE u.id = $1
+    `
+	var username, email, studentID string
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&username, &email, &studentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []string{username, email, studentID}, nil
+}
+
+func (r *passwordRepository) RecentHashes(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	query := `
+        SELECT password_hash FROM (
+            SELECT password_hash, NOW() AS created_at FROM users WHERE id = $1
+            UNION ALL
+            SELECT password_hash, created_at FROM password_history WHERE user_id = $1
+        ) h
+        ORDER BY created_at DESC
+        LIMIT $2
+    `
+	var hashes []string
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, h)
+	}
+	return hashes, rows.Err()
+}
+
+// UpdatePassword mengarsipkan hash lama ke password_history lalu mengganti
+// password dalam satu transaksi.
+func (r *passwordRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := archivePasswordTx(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2`,
+		passwordHash, userID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *passwordRepository) ReplacePasswordTx(ctx context.Context, tx *sql.Tx, userID uuid.UUID, passwordHash string) error {
+	if err := archivePasswordTx(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2`,
+		passwordHash, userID,
+	); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`,
+		userID,
+	)
+	return err
+}
+
+// archivePasswordTx menyalin hash password user saat ini ke riwayat; dipakai
+// di setiap transaksi yang mengganti password.
+func archivePasswordTx(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO password_history (id, user_id, password_hash, created_at)
+        SELECT $2, id, password_hash, NOW() FROM users WHERE id = $1
+    `, userID, uuid.New())
+	return err
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type PermissionRepository interface {
+	FindAll(ctx context.Context) ([]models.Permission, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Permission, error)
+	FindByName(ctx context.Context, name string) (*models.Permission, error)
+	CountExisting(ctx context.Context, ids []uuid.UUID) (int, error)
+	Create(ctx context.Context, permission *models.Permission) error
+	Update(ctx context.Context, permission *models.Permission) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CountRoles(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+type permissionRepository struct {
+	db *sql.DB
+}
+
+func NewPermissionRepository(db *sql.DB) PermissionRepository {
+	return &permissionRepository{db: db}
+}
+
+func (r *permissionRepository) FindAll(ctx context.Context) ([]models.Permission, error) {
+	query := `
+        SELECT id, name, resource, action, COALESCE(description, '')
+        FROM permissions
+        ORDER BY resource, action
+    `
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []models.Permission
+	for rows.Next() {
+		var p models.Permission
+		if err := rows.Scan(&p.ID, &p.Name, &p.Resource, &p.Action, &p.Description); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *permissionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Permission, error) {
+	return r.findOne(ctx, `
+        SELECT id, name, resource, action, COALESCE(description, '')
+        FROM permissions
+        WHERE id = $1
+    `, id)
+}
+
+func (r *permissionRepository) FindByName(ctx context.Context, name string) (*models.Permission, error) {
+	return r.findOne(ctx, `
+        SELECT id, name, resource, action, COALESCE(description, '')
+        FROM permissions
+        WHERE name = $1
+    `, name)
+}
+
+func (r *permissionRepository) findOne(ctx context.Context, query string, arg interface{}) (*models.Permission, error) {
+	var p models.Permission
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(&p.ID, &p.Name, &p.Resource, &p.Action, &p.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CountExisting menghitung berapa banyak id yang benar-benar ada di tabel
+// permissions, dipakai untuk memvalidasi input assign permission.
+func (r *permissionRepository) CountExisting(ctx context.Context, ids []uuid.UUID) (int, error) {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM permissions WHERE id = ANY($1::uuid[])`,
+		pq.Array(values),
+	).Scan(&count)
+	return count, err
+}
+
+func (r *permissionRepository) Create(ctx context.Context, p *models.Permission) error {
+	query := `
+        INSERT INTO permissions (id, name, resource, action, description)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.db.ExecContext(ctx, query, p.ID, p.Name, p.Resource, p.Action, p.Description)
+	return err
+}
+
+// Update mengubah permission dan menaikkan versi semua role yang memilikinya,
+// karena nama permission ikut tertanam di access token.
+func (r *permissionRepository) Update(ctx context.Context, p *models.Permission) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE permissions
+        SET name = $1, resource = $2, action = $3, description = $4
+        WHERE id = $5
+    `
+	if _, err := tx.ExecContext(ctx, query, p.Name, p.Resource, p.Action, p.Description, p.ID); err != nil {
+		return err
+	}
+
+	query = `
+        UPDATE roles
+        SET permissions_version = permissions_version + 1
+        WHERE id IN (SELECT role_id FROM role_permissions WHERE permission_id = $1)
+    `
+	if _, err := tx.ExecContext(ctx, query, p.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *permissionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM permissions WHERE id = $1`, id)
+	return err
+}
+
+func (r *permissionRepository) CountRoles(ctx context.Context, id uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM role_permissions WHERE permission_id = $1`, id).Scan(&count)
+	return count, err
+}
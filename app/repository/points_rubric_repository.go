@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+)
+
+type PointsRubricRepository interface {
+	FindAll(ctx context.Context) ([]models.PointsRubric, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.PointsRubric, error)
+	FindEffective(ctx context.Context, at time.Time) (*models.PointsRubric, error)
+	Create(ctx context.Context, rubric *models.PointsRubric) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetOverrides(ctx context.Context, referenceID uuid.UUID) ([]models.PointsOverride, error)
+}
+
+type pointsRubricRepository struct {
+	db *sql.DB
+}
+
+func NewPointsRubricRepository(db *sql.DB) PointsRubricRepository {
+	return &pointsRubricRepository{db: db}
+}
+
+const pointsRubricColumns = `
+        SELECT id, version, name, effective_from, created_by, created_at
+        FROM points_rubrics`
+
+// FindAll mengembalikan semua versi rubrik, terbaru dulu, tanpa aturan.
+func (r *pointsRubricRepository) FindAll(ctx context.Context) ([]models.PointsRubric, error) {
+	rows, err := r.db.QueryContext(ctx, pointsRubricColumns+`
+        ORDER BY version DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rubrics := []models.PointsRubric{}
+	for rows.Next() {
+		var rb models.PointsRubric
+		if err := scanPointsRubric(rows, &rb); err != nil {
+			return nil, err
+		}
+		rubrics = append(rubrics, rb)
+	}
+	return rubrics, rows.Err()
+}
+
+func (r *pointsRubricRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.PointsRubric, error) {
+	return r.findOne(ctx, pointsRubricColumns+`
+        WHERE id = $1`, id)
+}
+
+// FindEffective mengembalikan versi rubrik yang berlaku pada waktu at
+// beserta aturan dan faktor timnya, atau nil jika belum ada.
+func (r *pointsRubricRepository) FindEffective(ctx context.Context, at time.Time) (*models.PointsRubric, error) {
+	return r.findOne(ctx, pointsRubricColumns+`
+        WHERE effective_from <= $1
+        ORDER BY effective_from DESC, version DESC
+        LIMIT 1`, at)
+}
+
+func (r *pointsRubricRepository) findOne(ctx context.Context, query string, args ...interface{}) (*models.PointsRubric, error) {
+	var rb models.PointsRubric
+	err := scanPointsRubric(r.db.QueryRowContext(ctx, query, args...), &rb)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if rb.Rules, err = r.rules(ctx, rb.ID); err != nil {
+		return nil, err
+	}
+	if rb.TeamFactors, err = r.teamFactors(ctx, rb.ID); err != nil {
+		return nil, err
+	}
+	return &rb, nil
+}
+
+func scanPointsRubric(row interface{ Scan(...interface{}) error }, rb *models.PointsRubric) error {
+	var createdBy uuid.NullUUID
+	if err := row.Scan(&rb.ID, &rb.Version, &rb.Name, &rb.EffectiveFrom, &createdBy, &rb.CreatedAt); err != nil {
+		return err
+	}
+	if createdBy.Valid {
+		rb.CreatedBy = &createdBy.UUID
+	}
+	return nil
+}
+
+func (r *pointsRubricRepository) rules(ctx context.Context, rubricID uuid.UUID) ([]models.PointsRubricRule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, achievement_type, competition_level, rank, medal_type, publication_type, points
+        FROM points_rubric_rules
+        WHERE rubric_id = $1
+        ORDER BY achievement_type, points DESC`, rubricID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []models.PointsRubricRule{}
+	for rows.Next() {
+		var rule models.PointsRubricRule
+		var level, medal, publication sql.NullString
+		var rank sql.NullInt64
+		if err := rows.Scan(&rule.ID, &rule.AchievementType, &level, &rank, &medal, &publication, &rule.Points); err != nil {
+			return nil, err
+		}
+		if level.Valid {
+			rule.CompetitionLevel = &level.String
+		}
+		if rank.Valid {
+			n := int(rank.Int64)
+			rule.Rank = &n
+		}
+		if medal.Valid {
+			rule.MedalType = &medal.String
+		}
+		if publication.Valid {
+			rule.PublicationType = &publication.String
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *pointsRubricRepository) teamFactors(ctx context.Context, rubricID uuid.UUID) ([]models.TeamFactor, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT min_team_size, multiplier
+        FROM points_rubric_team_factors
+        WHERE rubric_id = $1
+        ORDER BY min_team_size`, rubricID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	factors := []models.TeamFactor{}
+	for rows.Next() {
+		var f models.TeamFactor
+		if err := rows.Scan(&f.MinTeamSize, &f.Multiplier); err != nil {
+			return nil, err
+		}
+		factors = append(factors, f)
+	}
+	return factors, rows.Err()
+}
+
+// Create menyimpan versi rubrik baru dengan nomor versi berikutnya, beserta
+// aturan dan faktor timnya, dalam satu transaksi.
+func (r *pointsRubricRepository) Create(ctx context.Context, rb *models.PointsRubric) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Kunci tabel agar dua admin tidak mendapat nomor versi yang sama.
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE points_rubrics IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO points_rubrics (id, version, name, effective_from, created_by, created_at)
+        SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5
+        FROM points_rubrics
+        RETURNING version`,
+		rb.ID, rb.Name, rb.EffectiveFrom, rb.CreatedBy, rb.CreatedAt,
+	).Scan(&rb.Version)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rb.Rules {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO points_rubric_rules (
+                id, rubric_id, achievement_type, competition_level, rank, medal_type, publication_type, points
+            ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			rule.ID, rb.ID, rule.AchievementType, rule.CompetitionLevel, rule.Rank, rule.MedalType, rule.PublicationType, rule.Points,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range rb.TeamFactors {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO points_rubric_team_factors (rubric_id, min_team_size, multiplier)
+            VALUES ($1, $2, $3)`,
+			rb.ID, f.MinTeamSize, f.Multiplier,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *pointsRubricRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM points_rubrics WHERE id = $1`, id)
+	return err
+}
+
+// GetOverrides mengembalikan riwayat override poin sebuah prestasi.
+func (r *pointsRubricRepository) GetOverrides(ctx context.Context, referenceID uuid.UUID) ([]models.PointsOverride, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, achievement_reference_id, rubric_id, suggested_points, awarded_points,
+               justification, actor_id, created_at
+        FROM achievement_points_overrides
+        WHERE achievement_reference_id = $1
+        ORDER BY created_at`, referenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := []models.PointsOverride{}
+	for rows.Next() {
+		var o models.PointsOverride
+		var rubricID uuid.NullUUID
+		var suggested sql.NullFloat64
+		if err := rows.Scan(&o.ID, &o.AchievementReferenceID, &rubricID, &suggested, &o.AwardedPoints,
+			&o.Justification, &o.ActorID, &o.CreatedAt); err != nil {
+			return nil, err
+		}
+		if rubricID.Valid {
+			o.RubricID = &rubricID.UUID
+		}
+		if suggested.Valid {
+			o.SuggestedPoints = &suggested.Float64
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, rows.Err()
+}
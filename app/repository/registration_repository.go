@@ -0,0 +1,467 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrAllowlistEntryTaken dikembalikan CreateRegistration jika NIM sudah
+// dipakai mendaftar (termasuk oleh request paralel).
+var ErrAllowlistEntryTaken = errors.New("student id already registered")
+
+// ErrEmailVerificationTokenUsed dikembalikan VerifyEmail jika token sudah
+// dipakai oleh request lain.
+var ErrEmailVerificationTokenUsed = errors.New("email verification token already used")
+
+// ErrEmailVerificationThrottled dikembalikan ReplaceEmailVerificationToken
+// jika token terakhir user dibuat kurang dari interval yang diminta.
+var ErrEmailVerificationThrottled = errors.New("email verification token requested too recently")
+
+// ErrRegistrationNotPending dikembalikan Approve dan Reject jika akun sudah
+// tidak berstatus pending (misalnya diproses admin lain).
+var ErrRegistrationNotPending = errors.New("registration is not pending")
+
+type RegistrationRepository interface {
+	UpsertAllowlist(ctx context.Context, entries []models.RegistrationAllowlistEntry, uploadedBy uuid.UUID) error
+	ListAllowlist(ctx context.Context, limit, offset int) ([]models.RegistrationAllowlistEntry, error)
+	FindAllowlistEntry(ctx context.Context, studentID string) (*models.RegistrationAllowlistEntry, error)
+	DeleteAllowlistEntry(ctx context.Context, studentID string) (bool, error)
+
+	IsUsernameOrEmailTaken(ctx context.Context, username, email string) (string, error)
+	CreateRegistration(ctx context.Context, user *models.User, student *models.Student, token models.EmailVerificationToken) error
+	GetEmailVerificationToken(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error)
+	VerifyEmail(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID) error
+	ReplaceEmailVerificationToken(ctx context.Context, token models.EmailVerificationToken, minInterval time.Duration) error
+
+	ListPending(ctx context.Context, limit, offset int) ([]models.PendingRegistration, error)
+	FindPending(ctx context.Context, userID uuid.UUID) (*models.PendingRegistration, error)
+	FindPendingByEmail(ctx context.Context, email string) (*models.PendingRegistration, error)
+	Approve(ctx context.Context, userID uuid.UUID) error
+	Reject(ctx context.Context, userID uuid.UUID) error
+}
+
+type registrationRepository struct {
+	db *sql.DB
+}
+
+func NewRegistrationRepository(db *sql.DB) RegistrationRepository {
+	return &registrationRepository{db: db}
+}
+
+// UpsertAllowlist menambah atau memperbarui entri. Entri yang sudah dipakai
+// mendaftar tetap menyimpan registered_user_id-nya.
+func (r *registrationRepository) UpsertAllowlist(
+	ctx context.Context,
+	entries []models.RegistrationAllowlistEntry,
+	uploadedBy uuid.UUID,
+) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO registration_allowlist (student_id, program_study, academic_year, uploaded_by, created_at)
+        VALUES ($1, $2, NULLIF($3, ''), $4, NOW())
+        ON CONFLICT (student_id) DO UPDATE
+        SET program_study = EXCLUDED.program_study,
+            academic_year = EXCLUDED.academic_year,
+            uploaded_by = EXCLUDED.uploaded_by
+    `
+	for _, e := range entries {
+		if _, err := tx.ExecContext(ctx, query, e.StudentID, e.ProgramStudy, e.AcademicYear, uploadedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *registrationRepository) ListAllowlist(
+	ctx context.Context,
+	limit, offset int,
+) ([]models.RegistrationAllowlistEntry, error) {
+
+	query := `
+        SELECT student_id, program_study, COALESCE(academic_year, ''), registered_user_id, uploaded_by, created_at
+        FROM registration_allowlist
+        ORDER BY student_id
+        LIMIT $1 OFFSET $2
+    `
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.RegistrationAllowlistEntry{}
+	for rows.Next() {
+		e, err := scanAllowlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *registrationRepository) FindAllowlistEntry(
+	ctx context.Context,
+	studentID string,
+) (*models.RegistrationAllowlistEntry, error) {
+
+	query := `
+        SELECT student_id, program_study, COALESCE(academic_year, ''), registered_user_id, uploaded_by, created_at
+        FROM registration_allowlist
+        WHERE student_id = $1
+    `
+	e, err := scanAllowlistEntry(r.db.QueryRowContext(ctx, query, studentID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return e, err
+}
+
+func (r *registrationRepository) DeleteAllowlistEntry(ctx context.Context, studentID string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM registration_allowlist WHERE student_id = $1`, studentID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAllowlistEntry(row rowScanner) (*models.RegistrationAllowlistEntry, error) {
+	var e models.RegistrationAllowlistEntry
+	var registered, uploadedBy uuid.NullUUID
+	if err := row.Scan(&e.StudentID, &e.ProgramStudy, &e.AcademicYear, &registered, &uploadedBy, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	if registered.Valid {
+		e.RegisteredUserID = &registered.UUID
+	}
+	if uploadedBy.Valid {
+		e.UploadedBy = &uploadedBy.UUID
+	}
+	return &e, nil
+}
+
+// IsUsernameOrEmailTaken mengembalikan "username" atau "email" jika sudah
+// dipakai user lain (aktif maupun belum), atau "" jika keduanya bebas.
+func (r *registrationRepository) IsUsernameOrEmailTaken(ctx context.Context, username, email string) (string, error) {
+	var taken string
+	err := r.db.QueryRowContext(ctx, `
+        SELECT CASE WHEN username = $1 THEN 'username' ELSE 'email' END
+        FROM users
+        WHERE username = $1 OR LOWER(email) = LOWER($2)
+        LIMIT 1
+    `, username, email).Scan(&taken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return taken, err
+}
+
+// CreateRegistration membuat user Mahasiswa (nonaktif, registrasi pending),
+// profil mahasiswa, mengklaim entri allow-list, dan menyimpan token
+// verifikasi email dalam satu transaksi.
+func (r *registrationRepository) CreateRegistration(
+	ctx context.Context,
+	user *models.User,
+	student *models.Student,
+	token models.EmailVerificationToken,
+) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO users (
+            id, username, email, password_hash, full_name, role_id, is_active, registration_status,
+            created_at, updated_at
+        )
+        SELECT $1, $2, $3, $4, $5, r.id, false, 'pending', $7, $7
+        FROM roles r
+        WHERE r.name = $6
+    `, user.ID, user.Username, user.Email, user.PasswordHash, user.FullName, models.RoleMahasiswa, user.CreatedAt); err != nil {
+		return err
+	}
+
+	// Klaim bersyarat: pendaftaran paralel dengan NIM sama menunggu lock
+	// baris ini dan gagal setelah yang pertama commit.
+	res, err := tx.ExecContext(ctx, `
+        UPDATE registration_allowlist
+        SET registered_user_id = $1
+        WHERE student_id = $2 AND registered_user_id IS NULL
+    `, user.ID, student.StudentID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrAllowlistEntryTaken
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO students (
+            id, user_id, student_id, program_study, academic_year, created_at, updated_at
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+    `, student.ID, student.UserID, student.StudentID, student.ProgramStudy, student.AcademicYear, student.CreatedAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO email_verification_tokens (id, user_id, token_hash, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *registrationRepository) GetEmailVerificationToken(
+	ctx context.Context,
+	tokenHash string,
+) (*models.EmailVerificationToken, error) {
+
+	query := `
+        SELECT id, user_id, token_hash, expires_at, used_at, created_at
+        FROM email_verification_tokens
+        WHERE token_hash = $1
+    `
+	var t models.EmailVerificationToken
+	var usedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &usedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	return &t, nil
+}
+
+func (r *registrationRepository) VerifyEmail(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	res, err := tx.ExecContext(ctx,
+		`UPDATE email_verification_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`,
+		now, tokenID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrEmailVerificationTokenUsed
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET email_verified_at = COALESCE(email_verified_at, $1), updated_at = $1 WHERE id = $2`,
+		now, userID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceEmailVerificationToken mengganti token verifikasi user yang belum
+// dipakai dengan token baru. Jika token terakhir dibuat kurang dari
+// minInterval yang lalu, tidak ada yang diubah.
+func (r *registrationRepository) ReplaceEmailVerificationToken(
+	ctx context.Context,
+	token models.EmailVerificationToken,
+	minInterval time.Duration,
+) error {
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock baris user agar permintaan paralel tidak lolos pemeriksaan
+	// interval bersamaan.
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, token.UserID); err != nil {
+		return err
+	}
+
+	var recent bool
+	if err := tx.QueryRowContext(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM email_verification_tokens
+            WHERE user_id = $1 AND created_at > $2
+        )
+    `, token.UserID, token.CreatedAt.Add(-minInterval)).Scan(&recent); err != nil {
+		return err
+	}
+	if recent {
+		return ErrEmailVerificationThrottled
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM email_verification_tokens WHERE user_id = $1 AND used_at IS NULL`, token.UserID,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO email_verification_tokens (id, user_id, token_hash, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+const pendingRegistrationQuery = `
+        SELECT u.id, u.username, u.email, u.full_name,
+               s.student_id, s.program_study, s.academic_year,
+               u.email_verified_at, u.created_at
+        FROM registration_allowlist a
+        JOIN users u ON u.id = a.registered_user_id
+        JOIN students s ON s.user_id = u.id
+        WHERE u.registration_status = 'pending'
+`
+
+func (r *registrationRepository) ListPending(
+	ctx context.Context,
+	limit, offset int,
+) ([]models.PendingRegistration, error) {
+
+	rows, err := r.db.QueryContext(ctx,
+		pendingRegistrationQuery+` ORDER BY u.created_at LIMIT $1 OFFSET $2`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pending := []models.PendingRegistration{}
+	for rows.Next() {
+		p, err := scanPendingRegistration(rows)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, *p)
+	}
+	return pending, rows.Err()
+}
+
+func (r *registrationRepository) FindPending(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*models.PendingRegistration, error) {
+
+	p, err := scanPendingRegistration(r.db.QueryRowContext(ctx,
+		pendingRegistrationQuery+` AND u.id = $1`, userID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return p, err
+}
+
+func (r *registrationRepository) FindPendingByEmail(
+	ctx context.Context,
+	email string,
+) (*models.PendingRegistration, error) {
+
+	p, err := scanPendingRegistration(r.db.QueryRowContext(ctx,
+		pendingRegistrationQuery+` AND LOWER(u.email) = LOWER($1)`, email,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return p, err
+}
+
+func scanPendingRegistration(row rowScanner) (*models.PendingRegistration, error) {
+	var p models.PendingRegistration
+	var verifiedAt sql.NullTime
+	if err := row.Scan(
+		&p.UserID, &p.Username, &p.Email, &p.FullName,
+		&p.StudentID, &p.ProgramStudy, &p.AcademicYear,
+		&verifiedAt, &p.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if verifiedAt.Valid {
+		p.EmailVerifiedAt = &verifiedAt.Time
+	}
+	return &p, nil
+}
+
+// Approve mengaktifkan akun dan menandai registrasinya approved;
+// authz_version dinaikkan seperti perubahan status aktif lainnya.
+func (r *registrationRepository) Approve(ctx context.Context, userID uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE users
+        SET is_active = true, registration_status = 'approved',
+            authz_version = authz_version + 1, updated_at = NOW()
+        WHERE id = $1 AND registration_status = 'pending'
+    `, userID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrRegistrationNotPending
+	}
+	return nil
+}
+
+// Reject menghapus akun registrasi yang masih pending beserta profil
+// mahasiswa dan tokennya. Klaim NIM di allow-list ikut lepas (ON DELETE SET
+// NULL) sehingga NIM, username dan email bisa dipakai mendaftar lagi.
+func (r *registrationRepository) Reject(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+        SELECT id FROM users WHERE id = $1 AND registration_status = 'pending' FOR UPDATE
+    `, userID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRegistrationNotPending
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM students WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
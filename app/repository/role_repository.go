@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type RoleRepository interface {
+	FindAll(ctx context.Context) ([]models.Role, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Role, error)
+	FindByName(ctx context.Context, name string) (*models.Role, error)
+	Create(ctx context.Context, role *models.Role) error
+	Update(ctx context.Context, role *models.Role) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CountUsers(ctx context.Context, id uuid.UUID) (int, error)
+	GetPermissions(ctx context.Context, id uuid.UUID) ([]models.Permission, error)
+	ReplacePermissions(ctx context.Context, id uuid.UUID, permissionIDs []uuid.UUID) error
+}
+
+type roleRepository struct {
+	db *sql.DB
+}
+
+func NewRoleRepository(db *sql.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) FindAll(ctx context.Context) ([]models.Role, error) {
+	query := `
+        SELECT id, name, COALESCE(description, ''), created_at
+        FROM roles
+        ORDER BY name
+    `
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []models.Role
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &role.CreatedAt); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (r *roleRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Role, error) {
+	return r.findOne(ctx, `
+        SELECT id, name, COALESCE(description, ''), created_at
+        FROM roles
+        WHERE id = $1
+    `, id)
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	return r.findOne(ctx, `
+        SELECT id, name, COALESCE(description, ''), created_at
+        FROM roles
+        WHERE LOWER(name) = LOWER($1)
+    `, name)
+}
+
+func (r *roleRepository) findOne(ctx context.Context, query string, arg interface{}) (*models.Role, error) {
+	var role models.Role
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(&role.ID, &role.Name, &role.Description, &role.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) error {
+	query := `
+        INSERT INTO roles (id, name, description, created_at)
+        VALUES ($1, $2, $3, $4)
+    `
+	_, err := r.db.ExecContext(ctx, query, role.ID, role.Name, role.Description, role.CreatedAt)
+	return err
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *models.Role) error {
+	query := `
+        UPDATE roles
+        SET name = $1, description = $2,
+            permissions_version = permissions_version + CASE WHEN name <> $1 THEN 1 ELSE 0 END
+        WHERE id = $3
+    `
+	_, err := r.db.ExecContext(ctx, query, role.Name, role.Description, role.ID)
+	return err
+}
+
+// Delete menghapus role beserta relasi permission-nya. Pengecekan apakah
+// role masih dipakai user dilakukan di service.
+func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_id = $1`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM roles WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *roleRepository) CountUsers(ctx context.Context, id uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE role_id = $1`, id).Scan(&count)
+	return count, err
+}
+
+func (r *roleRepository) GetPermissions(ctx context.Context, id uuid.UUID) ([]models.Permission, error) {
+	query := `
+        SELECT p.id, p.name, p.resource, p.action, COALESCE(p.description, '')
+        FROM role_permissions rp
+        JOIN permissions p ON p.id = rp.permission_id
+        WHERE rp.role_id = $1
+        ORDER BY p.name
+    `
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []models.Permission{}
+	for rows.Next() {
+		var p models.Permission
+		if err := rows.Scan(&p.ID, &p.Name, &p.Resource, &p.Action, &p.Description); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, p)
+	}
+	return permissions, rows.Err()
+}
+
+// ReplacePermissions mengganti seluruh permission milik role dalam satu
+// transaksi.
+func (r *roleRepository) ReplacePermissions(ctx context.Context, id uuid.UUID, permissionIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_id = $1`, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE roles SET permissions_version = permissions_version + 1 WHERE id = $1`, id,
+	); err != nil {
+		return err
+	}
+
+	if len(permissionIDs) > 0 {
+		ids := make([]string, len(permissionIDs))
+		for i, pid := range permissionIDs {
+			ids[i] = pid.String()
+		}
+
+		query := `
+            INSERT INTO role_permissions (role_id, permission_id)
+            SELECT $1, UNNEST($2::uuid[])
+            ON CONFLICT DO NOTHING
+        `
+		if _, err := tx.ExecContext(ctx, query, id, pq.Array(ids)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+)
+
+type SecurityEventRepository interface {
+	Create(ctx context.Context, event models.SecurityEvent) error
+	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.SecurityEvent, error)
+}
+
+type securityEventRepository struct {
+	db *sql.DB
+}
+
+func NewSecurityEventRepository(db *sql.DB) SecurityEventRepository {
+	return &securityEventRepository{db: db}
+}
+
+func (r *securityEventRepository) Create(ctx context.Context, event models.SecurityEvent) error {
+	query := `
+        INSERT INTO security_events (id, user_id, event_type, detail, ip_address, created_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID, event.UserID, event.EventType, event.Detail, event.IPAddress, event.CreatedAt,
+	)
+	return err
+}
+
+func (r *securityEventRepository) FindByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]models.SecurityEvent, error) {
+
+	query := `
+        SELECT id, user_id, event_type, COALESCE(detail, ''), COALESCE(ip_address, ''), created_at
+        FROM security_events
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.SecurityEvent
+	for rows.Next() {
+		var e models.SecurityEvent
+		var uid uuid.NullUUID
+		if err := rows.Scan(&e.ID, &uid, &e.EventType, &e.Detail, &e.IPAddress, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if uid.Valid {
+			e.UserID = &uid.UUID
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
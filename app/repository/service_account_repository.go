@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ServiceAccountRepository interface {
+	FindAll(ctx context.Context) ([]models.ServiceAccount, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error)
+	FindByName(ctx context.Context, name string) (*models.ServiceAccount, error)
+	Create(ctx context.Context, account *models.ServiceAccount) error
+	Update(ctx context.Context, account *models.ServiceAccount) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]models.APIKey, error)
+	FindKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (*models.APIKey, error)
+	FindKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	CreateKey(ctx context.Context, key *models.APIKey) error
+	RevokeKey(ctx context.Context, keyID uuid.UUID) (bool, error)
+	TouchKey(ctx context.Context, keyID uuid.UUID, ip string, throttle time.Duration) error
+}
+
+type serviceAccountRepository struct {
+	db *sql.DB
+}
+
+func NewServiceAccountRepository(db *sql.DB) ServiceAccountRepository {
+	return &serviceAccountRepository{db: db}
+}
+
+const serviceAccountColumns = `id, name, COALESCE(description, ''), is_active, created_by, created_at, updated_at`
+
+func scanServiceAccount(row rowScanner) (*models.ServiceAccount, error) {
+	var a models.ServiceAccount
+	var createdBy uuid.NullUUID
+	if err := row.Scan(&a.ID, &a.Name, &a.Description, &a.IsActive, &createdBy, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if createdBy.Valid {
+		a.CreatedBy = &createdBy.UUID
+	}
+	return &a, nil
+}
+
+func (r *serviceAccountRepository) FindAll(ctx context.Context) ([]models.ServiceAccount, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+serviceAccountColumns+` FROM service_accounts ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []models.ServiceAccount{}
+	for rows.Next() {
+		a, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *a)
+	}
+	return accounts, rows.Err()
+}
+
+func (r *serviceAccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error) {
+	a, err := scanServiceAccount(r.db.QueryRowContext(ctx,
+		`SELECT `+serviceAccountColumns+` FROM service_accounts WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return a, err
+}
+
+func (r *serviceAccountRepository) FindByName(ctx context.Context, name string) (*models.ServiceAccount, error) {
+	a, err := scanServiceAccount(r.db.QueryRowContext(ctx,
+		`SELECT `+serviceAccountColumns+` FROM service_accounts WHERE LOWER(name) = LOWER($1)`, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return a, err
+}
+
+func (r *serviceAccountRepository) Create(ctx context.Context, a *models.ServiceAccount) error {
+	query := `
+        INSERT INTO service_accounts (id, name, description, is_active, created_by, created_at, updated_at)
+        VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $6)
+    `
+	_, err := r.db.ExecContext(ctx, query, a.ID, a.Name, a.Description, a.IsActive, a.CreatedBy, a.CreatedAt)
+	return err
+}
+
+func (r *serviceAccountRepository) Update(ctx context.Context, a *models.ServiceAccount) error {
+	query := `
+        UPDATE service_accounts
+        SET description = NULLIF($2, ''), is_active = $3, updated_at = NOW()
+        WHERE id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, a.ID, a.Description, a.IsActive)
+	return err
+}
+
+// Delete menghapus service account beserta seluruh API key-nya (cascade).
+func (r *serviceAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM service_accounts WHERE id = $1`, id)
+	return err
+}
+
+const apiKeyColumns = `
+    k.id, k.service_account_id, k.name, k.prefix, k.key_hash, k.permissions,
+    k.expires_at, k.revoked_at, k.last_used_at, COALESCE(k.last_used_ip, ''),
+    k.created_by, k.created_at, a.name, a.is_active
+`
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var k models.APIKey
+	var expiresAt, revokedAt, lastUsedAt sql.NullTime
+	var createdBy uuid.NullUUID
+	if err := row.Scan(
+		&k.ID, &k.ServiceAccountID, &k.Name, &k.Prefix, &k.KeyHash, pq.Array(&k.Permissions),
+		&expiresAt, &revokedAt, &lastUsedAt, &k.LastUsedIP,
+		&createdBy, &k.CreatedAt, &k.ServiceAccountName, &k.ServiceAccountActive,
+	); err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+	if lastUsedAt.Valid {
+		k.LastUsedAt = &lastUsedAt.Time
+	}
+	if createdBy.Valid {
+		k.CreatedBy = &createdBy.UUID
+	}
+	return &k, nil
+}
+
+func (r *serviceAccountRepository) ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]models.APIKey, error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys k
+        JOIN service_accounts a ON a.id = k.service_account_id
+        WHERE k.service_account_id = $1
+        ORDER BY k.created_at DESC
+    `
+	rows, err := r.db.QueryContext(ctx, query, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *serviceAccountRepository) FindKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) (*models.APIKey, error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys k
+        JOIN service_accounts a ON a.id = k.service_account_id
+        WHERE k.service_account_id = $1 AND k.id = $2
+    `
+	k, err := scanAPIKey(r.db.QueryRowContext(ctx, query, serviceAccountID, keyID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return k, err
+}
+
+func (r *serviceAccountRepository) FindKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys k
+        JOIN service_accounts a ON a.id = k.service_account_id
+        WHERE k.prefix = $1
+    `
+	k, err := scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return k, err
+}
+
+func (r *serviceAccountRepository) CreateKey(ctx context.Context, k *models.APIKey) error {
+	query := `
+        INSERT INTO api_keys (id, service_account_id, name, prefix, key_hash, permissions, expires_at, created_by, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		k.ID, k.ServiceAccountID, k.Name, k.Prefix, k.KeyHash, pq.Array(k.Permissions),
+		k.ExpiresAt, k.CreatedBy, k.CreatedAt,
+	)
+	return err
+}
+
+// RevokeKey mengembalikan false jika key sudah dicabut sebelumnya.
+func (r *serviceAccountRepository) RevokeKey(ctx context.Context, keyID uuid.UUID) (bool, error) {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, keyID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// TouchKey mencatat pemakaian terakhir. Agar tidak menulis ke database di
+// setiap request, baris hanya diperbarui jika pemakaian terakhir lebih lama
+// dari throttle.
+func (r *serviceAccountRepository) TouchKey(ctx context.Context, keyID uuid.UUID, ip string, throttle time.Duration) error {
+	query := `
+        UPDATE api_keys
+        SET last_used_at = NOW(), last_used_ip = NULLIF($2, '')
+        WHERE id = $1
+          AND (last_used_at IS NULL OR last_used_at < NOW() - make_interval(secs => $3))
+    `
+	_, err := r.db.ExecContext(ctx, query, keyID, ip, throttle.Seconds())
+	return err
+}
@@ -12,6 +12,7 @@ import (
 type StudentLecturerRepository interface {
 	GetAllStudents(ctx context.Context) ([]models.StudentDetailResponse, error)
 	GetStudentByID(ctx context.Context, id uuid.UUID) (*models.StudentDetailResponse, error)
+	GetStudentByUserID(ctx context.Context, userID uuid.UUID) (*models.StudentDetailResponse, error)
 	GetStudentAchievements(ctx context.Context, studentID uuid.UUID) ([]models.StudentAchievementResponse, error)
 	UpdateStudentAdvisor(ctx context.Context, studentID uuid.UUID, advisorID *uuid.UUID) error
 
@@ -116,6 +117,50 @@ func (r *studentLecturerRepository) GetStudentByID(
 	return &s, nil
 }
 
+// GetStudentByUserID mencari profil mahasiswa milik user; nil jika user
+// tersebut bukan mahasiswa.
+func (r *studentLecturerRepository) GetStudentByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*models.StudentDetailResponse, error) {
+
+	query := `
+		SELECT
+			s.id,
+			s.user_id,
+			u.full_name,
+			s.student_id,
+			s.program_study,
+			s.academic_year,
+			COALESCE(adv.full_name, '') AS advisor_name
+		FROM students s
+		JOIN users u ON u.id = s.user_id
+		LEFT JOIN lecturers l ON l.id = s.advisor_id
+		LEFT JOIN users adv ON adv.id = l.user_id
+		WHERE s.user_id = $1
+	`
+
+	var s models.StudentDetailResponse
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&s.ID,
+		&s.UserID,
+		&s.FullName,
+		&s.StudentID,
+		&s.ProgramStudy,
+		&s.AcademicYear,
+		&s.AdvisorName,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
 func (r *studentLecturerRepository) GetStudentAchievements(
 	ctx context.Context,
 	studentID uuid.UUID,
@@ -17,7 +17,10 @@ type UserRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetPermissionsByRoleID(ctx context.Context, roleID uuid.UUID) ([]string, error)
 	Update(ctx context.Context, user *models.User) error
+	UpdateTx(ctx context.Context, tx *sql.Tx, user *models.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Unlock(ctx context.Context, id uuid.UUID) error
+	CountActiveByRoleName(ctx context.Context, roleName string) (int, error)
 
 	BeginTx(ctx context.Context) (*sql.Tx, error)
 	CreateTx(ctx context.Context, tx *sql.Tx, user *models.User) error
@@ -60,13 +63,15 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Us
         SELECT 
             u.id, u.username, u.email, u.password_hash, u.full_name, 
             u.role_id, r.name as role_name, 
-            u.is_active, u.created_at, u.updated_at
+            u.is_active, u.created_at, u.updated_at,
+            u.failed_login_attempts, u.locked_until
         FROM users u
         LEFT JOIN roles r ON u.role_id = r.id
         WHERE u.id = $1
     `
 
 	var user models.User
+	var lockedUntil sql.NullTime
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Username,
@@ -78,6 +83,8 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Us
 		&user.IsActive,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.FailedLoginAttempts,
+		&lockedUntil,
 	)
 
 	if err != nil {
@@ -86,6 +93,9 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Us
 		}
 		return nil, err
 	}
+	if lockedUntil.Valid {
+		user.LockedUntil = &lockedUntil.Time
+	}
 	return &user, nil
 }
 func (r *userRepository) FindByUsernameOrEmail(ctx context.Context, identity string) (*models.User, error) {
@@ -152,13 +162,32 @@ func (r *userRepository) GetPermissionsByRoleID(
 	return permissions, nil
 }
 
+// Mengaktifkan akun registrasi mandiri yang masih pending sama dengan
+// menyetujuinya.
+const updateUserQuery = `
+    UPDATE users
+    SET full_name = $1, username = $2, email = $3, role_id = $4, is_active = $5, updated_at = $6,
+        authz_version = authz_version + CASE WHEN role_id <> $4 OR is_active <> $5 THEN 1 ELSE 0 END,
+        registration_status = CASE WHEN $5 AND registration_status = 'pending' THEN 'approved' ELSE registration_status END
+    WHERE id = $7
+`
+
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
-	query := `
-        UPDATE users
-        SET full_name = $1, username = $2, email = $3, role_id = $4, is_active = $5, updated_at = $6
-        WHERE id = $7
-    `
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.db.ExecContext(ctx, updateUserQuery,
+		user.FullName,
+		user.Username,
+		user.Email,
+		user.RoleID,
+		user.IsActive,
+		user.UpdatedAt,
+		user.ID,
+	)
+	return err
+}
+
+// UpdateTx sama dengan Update di dalam transaksi pemanggil.
+func (r *userRepository) UpdateTx(ctx context.Context, tx *sql.Tx, user *models.User) error {
+	_, err := tx.ExecContext(ctx, updateUserQuery,
 		user.FullName,
 		user.Username,
 		user.Email,
@@ -176,12 +205,35 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+func (r *userRepository) Unlock(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE users
+        SET failed_login_attempts = 0, last_failed_login_at = NULL, locked_until = NULL, updated_at = NOW()
+        WHERE id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *userRepository) CountActiveByRoleName(ctx context.Context, roleName string) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM users u
+        JOIN roles r ON u.role_id = r.id
+        WHERE r.name = $1 AND u.is_active = true
+    `
+	var count int
+	err := r.db.QueryRowContext(ctx, query, roleName).Scan(&count)
+	return count, err
+}
+
 func (r *userRepository) FindAll(ctx context.Context, limit int, offset int) ([]models.User, error) {
 	query := `
         SELECT 
             u.id, u.username, u.email, u.password_hash, u.full_name, 
             u.role_id, r.name as role_name, 
-            u.is_active, u.created_at, u.updated_at
+            u.is_active, u.created_at, u.updated_at,
+            u.failed_login_attempts, u.locked_until
         FROM users u
         LEFT JOIN roles r ON u.role_id = r.id
         LIMIT $1 OFFSET $2
@@ -195,6 +247,7 @@ func (r *userRepository) FindAll(ctx context.Context, limit int, offset int) ([]
 	var users []models.User
 	for rows.Next() {
 		var user models.User
+		var lockedUntil sql.NullTime
 		if err := rows.Scan(
 			&user.ID,
 			&user.Username,
@@ -206,9 +259,14 @@ func (r *userRepository) FindAll(ctx context.Context, limit int, offset int) ([]
 			&user.IsActive,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.FailedLoginAttempts,
+			&lockedUntil,
 		); err != nil {
 			return nil, err
 		}
+		if lockedUntil.Valid {
+			user.LockedUntil = &lockedUntil.Time
+		}
 		users = append(users, user)
 	}
 	return users, nil
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"backend/app/models"
+	"backend/app/repository"
+)
+
+// ErrAchievementNotFound dikembalikan untuk prestasi yang tidak ada maupun
+// yang berada di luar scope pemanggil, agar keberadaannya tidak bocor.
+var ErrAchievementNotFound = errors.New("achievement not found")
+
+// ErrNotStepApprover dikembalikan saat user tidak termasuk penyetuju
+// langkah persetujuan yang sedang berjalan.
+var ErrNotStepApprover = errors.New("not an approver for the current approval step")
+
+// ErrNotAchievementOwner dikembalikan saat aksi yang hanya boleh dilakukan
+// mahasiswa pemilik (ubah, lampiran, ajukan, hapus) dipanggil user lain.
+var ErrNotAchievementOwner = errors.New("only the owning student can perform this action")
+
+// ErrApprovalWorkflowMissing dikembalikan saat prestasi berada di tengah
+// rantai persetujuan yang workflow-nya sudah tidak ada.
+var ErrApprovalWorkflowMissing = errors.New("approval workflow of this achievement no longer exists")
+
+// ErrIfMatchRequired dikembalikan saat aksi tulis prestasi dipanggil tanpa
+// ETag prestasi di header If-Match.
+var ErrIfMatchRequired = errors.New("If-Match header with the achievement ETag is required")
+
+// achievementAccess adalah lapisan otorisasi tingkat resource untuk
+// prestasi, dipakai bersama oleh AchievementService dan
+// AchievementReferenceService.
+type achievementAccess struct {
+	refRepo     repository.AchievementReferenceRepository
+	studentRepo repository.StudentLecturerRepository
+	workflows   repository.ApprovalWorkflowRepository
+}
+
+// resolve mencari referensi prestasi dan memastikan pemanggil berhak
+// melihatnya:
+//   - Mahasiswa hanya prestasi miliknya sendiri.
+//   - Dosen wali hanya prestasi mahasiswa bimbingannya, atau yang langkah
+//     persetujuannya sedang menunggu dia.
+//   - Admin semua prestasi.
+//   - Role lain hanya prestasi yang langkah persetujuannya sedang menunggu
+//     dia.
+//
+// resolve hanya memberi akses lihat; aksi tulis diperiksa lagi lewat
+// resolveOwned atau authorizeStep.
+//
+// Prestasi yang sudah dihapus hanya terlihat oleh Admin.
+func (a *achievementAccess) resolve(
+	ctx context.Context,
+	mongoID string,
+	actor models.AchievementActor,
+) (*models.AchievementReference, error) {
+
+	ref, err := a.refRepo.GetByMongoID(ctx, mongoID)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, ErrAchievementNotFound
+	}
+
+	if ref.Status == models.StatusDeleted && actor.Role != models.RoleAdmin {
+		return nil, ErrAchievementNotFound
+	}
+
+	switch actor.Role {
+	case models.RoleMahasiswa:
+		if actor.StudentID == nil || *actor.StudentID != ref.StudentID {
+			return nil, ErrAchievementNotFound
+		}
+
+	case models.RoleDosenWali:
+		ok, err := a.studentRepo.IsAdvisorOf(ctx, actor.UserID, ref.StudentID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+
+		if err := a.requirePendingApprover(ctx, ref, actor); err != nil {
+			return nil, err
+		}
+
+	case models.RoleAdmin:
+
+	default:
+		if err := a.requirePendingApprover(ctx, ref, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	return ref, nil
+}
+
+// requirePendingApprover memastikan langkah persetujuan yang sedang berjalan
+// menunggu actor; selain itu prestasi dianggap tidak ada.
+func (a *achievementAccess) requirePendingApprover(
+	ctx context.Context,
+	ref *models.AchievementReference,
+	actor models.AchievementActor,
+) error {
+
+	step, _, err := a.currentStep(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if step == nil || a.authorizeStep(ctx, ref, step, actor) != nil {
+		return ErrAchievementNotFound
+	}
+	return nil
+}
+
+// resolveOwned seperti resolve, tetapi hanya untuk mahasiswa pemilik
+// prestasi; Admin maupun dosen yang bisa melihatnya tetap ditolak.
+func (a *achievementAccess) resolveOwned(
+	ctx context.Context,
+	mongoID string,
+	actor models.AchievementActor,
+) (*models.AchievementReference, error) {
+
+	ref, err := a.resolve(ctx, mongoID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != models.RoleMahasiswa || actor.StudentID == nil || *actor.StudentID != ref.StudentID {
+		return nil, ErrNotAchievementOwner
+	}
+	return ref, nil
+}
+
+// currentStep mengembalikan langkah persetujuan yang sedang berjalan dan
+// langkah berikutnya (nil jika ini langkah terakhir). Prestasi tanpa
+// workflow menghasilkan nil (verifikasi satu langkah). Prestasi yang masih
+// punya posisi langkah tetapi workflow-nya hilang ditolak, bukan dianggap
+// satu langkah.
+func (a *achievementAccess) currentStep(
+	ctx context.Context,
+	ref *models.AchievementReference,
+) (step *models.ApprovalWorkflowStep, next *models.ApprovalWorkflowStep, err error) {
+
+	if ref.CurrentStep == nil {
+		return nil, nil, nil
+	}
+	if ref.ApprovalWorkflowID == nil {
+		return nil, nil, ErrApprovalWorkflowMissing
+	}
+
+	workflow, err := a.workflows.FindByID(ctx, *ref.ApprovalWorkflowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if workflow == nil || len(workflow.Steps) == 0 {
+		return nil, nil, ErrApprovalWorkflowMissing
+	}
+
+	idx := *ref.CurrentStep - 1
+	if idx >= len(workflow.Steps) {
+		idx = len(workflow.Steps) - 1
+	}
+	if idx+1 < len(workflow.Steps) {
+		next = &workflow.Steps[idx+1]
+	}
+	return &workflow.Steps[idx], next, nil
+}
+
+// authorizeStep memastikan user boleh bertindak di langkah step: role harus
+// sama jika ditentukan, dan user harus berada di scope terhadap mahasiswa.
+// Tanpa workflow (step nil) hanya dosen wali mahasiswa yang boleh.
+func (a *achievementAccess) authorizeStep(
+	ctx context.Context,
+	ref *models.AchievementReference,
+	step *models.ApprovalWorkflowStep,
+	actor models.AchievementActor,
+) error {
+
+	if step == nil {
+		ok, err := a.studentRepo.IsAdvisorOf(ctx, actor.UserID, ref.StudentID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNotStepApprover
+		}
+		return nil
+	}
+
+	if step.ApproverRole != nil && *step.ApproverRole != actor.Role {
+		return ErrNotStepApprover
+	}
+
+	if step.ApproverScope != nil {
+		ok, err := a.workflows.InScope(ctx, *step.ApproverScope, actor.UserID, ref.StudentID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNotStepApprover
+		}
+	}
+
+	return nil
+}
+
+// checkIfMatch memastikan header If-Match memuat ETag prestasi terkini.
+// "*" tidak diterima karena tidak mengunci versi mana pun.
+func checkIfMatch(ifMatch string, achievement *models.Achievement, ref *models.AchievementReference) error {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return ErrIfMatchRequired
+	}
+
+	etag := models.AchievementETag(achievement.Version, ref.Version)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		if strings.TrimSpace(tag) == etag {
+			return nil
+		}
+	}
+	return repository.ErrVersionConflict
+}
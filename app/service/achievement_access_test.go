@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// accessFixture menyiapkan satu prestasi milik mahasiswa owner beserta
+// actor di setiap scope: pemilik, mahasiswa lain, dosen wali pemilik, dosen
+// wali lain dan Admin.
+type accessFixture struct {
+	achievements AchievementService
+	references   AchievementReferenceService
+
+	refs *fakeAchievementRefs
+	docs *fakeAchievementDocs
+
+	mongoID string
+	actors  map[string]models.AchievementActor
+}
+
+const (
+	actorOwner        = "owner"
+	actorOtherStudent = "other student"
+	actorAdvisor      = "advisor"
+	actorNonAdvisor   = "non-advisor"
+	actorAdmin        = "admin"
+)
+
+func newAccessFixture(t *testing.T, status models.AchievementStatus) *accessFixture {
+	t.Helper()
+
+	ownerStudent, otherStudent := uuid.New(), uuid.New()
+	advisor := uuid.New()
+
+	mongoID := primitive.NewObjectID().Hex()
+	refs := &fakeAchievementRefs{byMongoID: map[string]*models.AchievementReference{
+		mongoID: {
+			ID:                 uuid.New(),
+			StudentID:          ownerStudent,
+			MongoAchievementID: mongoID,
+			Status:             status,
+			Version:            1,
+		},
+	}}
+	docs := &fakeAchievementDocs{byID: map[string]*models.Achievement{
+		mongoID: {
+			StudentID:       ownerStudent.String(),
+			AchievementType: "competition",
+			Title:           "Juara 1 Lomba Robotik",
+			Attachments:     []models.Attachment{},
+			Version:         1,
+		},
+	}}
+	advisors := fakeAdvisors{advisees: map[uuid.UUID]map[uuid.UUID]bool{
+		advisor: {ownerStudent: true},
+	}}
+
+	return &accessFixture{
+		achievements: NewAchievementService(docs, refs, advisors, fakeWorkflows{}, fakeSchemas{}, fakeCatalogs{}),
+		references:   NewAchievementReferenceService(refs, docs, fakeWorkflows{}, advisors, fakeRubrics{}, 7*24*time.Hour),
+		refs:         refs,
+		docs:         docs,
+		mongoID:      mongoID,
+		actors: map[string]models.AchievementActor{
+			actorOwner:        {UserID: uuid.New(), Role: models.RoleMahasiswa, StudentID: &ownerStudent},
+			actorOtherStudent: {UserID: uuid.New(), Role: models.RoleMahasiswa, StudentID: &otherStudent},
+			actorAdvisor:      {UserID: advisor, Role: models.RoleDosenWali},
+			actorNonAdvisor:   {UserID: uuid.New(), Role: models.RoleDosenWali},
+			actorAdmin:        {UserID: uuid.New(), Role: models.RoleAdmin},
+		},
+	}
+}
+
+// etag mengembalikan ETag prestasi saat ini untuk header If-Match.
+func (f *accessFixture) etag() string {
+	ref, _ := f.refs.GetByMongoID(context.Background(), f.mongoID)
+	doc, _ := f.docs.FindByID(context.Background(), f.mongoID)
+	return models.AchievementETag(doc.Version, ref.Version)
+}
+
+func TestAchievementAccessScope(t *testing.T) {
+	ctx := context.Background()
+
+	ownerOnly := map[string]error{
+		actorOwner:        nil,
+		actorOtherStudent: ErrAchievementNotFound,
+		actorAdvisor:      ErrNotAchievementOwner,
+		actorNonAdvisor:   ErrAchievementNotFound,
+		actorAdmin:        ErrNotAchievementOwner,
+	}
+	advisorOnly := map[string]error{
+		actorOwner:        ErrNotStepApprover,
+		actorOtherStudent: ErrAchievementNotFound,
+		actorAdvisor:      nil,
+		actorNonAdvisor:   ErrAchievementNotFound,
+		actorAdmin:        ErrNotStepApprover,
+	}
+
+	tests := []struct {
+		action string
+		status models.AchievementStatus
+		call   func(f *accessFixture, actor models.AchievementActor) error
+		want   map[string]error
+	}{
+		{
+			action: "detail",
+			status: models.StatusDraft,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				_, err := f.achievements.GetByID(ctx, f.mongoID, actor)
+				return err
+			},
+			want: map[string]error{
+				actorOwner:        nil,
+				actorOtherStudent: ErrAchievementNotFound,
+				actorAdvisor:      nil,
+				actorNonAdvisor:   ErrAchievementNotFound,
+				actorAdmin:        nil,
+			},
+		},
+		{
+			action: "update",
+			status: models.StatusDraft,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				_, err := f.achievements.Patch(ctx, f.mongoID, models.PatchMerge, []byte(`{"title":"Juara 2"}`), actor, f.etag())
+				return err
+			},
+			want: ownerOnly,
+		},
+		{
+			action: "delete",
+			status: models.StatusDraft,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				return f.references.Delete(ctx, f.mongoID, actor)
+			},
+			want: ownerOnly,
+		},
+		{
+			action: "submit",
+			status: models.StatusDraft,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				return f.references.Submit(ctx, f.mongoID, actor, f.etag())
+			},
+			want: ownerOnly,
+		},
+		{
+			action: "attachment",
+			status: models.StatusDraft,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				if err := f.achievements.AuthorizeAttachment(ctx, f.mongoID, actor); err != nil {
+					return err
+				}
+				return f.achievements.AddAttachment(ctx, f.mongoID, models.Attachment{
+					FileName: "sertifikat.pdf",
+					FileURL:  "/uploads/sertifikat.pdf",
+				}, actor)
+			},
+			want: ownerOnly,
+		},
+		{
+			action: "verify",
+			status: models.StatusSubmitted,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				_, err := f.references.Verify(ctx, f.mongoID, actor, nil, "", "", f.etag())
+				return err
+			},
+			want: advisorOnly,
+		},
+		{
+			action: "reject",
+			status: models.StatusSubmitted,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				return f.references.Reject(ctx, f.mongoID, "bukti tidak lengkap", actor, f.etag())
+			},
+			want: advisorOnly,
+		},
+		{
+			action: "request revision",
+			status: models.StatusSubmitted,
+			call: func(f *accessFixture, actor models.AchievementActor) error {
+				return f.references.RequestRevision(ctx, f.mongoID, "lengkapi sertifikat", actor, f.etag())
+			},
+			want: advisorOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			t.Run(tt.action+"/"+name, func(t *testing.T) {
+				f := newAccessFixture(t, tt.status)
+				before := f.etag()
+
+				err := tt.call(f, f.actors[name])
+				if !errors.Is(err, want) {
+					t.Fatalf("err = %v, want %v", err, want)
+				}
+				if want != nil && tt.action != "detail" && f.etag() != before {
+					t.Errorf("rejected %s changed the achievement: etag %s -> %s", tt.action, before, f.etag())
+				}
+			})
+		}
+	}
+}
+
+func TestDeletedAchievementVisibleToAdminOnly(t *testing.T) {
+	f := newAccessFixture(t, models.StatusDeleted)
+
+	for name, actor := range f.actors {
+		_, err := f.achievements.GetByID(context.Background(), f.mongoID, actor)
+		if name == actorAdmin {
+			if err != nil {
+				t.Errorf("%s: err = %v, want nil", name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrAchievementNotFound) {
+			t.Errorf("%s: err = %v, want %v", name, err, ErrAchievementNotFound)
+		}
+	}
+}
+
+// fakeAchievementRefs menyimpan referensi prestasi di memori. Transisi hanya
+// mengubah status dan menaikkan versi; failWith membuat semua transisi gagal.
+type fakeAchievementRefs struct {
+	repository.AchievementReferenceRepository
+
+	mu        sync.Mutex
+	byMongoID map[string]*models.AchievementReference
+	failWith  error
+}
+
+func (r *fakeAchievementRefs) GetByMongoID(ctx context.Context, mongoID string) (*models.AchievementReference, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ref, ok := r.byMongoID[mongoID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *ref
+	return &copied, nil
+}
+
+func (r *fakeAchievementRefs) transition(mongoID string, status models.AchievementStatus, expectedVersion *int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failWith != nil {
+		return r.failWith
+	}
+	ref := r.byMongoID[mongoID]
+	if expectedVersion != nil && *expectedVersion != ref.Version {
+		return repository.ErrVersionConflict
+	}
+	ref.Status = status
+	ref.Version++
+	return nil
+}
+
+func (r *fakeAchievementRefs) Submit(ctx context.Context, mongoID string, actorID uuid.UUID, workflowID *uuid.UUID, expectedVersion *int) error {
+	return r.transition(mongoID, models.StatusSubmitted, expectedVersion)
+}
+
+func (r *fakeAchievementRefs) Verify(ctx context.Context, mongoID string, verifierID uuid.UUID, points float64, step *int, override *models.PointsOverride, expectedVersion *int) error {
+	return r.transition(mongoID, models.StatusVerified, expectedVersion)
+}
+
+func (r *fakeAchievementRefs) Reject(ctx context.Context, mongoID string, note string, actorID uuid.UUID, expectedVersion *int) error {
+	return r.transition(mongoID, models.StatusRejected, expectedVersion)
+}
+
+func (r *fakeAchievementRefs) RequestRevision(ctx context.Context, mongoID string, note string, actorID uuid.UUID, expectedVersion *int) error {
+	return r.transition(mongoID, models.StatusRevisionRequested, expectedVersion)
+}
+
+func (r *fakeAchievementRefs) SoftDeleteByMongoID(ctx context.Context, mongoID string, actorID uuid.UUID) error {
+	return r.transition(mongoID, models.StatusDeleted, nil)
+}
+
+func (r *fakeAchievementRefs) CompletePointsSync(ctx context.Context, referenceID uuid.UUID) error {
+	return nil
+}
+
+// fakeAchievementDocs menyimpan dokumen prestasi di memori; setiap tulis
+// menaikkan versi seperti repository MongoDB.
+type fakeAchievementDocs struct {
+	repository.AchievementRepository
+
+	mu   sync.Mutex
+	byID map[string]*models.Achievement
+}
+
+func (r *fakeAchievementDocs) FindByID(ctx context.Context, id string) (*models.Achievement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, ok := r.byID[id]
+	if !ok {
+		return nil, errors.New("achievement not found")
+	}
+	copied := *doc
+	return &copied, nil
+}
+
+func (r *fakeAchievementDocs) write(id string, expectedVersion *int64, apply func(doc *models.Achievement)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc := r.byID[id]
+	if expectedVersion != nil && *expectedVersion != doc.Version {
+		return repository.ErrVersionConflict
+	}
+	apply(doc)
+	doc.Version++
+	return nil
+}
+
+func (r *fakeAchievementDocs) Update(ctx context.Context, id string, achievement *models.Achievement, expectedVersion *int64) error {
+	return r.write(id, expectedVersion, func(doc *models.Achievement) {
+		version := doc.Version
+		*doc = *achievement
+		doc.Version = version
+	})
+}
+
+func (r *fakeAchievementDocs) ClaimVersion(ctx context.Context, id string, expectedVersion int64) error {
+	return r.write(id, &expectedVersion, func(*models.Achievement) {})
+}
+
+func (r *fakeAchievementDocs) ReleaseVersion(ctx context.Context, id string, claimedVersion int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if doc := r.byID[id]; doc.Version == claimedVersion+1 {
+		doc.Version = claimedVersion
+	}
+	return nil
+}
+
+func (r *fakeAchievementDocs) AddAttachment(ctx context.Context, id string, attachment models.Attachment) error {
+	return r.write(id, nil, func(doc *models.Achievement) {
+		doc.Attachments = append(doc.Attachments, attachment)
+	})
+}
+
+func (r *fakeAchievementDocs) UpdatePoints(ctx context.Context, id string, points float64) error {
+	return r.write(id, nil, func(doc *models.Achievement) { doc.Points = points })
+}
+
+func (r *fakeAchievementDocs) SoftDelete(ctx context.Context, id string) error {
+	return r.write(id, nil, func(doc *models.Achievement) { doc.IsDelete = true })
+}
+
+// fakeAdvisors memetakan user dosen wali ke mahasiswa bimbingannya.
+type fakeAdvisors struct {
+	repository.StudentLecturerRepository
+
+	advisees map[uuid.UUID]map[uuid.UUID]bool
+}
+
+func (r fakeAdvisors) IsAdvisorOf(ctx context.Context, advisorUserID uuid.UUID, studentID uuid.UUID) (bool, error) {
+	return r.advisees[advisorUserID][studentID], nil
+}
+
+// fakeWorkflows tidak punya workflow persetujuan, sehingga semua prestasi
+// diverifikasi satu langkah oleh dosen wali.
+type fakeWorkflows struct {
+	repository.ApprovalWorkflowRepository
+}
+
+func (fakeWorkflows) Match(ctx context.Context, achievementType, competitionLevel string) (*models.ApprovalWorkflow, error) {
+	return nil, nil
+}
+
+type fakeRubrics struct {
+	PointsRubricService
+}
+
+func (fakeRubrics) Suggest(ctx context.Context, achievement *models.Achievement, at time.Time) (*models.PointsSuggestion, error) {
+	points := 10.0
+	return &models.PointsSuggestion{Points: &points}, nil
+}
+
+type fakeSchemas struct {
+	AchievementTypeSchemaService
+}
+
+func (fakeSchemas) Validate(ctx context.Context, achievement *models.Achievement) error {
+	return nil
+}
+
+type fakeCatalogs struct {
+	CatalogService
+}
+
+func (fakeCatalogs) Normalize(ctx context.Context, achievement *models.Achievement) ([]models.FieldError, error) {
+	return nil, nil
+}
@@ -2,7 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"log"
+	"math"
+	"strings"
+	"time"
 
 	"backend/app/models"
 	"backend/app/repository"
@@ -11,28 +17,51 @@ import (
 )
 
 type AchievementReferenceService interface {
-	Create(ctx context.Context, studentID uuid.UUID, mongoAchievementID string) (*models.AchievementReference, error)
+	Create(ctx context.Context, studentID uuid.UUID, mongoAchievementID string, actorID uuid.UUID) (*models.AchievementReference, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.AchievementReference, error)
 	GetByMongoID(ctx context.Context, mongoID string) (*models.AchievementReference, error)
 	GetByStudentID(ctx context.Context, studentID uuid.UUID, limit, offset int) ([]models.AchievementReference, error)
-	Submit(ctx context.Context, mongoID string) error
-	Verify(ctx context.Context, mongoID string, verifierID uuid.UUID, points float64) error
-	Reject(ctx context.Context, mongoID string, note string) error
-	Delete(ctx context.Context, mongoID string) error
+	Submit(ctx context.Context, mongoID string, actor models.AchievementActor, ifMatch string) error
+	Verify(ctx context.Context, mongoID string, actor models.AchievementActor, points *float64, justification, note, ifMatch string) (*models.ApprovalResult, error)
+	SuggestPoints(ctx context.Context, mongoID string, actor models.AchievementActor) (*models.PointsSuggestion, error)
+	Reject(ctx context.Context, mongoID string, note string, actor models.AchievementActor, ifMatch string) error
+	RequestRevision(ctx context.Context, mongoID string, note string, actor models.AchievementActor, ifMatch string) error
+	Delete(ctx context.Context, mongoID string, actor models.AchievementActor) error
+	GetHistory(ctx context.Context, mongoID string, actor models.AchievementActor) (*models.AchievementHistoryResponse, error)
+	GetVerificationQueue(ctx context.Context, actor models.AchievementActor, filter models.VerificationQueueFilter) (*models.VerificationQueueResponse, error)
+	BulkVerify(ctx context.Context, actor models.AchievementActor, items []models.BulkVerifyItem) []models.BulkItemResult
+	BulkReject(ctx context.Context, actor models.AchievementActor, items []models.BulkRejectItem) []models.BulkItemResult
+	SyncPendingPoints(ctx context.Context) (int, error)
 }
 
 type achievementReferenceService struct {
 	repo            repository.AchievementReferenceRepository
 	achievementRepo repository.AchievementRepository
+	workflows       repository.ApprovalWorkflowRepository
+	rubrics         PointsRubricService
+	access          *achievementAccess
+	reviewSLA       time.Duration
 }
 
 func NewAchievementReferenceService(
 	repo repository.AchievementReferenceRepository,
 	achievementRepo repository.AchievementRepository,
+	workflows repository.ApprovalWorkflowRepository,
+	studentRepo repository.StudentLecturerRepository,
+	rubrics PointsRubricService,
+	reviewSLA time.Duration,
 ) AchievementReferenceService {
 	return &achievementReferenceService{
 		repo:            repo,
 		achievementRepo: achievementRepo,
+		workflows:       workflows,
+		rubrics:         rubrics,
+		access: &achievementAccess{
+			refRepo:     repo,
+			studentRepo: studentRepo,
+			workflows:   workflows,
+		},
+		reviewSLA: reviewSLA,
 	}
 }
 
@@ -40,6 +69,7 @@ func (s *achievementReferenceService) Create(
 	ctx context.Context,
 	studentID uuid.UUID,
 	mongoAchievementID string,
+	actorID uuid.UUID,
 ) (*models.AchievementReference, error) {
 
 	existing, err := s.repo.GetByMongoID(ctx, mongoAchievementID)
@@ -55,7 +85,7 @@ func (s *achievementReferenceService) Create(
 		MongoAchievementID: mongoAchievementID,
 	}
 
-	if err := s.repo.Create(ctx, ref); err != nil {
+	if err := s.repo.Create(ctx, ref, actorID); err != nil {
 		return nil, err
 	}
 
@@ -84,100 +114,721 @@ func (s *achievementReferenceService) GetByStudentID(
 	return s.repo.GetByStudentID(ctx, studentID, limit, offset)
 }
 
+// Submit mengajukan prestasi. ifMatch (header If-Match) wajib memuat ETag
+// prestasi terkini.
 func (s *achievementReferenceService) Submit(
 	ctx context.Context,
 	mongoID string,
+	actor models.AchievementActor,
+	ifMatch string,
 ) error {
 
-	ref, err := s.repo.GetByMongoID(ctx, mongoID)
+	ref, err := s.access.resolveOwned(ctx, mongoID, actor)
 	if err != nil {
 		return err
 	}
-	if ref == nil {
-		return errors.New("achievement reference not found")
+
+	if _, err := models.NextAchievementStatus(ref.Status, models.ActionSubmit); err != nil {
+		return err
+	}
+
+	achievement, err := s.achievementRepo.FindByID(ctx, mongoID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkIfMatch(ifMatch, achievement, ref); err != nil {
+		return err
+	}
+
+	workflow, err := s.workflows.Match(ctx, achievement.AchievementType, achievement.Details.CompetitionLevel)
+	if err != nil {
+		return err
+	}
+
+	var workflowID *uuid.UUID
+	if workflow != nil && len(workflow.Steps) > 0 {
+		workflowID = &workflow.ID
 	}
 
-	if ref.Status != models.StatusDraft {
-		return errors.New("only draft achievement can be submitted")
+	return s.withClaimedVersion(ctx, achievement, ref, func(expected *int) error {
+		return s.repo.Submit(ctx, mongoID, actor.UserID, workflowID, expected)
+	})
+}
+
+// withClaimedVersion menaikkan versi dokumen MongoDB secara bersyarat,
+// sehingga edit yang masuk setelah If-Match diperiksa gagal dengan konflik
+// versi, lalu menjalankan transisi Postgres dengan versi referensi yang
+// diharapkan. Jika transisi gagal, klaim versi dilepas lagi. Dipanggil
+// tepat sebelum transisi, setelah semua validasi.
+func (s *achievementReferenceService) withClaimedVersion(
+	ctx context.Context,
+	achievement *models.Achievement,
+	ref *models.AchievementReference,
+	transition func(expected *int) error,
+) error {
+
+	if err := s.achievementRepo.ClaimVersion(ctx, ref.MongoAchievementID, achievement.Version); err != nil {
+		return err
 	}
 
-	return s.repo.UpdateStatus(ctx, mongoID, models.StatusSubmitted)
+	version := ref.Version
+	if err := transition(&version); err != nil {
+		if releaseErr := s.achievementRepo.ReleaseVersion(ctx, ref.MongoAchievementID, achievement.Version); releaseErr != nil {
+			log.Println("❌ failed to release achievement version claim:", releaseErr)
+		}
+		return err
+	}
+	return nil
 }
 
+// Verify menyetujui langkah persetujuan yang sedang berjalan. Poin hanya
+// ditetapkan saat langkah terakhir menyetujui; poin di langkah sebelumnya
+// dicatat di riwayat sebagai usulan. Tanpa points, saran rubrik dipakai;
+// poin yang berbeda dari saran wajib disertai justifikasi dan dicatat
+// sebagai override.
 func (s *achievementReferenceService) Verify(
 	ctx context.Context,
 	mongoID string,
-	verifierID uuid.UUID,
-	points float64,
-) error {
-	ref, err := s.repo.GetByMongoID(ctx, mongoID)
+	actor models.AchievementActor,
+	points *float64,
+	justification string,
+	note string,
+	ifMatch string,
+) (*models.ApprovalResult, error) {
+	ref, err := s.access.resolve(ctx, mongoID, actor)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if ref == nil {
-		return errors.New("achievement reference not found")
+
+	if _, err := models.NextAchievementStatus(ref.Status, models.ActionVerify); err != nil {
+		return nil, err
 	}
 
-	if ref.Status != models.StatusSubmitted {
-		return errors.New("only submitted achievement can be verified")
+	step, next, err := s.access.currentStep(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.access.authorizeStep(ctx, ref, step, actor); err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.Verify(ctx, mongoID, verifierID); err != nil {
-		return err
+	achievement, err := s.achievementRepo.FindByID(ctx, mongoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkIfMatch(ifMatch, achievement, ref); err != nil {
+		return nil, err
+	}
+
+	suggestion, err := s.suggest(ctx, ref, achievement)
+	if err != nil {
+		return nil, err
+	}
+
+	awarded, override, err := awardPoints(ref.ID, suggestion, points, justification, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.withClaimedVersion(ctx, achievement, ref, func(expected *int) error {
+		if next != nil {
+			var notePtr *string
+			if note != "" {
+				notePtr = &note
+			}
+			return s.repo.ApproveStep(ctx, mongoID, *ref.CurrentStep, actor.UserID, notePtr, &awarded, override, expected)
+		}
+		return s.repo.Verify(ctx, mongoID, actor.UserID, awarded, ref.CurrentStep, override, expected)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if next != nil {
+		return &models.ApprovalResult{
+			Status:      models.StatusSubmitted,
+			CurrentStep: &next.StepOrder,
+			NextStep:    next,
+			Points:      awarded,
+			Suggestion:  suggestion,
+		}, nil
+	}
+
+	result := &models.ApprovalResult{
+		Status:      models.StatusVerified,
+		Finalized:   true,
+		CurrentStep: ref.CurrentStep,
+		Points:      awarded,
+		Suggestion:  suggestion,
 	}
 
-	if err := s.achievementRepo.UpdatePoints(ctx, mongoID, points); err != nil {
+	// Verifikasi sudah di-commit; kegagalan menulis poin tidak membatalkan
+	// hasilnya. Poin tetap tercatat di outbox dan diulang oleh
+	// SyncPendingPoints.
+	if err := s.syncPoints(ctx, ref.ID, mongoID, awarded); err != nil {
+		log.Println("⚠️  points sync pending for achievement", mongoID+":", err)
+		result.PointsSyncPending = true
+	}
+
+	return result, nil
+}
+
+// SuggestPoints mengembalikan saran poin rubrik untuk sebuah prestasi.
+func (s *achievementReferenceService) SuggestPoints(
+	ctx context.Context,
+	mongoID string,
+	actor models.AchievementActor,
+) (*models.PointsSuggestion, error) {
+
+	ref, err := s.access.resolve(ctx, mongoID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	achievement, err := s.achievementRepo.FindByID(ctx, mongoID)
+	if err != nil {
+		return nil, err
+	}
+	return s.suggest(ctx, ref, achievement)
+}
+
+// suggest menilai prestasi dengan rubrik yang berlaku saat diajukan, atau
+// rubrik yang berlaku sekarang jika belum diajukan.
+func (s *achievementReferenceService) suggest(
+	ctx context.Context,
+	ref *models.AchievementReference,
+	achievement *models.Achievement,
+) (*models.PointsSuggestion, error) {
+
+	at := time.Now()
+	if ref.SubmittedAt != nil {
+		at = *ref.SubmittedAt
+	}
+	return s.rubrics.Suggest(ctx, achievement, at)
+}
+
+// awardPoints menentukan poin yang diberikan. Poin yang berbeda dari saran
+// rubrik, atau diberikan tanpa saran, menghasilkan catatan override.
+func awardPoints(
+	refID uuid.UUID,
+	suggestion *models.PointsSuggestion,
+	points *float64,
+	justification string,
+	actorID uuid.UUID,
+) (float64, *models.PointsOverride, error) {
+
+	if points == nil {
+		if suggestion.Points == nil {
+			return 0, nil, errors.New("points are required: no rubric rule matches this achievement")
+		}
+		return *suggestion.Points, nil, nil
+	}
+
+	if *points < 0 {
+		return 0, nil, errors.New("points must not be negative")
+	}
+	if suggestion.Points != nil && math.Abs(*points-*suggestion.Points) < 0.005 {
+		return *points, nil, nil
+	}
+
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return 0, nil, errors.New("justification is required to override suggested points")
+	}
+
+	return *points, &models.PointsOverride{
+		ID:                     uuid.New(),
+		AchievementReferenceID: refID,
+		RubricID:               suggestion.RubricID,
+		SuggestedPoints:        suggestion.Points,
+		AwardedPoints:          *points,
+		Justification:          justification,
+		ActorID:                actorID,
+		CreatedAt:              time.Now(),
+	}, nil
+}
+
+const (
+	updatePointsAttempts = 3
+	updatePointsBackoff  = 200 * time.Millisecond
+)
+
+// syncPoints menulis poin final ke MongoDB lalu menghapus catatan outbox-nya;
+// jika gagal, percobaan dicatat di outbox.
+func (s *achievementReferenceService) syncPoints(ctx context.Context, refID uuid.UUID, mongoID string, points float64) error {
+	if err := s.updatePoints(ctx, mongoID, points); err != nil {
+		if recErr := s.repo.FailPointsSync(ctx, refID, err.Error()); recErr != nil {
+			log.Println("❌ failed to record points sync failure:", recErr)
+		}
 		return err
 	}
+	return s.repo.CompletePointsSync(ctx, refID)
+}
 
-	return nil
+const pointsSyncBatch = 100
+
+// SyncPendingPoints mengulang penulisan poin yang tertunda di outbox dan
+// mengembalikan jumlah yang berhasil. Aman dijalankan berulang.
+func (s *achievementReferenceService) SyncPendingPoints(ctx context.Context) (int, error) {
+	pending, err := s.repo.ListPendingPointsSync(ctx, pointsSyncBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	synced := 0
+	for _, p := range pending {
+		if err := s.achievementRepo.UpdatePoints(ctx, p.MongoAchievementID, p.Points); err != nil {
+			if err := s.repo.FailPointsSync(ctx, p.AchievementReferenceID, err.Error()); err != nil {
+				return synced, err
+			}
+			continue
+		}
+		if err := s.repo.CompletePointsSync(ctx, p.AchievementReferenceID); err != nil {
+			return synced, err
+		}
+		synced++
+	}
+	return synced, nil
+}
+
+// updatePoints menulis poin final ke MongoDB dengan beberapa kali percobaan.
+// Aman diulang karena hanya $set nilai yang sama.
+func (s *achievementReferenceService) updatePoints(ctx context.Context, mongoID string, points float64) error {
+	var err error
+	for attempt := 0; attempt < updatePointsAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(updatePointsBackoff << (attempt - 1)):
+			}
+		}
+		if err = s.achievementRepo.UpdatePoints(ctx, mongoID, points); err == nil {
+			return nil
+		}
+	}
+	return err
 }
 
 func (s *achievementReferenceService) Reject(
 	ctx context.Context,
 	mongoID string,
 	note string,
+	actor models.AchievementActor,
+	ifMatch string,
 ) error {
 
 	if note == "" {
 		return errors.New("rejection note is required")
 	}
 
-	ref, err := s.repo.GetByMongoID(ctx, mongoID)
+	ref, err := s.access.resolve(ctx, mongoID, actor)
 	if err != nil {
 		return err
 	}
-	if ref == nil {
-		return errors.New("achievement reference not found")
+
+	if _, err := models.NextAchievementStatus(ref.Status, models.ActionReject); err != nil {
+		return err
 	}
 
-	if ref.Status != models.StatusSubmitted {
-		return errors.New("only submitted achievement can be rejected")
+	step, _, err := s.access.currentStep(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if err := s.access.authorizeStep(ctx, ref, step, actor); err != nil {
+		return err
 	}
 
-	return s.repo.Reject(ctx, mongoID, note)
+	achievement, err := s.achievementRepo.FindByID(ctx, mongoID)
+	if err != nil {
+		return err
+	}
+	if err := checkIfMatch(ifMatch, achievement, ref); err != nil {
+		return err
+	}
+
+	return s.withClaimedVersion(ctx, achievement, ref, func(expected *int) error {
+		return s.repo.Reject(ctx, mongoID, note, actor.UserID, expected)
+	})
 }
 
-func (s *achievementReferenceService) Delete(
+// RequestRevision mengembalikan prestasi ke mahasiswa untuk diperbaiki lalu
+// diajukan ulang. ifMatch (header If-Match) wajib memuat ETag prestasi
+// terkini.
+func (s *achievementReferenceService) RequestRevision(
 	ctx context.Context,
 	mongoID string,
+	note string,
+	actor models.AchievementActor,
+	ifMatch string,
 ) error {
 
-	ref, err := s.repo.GetByMongoID(ctx, mongoID)
+	if note == "" {
+		return errors.New("revision note is required")
+	}
+
+	ref, err := s.access.resolve(ctx, mongoID, actor)
+	if err != nil {
+		return err
+	}
+
+	if _, err := models.NextAchievementStatus(ref.Status, models.ActionRequestRevision); err != nil {
+		return err
+	}
+
+	step, _, err := s.access.currentStep(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if err := s.access.authorizeStep(ctx, ref, step, actor); err != nil {
+		return err
+	}
+
+	achievement, err := s.achievementRepo.FindByID(ctx, mongoID)
 	if err != nil {
 		return err
 	}
-	if ref == nil {
-		return errors.New("achievement not found")
+	if err := checkIfMatch(ifMatch, achievement, ref); err != nil {
+		return err
 	}
 
-	if ref.Status != models.StatusDraft {
-		return errors.New("only draft achievement can be deleted")
+	return s.withClaimedVersion(ctx, achievement, ref, func(expected *int) error {
+		return s.repo.RequestRevision(ctx, mongoID, note, actor.UserID, expected)
+	})
+}
+
+func (s *achievementReferenceService) Delete(
+	ctx context.Context,
+	mongoID string,
+	actor models.AchievementActor,
+) error {
+
+	ref, err := s.access.resolveOwned(ctx, mongoID, actor)
+	if err != nil {
+		return err
+	}
+
+	if _, err := models.NextAchievementStatus(ref.Status, models.ActionDelete); err != nil {
+		return err
 	}
 
 	if err := s.achievementRepo.SoftDelete(ctx, mongoID); err != nil {
 		return err
 	}
 
-	return s.repo.SoftDeleteByMongoID(ctx, mongoID)
+	return s.repo.SoftDeleteByMongoID(ctx, mongoID, actor.UserID)
+}
+
+// GetHistory mengembalikan data prestasi beserta seluruh riwayat statusnya.
+func (s *achievementReferenceService) GetHistory(
+	ctx context.Context,
+	mongoID string,
+	actor models.AchievementActor,
+) (*models.AchievementHistoryResponse, error) {
+
+	ref, err := s.access.resolve(ctx, mongoID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := s.repo.GetHistory(ctx, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.rubrics.GetOverrides(ctx, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AchievementHistoryResponse{
+		Achievement: *ref,
+		Timeline:    timeline,
+		Overrides:   overrides,
+	}, nil
+}
+
+const (
+	defaultQueueLimit = 20
+	maxQueueLimit     = 100
+)
+
+// queueMongoSortFields memetakan urutan antrean yang kuncinya ada di
+// MongoDB ke field dokumennya.
+var queueMongoSortFields = map[string]string{
+	models.QueueSortTitle: "title",
+	models.QueueSortType:  "achievementType",
+}
+
+// GetVerificationQueue mengembalikan prestasi mahasiswa bimbingan yang
+// menunggu verifikasi pemanggil, lengkap dengan judul dan tipe dari MongoDB,
+// lama menunggu dan penanda lewat SLA. Halaman dipotong dengan keyset di
+// PostgreSQL (submittedAt, student) atau di MongoDB (title, type).
+func (s *achievementReferenceService) GetVerificationQueue(
+	ctx context.Context,
+	actor models.AchievementActor,
+	filter models.VerificationQueueFilter,
+) (*models.VerificationQueueResponse, error) {
+
+	if filter.Sort == "" {
+		filter.Sort = models.QueueSortSubmittedAt
+	}
+	if _, ok := queueMongoSortFields[filter.Sort]; !ok && !repository.IsSQLQueueSort(filter.Sort) {
+		return nil, errors.New("invalid sort field")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultQueueLimit
+	}
+	if filter.Limit > maxQueueLimit {
+		filter.Limit = maxQueueLimit
+	}
+
+	var after *models.QueueCursor
+	if filter.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(filter.Cursor)
+		if err != nil {
+			return nil, errInvalidQueueCursor
+		}
+		after = &models.QueueCursor{}
+		if err := json.Unmarshal(raw, after); err != nil || !validQueueCursor(filter.Sort, after) {
+			return nil, errInvalidQueueCursor
+		}
+	}
+
+	var (
+		page []models.VerificationQueueItem
+		next *models.QueueCursor
+		err  error
+	)
+	if repository.IsSQLQueueSort(filter.Sort) {
+		page, next, err = s.queuePageBySQL(ctx, actor, filter, after)
+	} else {
+		page, next, err = s.queuePageByMongo(ctx, actor, filter, after)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidQueueCursor) {
+			return nil, errInvalidQueueCursor
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range page {
+		waiting := now.Sub(page[i].SubmittedAt)
+		page[i].DaysWaiting = int(waiting.Hours() / 24)
+		page[i].Overdue = s.reviewSLA > 0 && waiting > s.reviewSLA
+	}
+
+	resp := &models.VerificationQueueResponse{
+		Items:   page,
+		SLADays: int(s.reviewSLA.Hours() / 24),
+	}
+	if next != nil {
+		raw, err := json.Marshal(next)
+		if err != nil {
+			return nil, err
+		}
+		encoded := base64.RawURLEncoding.EncodeToString(raw)
+		resp.NextCursor = &encoded
+	}
+	return resp, nil
+}
+
+var errInvalidQueueCursor = errors.New("invalid cursor")
+
+// validQueueCursor memeriksa bentuk cursor sesuai sumber urutannya agar
+// cursor rusak ditolak sebagai 400, bukan galat query.
+func validQueueCursor(sortField string, c *models.QueueCursor) bool {
+	if _, ok := queueMongoSortFields[sortField]; ok {
+		return c.ID != ""
+	}
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return false
+	}
+	if sortField == models.QueueSortSubmittedAt {
+		_, err := time.Parse("2006-01-02 15:04:05.999999999", c.Key)
+		return err == nil
+	}
+	return true
+}
+
+// queuePageBySQL mengambil halaman antrean dengan keyset di PostgreSQL.
+// Filter tipe/tingkat ada di MongoDB, jadi baris diambil per batch sampai
+// halaman terisi atau antrean habis.
+func (s *achievementReferenceService) queuePageBySQL(
+	ctx context.Context,
+	actor models.AchievementActor,
+	filter models.VerificationQueueFilter,
+	after *models.QueueCursor,
+) ([]models.VerificationQueueItem, *models.QueueCursor, error) {
+
+	batch := filter.Limit + 1
+	page := make([]models.VerificationQueueItem, 0, batch)
+
+	for len(page) <= filter.Limit {
+		rows, err := s.repo.GetVerificationQueue(ctx, actor.UserID, actor.Role, filter, after, batch)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		ids := make([]string, len(rows))
+		for i := range rows {
+			ids[i] = rows[i].MongoAchievementID
+		}
+		achievements, err := s.achievementRepo.FindSummaries(ctx, models.AchievementSummaryQuery{
+			IDs:              ids,
+			AchievementType:  filter.AchievementType,
+			CompetitionLevel: filter.CompetitionLevel,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		byID := make(map[string]*models.Achievement, len(achievements))
+		for i := range achievements {
+			byID[achievements[i].ID.Hex()] = &achievements[i]
+		}
+
+		for _, item := range rows {
+			if a, ok := byID[item.MongoAchievementID]; ok {
+				page = append(page, withSummary(item, a))
+				if len(page) > filter.Limit {
+					break
+				}
+			}
+		}
+
+		if len(rows) < batch {
+			break
+		}
+		last := rows[len(rows)-1]
+		after = &models.QueueCursor{Key: last.SortKey, ID: last.ID.String()}
+	}
+
+	if len(page) <= filter.Limit {
+		return page, nil, nil
+	}
+	page = page[:filter.Limit]
+	last := page[len(page)-1]
+	return page, &models.QueueCursor{Key: last.SortKey, ID: last.ID.String()}, nil
 }
+
+// queuePageByMongo mengambil halaman antrean yang diurutkan menurut field
+// MongoDB. PostgreSQL hanya menentukan prestasi mana yang masuk antrean;
+// filter, urutan dan keyset dijalankan di MongoDB.
+func (s *achievementReferenceService) queuePageByMongo(
+	ctx context.Context,
+	actor models.AchievementActor,
+	filter models.VerificationQueueFilter,
+	after *models.QueueCursor,
+) ([]models.VerificationQueueItem, *models.QueueCursor, error) {
+
+	rows, err := s.repo.GetVerificationQueue(ctx, actor.UserID, actor.Role, filter, nil, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return []models.VerificationQueueItem{}, nil, nil
+	}
+
+	ids := make([]string, len(rows))
+	byMongoID := make(map[string]models.VerificationQueueItem, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].MongoAchievementID
+		byMongoID[rows[i].MongoAchievementID] = rows[i]
+	}
+
+	field := queueMongoSortFields[filter.Sort]
+	achievements, err := s.achievementRepo.FindSummaries(ctx, models.AchievementSummaryQuery{
+		IDs:              ids,
+		AchievementType:  filter.AchievementType,
+		CompetitionLevel: filter.CompetitionLevel,
+		SortField:        field,
+		Desc:             filter.Desc,
+		After:            after,
+		Limit:            filter.Limit + 1,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *models.QueueCursor
+	if len(achievements) > filter.Limit {
+		achievements = achievements[:filter.Limit]
+		last := achievements[len(achievements)-1]
+		key := last.Title
+		if field == "achievementType" {
+			key = last.AchievementType
+		}
+		next = &models.QueueCursor{Key: key, ID: last.ID.Hex()}
+	}
+
+	page := make([]models.VerificationQueueItem, 0, len(achievements))
+	for i := range achievements {
+		page = append(page, withSummary(byMongoID[achievements[i].ID.Hex()], &achievements[i]))
+	}
+	return page, next, nil
+}
+
+// withSummary melengkapi item antrean dengan judul, tipe dan tingkat dari
+// dokumen MongoDB.
+func withSummary(item models.VerificationQueueItem, a *models.Achievement) models.VerificationQueueItem {
+	item.Title = a.Title
+	item.AchievementType = a.AchievementType
+	item.CompetitionLevel = a.Details.CompetitionLevel
+	return item
+}
+
+// BulkVerify memverifikasi banyak prestasi; setiap item diperiksa dan
+// ditransisikan sendiri-sendiri (satu transaksi per item) sehingga kegagalan
+// satu item tidak memengaruhi item lain.
+func (s *achievementReferenceService) BulkVerify(
+	ctx context.Context,
+	actor models.AchievementActor,
+	items []models.BulkVerifyItem,
+) []models.BulkItemResult {
+
+	results := make([]models.BulkItemResult, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		results[i].ID = item.ID
+		if seen[item.ID] {
+			results[i].Err = errDuplicateBulkItem
+			continue
+		}
+		seen[item.ID] = true
+
+		results[i].Result, results[i].Err = s.Verify(ctx, item.ID, actor, item.Points, item.Justification, item.Note, item.IfMatch)
+		results[i].OK = results[i].Err == nil
+	}
+	return results
+}
+
+// BulkReject menolak banyak prestasi, satu transaksi per item.
+func (s *achievementReferenceService) BulkReject(
+	ctx context.Context,
+	actor models.AchievementActor,
+	items []models.BulkRejectItem,
+) []models.BulkItemResult {
+
+	results := make([]models.BulkItemResult, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		results[i].ID = item.ID
+		if seen[item.ID] {
+			results[i].Err = errDuplicateBulkItem
+			continue
+		}
+		seen[item.ID] = true
+
+		results[i].Err = s.Reject(ctx, item.ID, item.Note, actor, item.IfMatch)
+		results[i].OK = results[i].Err == nil
+	}
+	return results
+}
+
+var errDuplicateBulkItem = errors.New("duplicate id in batch")
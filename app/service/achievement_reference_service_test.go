@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/app/models"
+	"backend/app/repository"
+)
+
+// transitionCall menjalankan satu transisi status dengan If-Match tertentu.
+type transitionCall struct {
+	action string
+	status models.AchievementStatus
+	actor  string
+	call   func(f *accessFixture, actor models.AchievementActor, ifMatch string) error
+}
+
+func transitionCalls() []transitionCall {
+	ctx := context.Background()
+
+	return []transitionCall{
+		{"submit", models.StatusDraft, actorOwner, func(f *accessFixture, actor models.AchievementActor, ifMatch string) error {
+			return f.references.Submit(ctx, f.mongoID, actor, ifMatch)
+		}},
+		{"verify", models.StatusSubmitted, actorAdvisor, func(f *accessFixture, actor models.AchievementActor, ifMatch string) error {
+			_, err := f.references.Verify(ctx, f.mongoID, actor, nil, "", "", ifMatch)
+			return err
+		}},
+		{"reject", models.StatusSubmitted, actorAdvisor, func(f *accessFixture, actor models.AchievementActor, ifMatch string) error {
+			return f.references.Reject(ctx, f.mongoID, "bukti tidak lengkap", actor, ifMatch)
+		}},
+		{"request revision", models.StatusSubmitted, actorAdvisor, func(f *accessFixture, actor models.AchievementActor, ifMatch string) error {
+			return f.references.RequestRevision(ctx, f.mongoID, "lengkapi sertifikat", actor, ifMatch)
+		}},
+	}
+}
+
+func TestTransitionsRequireIfMatch(t *testing.T) {
+	for _, tt := range transitionCalls() {
+		t.Run(tt.action, func(t *testing.T) {
+			f := newAccessFixture(t, tt.status)
+			actor := f.actors[tt.actor]
+
+			attempts := []struct {
+				ifMatch string
+				want    error
+			}{
+				{"", ErrIfMatchRequired},
+				{"*", ErrIfMatchRequired},
+				{`"0-0"`, repository.ErrVersionConflict},
+				{f.etag(), nil},
+			}
+			for _, a := range attempts {
+				if err := tt.call(f, actor, a.ifMatch); !errors.Is(err, a.want) {
+					t.Errorf("If-Match %q: err = %v, want %v", a.ifMatch, err, a.want)
+				}
+			}
+		})
+	}
+}
+
+// Klaim versi MongoDB harus dilepas saat transisi Postgres gagal, agar ETag
+// yang dipegang klien masih bisa dipakai untuk mencoba lagi.
+func TestFailedTransitionReleasesVersionClaim(t *testing.T) {
+	errTransition := errors.New("database is unavailable")
+
+	for _, tt := range transitionCalls() {
+		t.Run(tt.action, func(t *testing.T) {
+			f := newAccessFixture(t, tt.status)
+			actor := f.actors[tt.actor]
+			etag := f.etag()
+
+			f.refs.failWith = errTransition
+			if err := tt.call(f, actor, etag); !errors.Is(err, errTransition) {
+				t.Fatalf("err = %v, want %v", err, errTransition)
+			}
+			if f.etag() != etag {
+				t.Fatalf("failed %s left etag %s, want %s", tt.action, f.etag(), etag)
+			}
+
+			f.refs.failWith = nil
+			if err := tt.call(f, actor, etag); err != nil {
+				t.Fatalf("retry with the same ETag: %v", err)
+			}
+		})
+	}
+}
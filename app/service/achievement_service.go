@@ -1,33 +1,98 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"reflect"
+	"strings"
 	"time"
 
 	"backend/app/models"
 	"backend/app/repository"
+	"backend/app/utils"
 )
 
 type AchievementService interface {
 	Create(ctx context.Context, achievement *models.Achievement) (string, error)
-	GetByID(ctx context.Context, id string) (*models.Achievement, error)
-	Update(ctx context.Context, id string, achievement *models.Achievement) error
-	AddAttachment(ctx context.Context, id string, attachment models.Attachment) error
+	GetByID(ctx context.Context, id string, actor models.AchievementActor) (*models.Achievement, error)
+	Patch(ctx context.Context, id string, kind string, body []byte, actor models.AchievementActor, ifMatch string) (*models.Achievement, error)
+	AuthorizeAttachment(ctx context.Context, id string, actor models.AchievementActor) error
+	AddAttachment(ctx context.Context, id string, attachment models.Attachment, actor models.AchievementActor) error
 }
 
 type achievementService struct {
-	repo repository.AchievementRepository
+	repo     repository.AchievementRepository
+	schemas  AchievementTypeSchemaService
+	catalogs CatalogService
+	access   *achievementAccess
 }
 
 func NewAchievementService(
 	repo repository.AchievementRepository,
+	refRepo repository.AchievementReferenceRepository,
+	studentRepo repository.StudentLecturerRepository,
+	workflows repository.ApprovalWorkflowRepository,
+	schemas AchievementTypeSchemaService,
+	catalogs CatalogService,
 ) AchievementService {
 	return &achievementService{
-		repo: repo,
+		repo:     repo,
+		schemas:  schemas,
+		catalogs: catalogs,
+		access: &achievementAccess{
+			refRepo:     refRepo,
+			studentRepo: studentRepo,
+			workflows:   workflows,
+		},
 	}
 }
 
+// ensureEditable memastikan pemanggil adalah mahasiswa pemilik prestasi dan
+// isinya masih boleh diubah menurut tabel transisi (draft atau
+// revision_requested).
+func (s *achievementService) ensureEditable(ctx context.Context, id string, actor models.AchievementActor) (*models.AchievementReference, error) {
+	ref, err := s.access.resolveOwned(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := models.NextAchievementStatus(ref.Status, models.ActionEdit); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// validate menormalkan nilai katalog lalu memeriksa skema tipe prestasi;
+// semua pelanggaran dikembalikan sekaligus sebagai SchemaValidationError.
+func (s *achievementService) validate(ctx context.Context, achievement *models.Achievement) error {
+	fieldErrs, err := s.catalogs.Normalize(ctx, achievement)
+	if err != nil {
+		return err
+	}
+
+	// Skema tidak bisa dicari jika tipenya sendiri tidak dikenal.
+	for _, fe := range fieldErrs {
+		if fe.Field == "achievementType" {
+			return models.NewSchemaValidationError(fieldErrs)
+		}
+	}
+
+	if err := s.schemas.Validate(ctx, achievement); err != nil {
+		var schemaErr *models.SchemaValidationError
+		if !errors.As(err, &schemaErr) {
+			return err
+		}
+		fieldErrs = append(fieldErrs, schemaErr.Errors...)
+	}
+
+	if len(fieldErrs) > 0 {
+		return models.NewSchemaValidationError(fieldErrs)
+	}
+	return nil
+}
+
 func (s *achievementService) Create(
 	ctx context.Context,
 	achievement *models.Achievement,
@@ -45,6 +110,10 @@ func (s *achievementService) Create(
 		return "", errors.New("student_id is required")
 	}
 
+	if err := s.validate(ctx, achievement); err != nil {
+		return "", err
+	}
+
 	if achievement.Attachments == nil {
 		achievement.Attachments = []models.Attachment{}
 	}
@@ -58,48 +127,178 @@ func (s *achievementService) Create(
 func (s *achievementService) GetByID(
 	ctx context.Context,
 	id string,
+	actor models.AchievementActor,
 ) (*models.Achievement, error) {
 
 	if id == "" {
 		return nil, errors.New("achievement id is required")
 	}
 
-	return s.repo.FindByID(ctx, id)
+	ref, err := s.access.resolve(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	achievement, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	achievement.ETag = models.AchievementETag(achievement.Version, ref.Version)
+	return achievement, nil
+}
+
+// protectedAchievementFields adalah field JSON yang tidak boleh diubah lewat
+// patch: poin, pemilik, status (termasuk nama field status dari referensi
+// Postgres), lampiran (lewat endpoint sendiri) dan field sistem.
+var protectedAchievementFields = []string{
+	"id", "studentId", "points", "attachments",
+	"status", "submittedAt", "verifiedAt", "verifiedBy", "rejectionNote", "revisionNote",
+	"approvalWorkflowId", "currentStep",
+	"createdAt", "updatedAt", "deletedAt", "is_deleted", "version",
 }
-func (s *achievementService) Update(
+
+// Patch menerapkan JSON Merge Patch atau JSON Patch pada prestasi yang masih
+// boleh diubah, lalu memvalidasi hasilnya seperti saat membuat prestasi.
+// ifMatch (header If-Match) wajib memuat ETag prestasi terkini; versi
+// dokumen diperiksa ulang saat menulis.
+func (s *achievementService) Patch(
 	ctx context.Context,
 	id string,
-	payload *models.Achievement,
-) error {
+	kind string,
+	body []byte,
+	actor models.AchievementActor,
+	ifMatch string,
+) (*models.Achievement, error) {
 
 	if id == "" {
-		return errors.New("achievement id is required")
+		return nil, errors.New("achievement id is required")
 	}
-	if payload == nil {
-		return errors.New("update payload is required")
+
+	ref, err := s.ensureEditable(ctx, id, actor)
+	if err != nil {
+		return nil, err
 	}
 
 	existing, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := checkIfMatch(ifMatch, existing, ref); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(existing)
+	if err != nil {
+		return nil, err
+	}
+	var before, doc interface{}
+	if err := json.Unmarshal(raw, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case models.PatchMerge:
+		var patch interface{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			return nil, errors.New("invalid merge patch: " + err.Error())
+		}
+		if _, ok := patch.(map[string]interface{}); !ok {
+			return nil, errors.New("invalid merge patch: body must be a JSON object")
+		}
+		doc = utils.MergePatch(doc, patch)
+
+	case models.PatchJSON:
+		var ops []utils.PatchOperation
+		if err := json.Unmarshal(body, &ops); err != nil {
+			return nil, errors.New("invalid JSON patch: " + err.Error())
+		}
+		if doc, err = utils.ApplyPatch(doc, ops); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, errors.New("unsupported patch type")
+	}
+
+	after, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("patched achievement must be a JSON object")
 	}
+	beforeObj := before.(map[string]interface{})
 
-	if payload.Title != "" {
-		existing.Title = payload.Title
+	var denied []models.FieldError
+	for _, field := range protectedAchievementFields {
+		if !reflect.DeepEqual(beforeObj[field], after[field]) {
+			denied = append(denied, models.FieldError{Field: field, Message: "is read-only"})
+		}
 	}
-	if payload.Description != "" {
-		existing.Description = payload.Description
+	if len(denied) > 0 {
+		return nil, models.NewSchemaValidationError(denied)
 	}
 
-	existing.UpdatedAt = time.Now()
+	raw, err = json.Marshal(after)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var updated models.Achievement
+	if err := dec.Decode(&updated); err != nil {
+		return nil, errors.New("invalid patched achievement: " + err.Error())
+	}
+
+	// Field yang dilindungi selalu diambil dari dokumen tersimpan.
+	updated.ID = existing.ID
+	updated.StudentID = existing.StudentID
+	updated.Points = existing.Points
+	updated.Attachments = existing.Attachments
+	updated.CreatedAt = existing.CreatedAt
+	updated.DeletedAt = existing.DeletedAt
+	updated.IsDelete = existing.IsDelete
+	updated.Version = existing.Version
+
+	if strings.TrimSpace(updated.Title) == "" {
+		return nil, errors.New("achievement title is required")
+	}
 
-	return s.repo.Update(ctx, id, existing)
+	if err := s.validate(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	updated.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, id, &updated, &existing.Version); err != nil {
+		return nil, err
+	}
+	updated.ETag = models.AchievementETag(updated.Version, ref.Version)
+	return &updated, nil
+}
+
+// AuthorizeAttachment memeriksa bahwa pemanggil boleh menambah lampiran,
+// sebelum file diunggah disimpan ke disk.
+func (s *achievementService) AuthorizeAttachment(
+	ctx context.Context,
+	id string,
+	actor models.AchievementActor,
+) error {
+
+	if id == "" {
+		return errors.New("achievement id is required")
+	}
+
+	_, err := s.ensureEditable(ctx, id, actor)
+	return err
 }
 
 func (s *achievementService) AddAttachment(
 	ctx context.Context,
 	id string,
 	attachment models.Attachment,
+	actor models.AchievementActor,
 ) error {
 
 	if id == "" {
@@ -110,6 +309,10 @@ func (s *achievementService) AddAttachment(
 		return errors.New("attachment file_url is required")
 	}
 
+	if _, err := s.ensureEditable(ctx, id, actor); err != nil {
+		return err
+	}
+
 	attachment.UploadedAt = time.Now()
 
 	return s.repo.AddAttachment(ctx, id, attachment)
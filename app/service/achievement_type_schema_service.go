@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+type AchievementTypeSchemaService interface {
+	GetAll(ctx context.Context) ([]models.AchievementTypeSchema, error)
+	GetByType(ctx context.Context, achievementType string) (*models.AchievementTypeSchema, error)
+	Save(ctx context.Context, achievementType string, req *models.AchievementTypeSchemaRequest, actorID uuid.UUID) (*models.AchievementTypeSchema, error)
+	Delete(ctx context.Context, achievementType string) error
+	Validate(ctx context.Context, achievement *models.Achievement) error
+}
+
+type achievementTypeSchemaService struct {
+	repo repository.AchievementTypeSchemaRepository
+}
+
+func NewAchievementTypeSchemaService(repo repository.AchievementTypeSchemaRepository) AchievementTypeSchemaService {
+	return &achievementTypeSchemaService{repo: repo}
+}
+
+// detailFieldTypes memetakan nama JSON field DynamicDetails ke tipe skema.
+var detailFieldTypes = func() map[string]string {
+	types := map[string]string{}
+	t := reflect.TypeOf(models.DynamicDetails{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		switch {
+		case f.Type == reflect.TypeOf(time.Time{}):
+			types[name] = models.FieldTypeDate
+		case f.Type == reflect.TypeOf(&models.Period{}):
+			types[name] = models.FieldTypePeriod
+		case f.Type.Kind() == reflect.Slice:
+			types[name] = models.FieldTypeStringArray
+		case f.Type.Kind() == reflect.Int:
+			types[name] = models.FieldTypeInteger
+		case f.Type.Kind() == reflect.Float64:
+			types[name] = models.FieldTypeNumber
+		default:
+			types[name] = models.FieldTypeString
+		}
+	}
+	return types
+}()
+
+func (s *achievementTypeSchemaService) GetAll(ctx context.Context) ([]models.AchievementTypeSchema, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *achievementTypeSchemaService) GetByType(ctx context.Context, achievementType string) (*models.AchievementTypeSchema, error) {
+	schema, err := s.repo.FindByType(ctx, achievementType)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, errors.New("achievement type schema not found")
+	}
+	return schema, nil
+}
+
+// Save mengganti seluruh skema satu tipe. Tipe field details selalu diambil
+// dari DynamicDetails; field customFields wajib menyebut tipenya.
+func (s *achievementTypeSchemaService) Save(
+	ctx context.Context,
+	achievementType string,
+	req *models.AchievementTypeSchemaRequest,
+	actorID uuid.UUID,
+) (*models.AchievementTypeSchema, error) {
+
+	achievementType = strings.ToLower(strings.TrimSpace(achievementType))
+	if achievementType == "" || len(achievementType) > 50 {
+		return nil, errors.New("invalid achievement type")
+	}
+
+	details, err := normalizeFieldSpecs(req.Details, true)
+	if err != nil {
+		return nil, err
+	}
+	customFields, err := normalizeFieldSpecs(req.CustomFields, false)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &models.AchievementTypeSchema{
+		AchievementType:          achievementType,
+		Details:                  details,
+		CustomFields:             customFields,
+		AllowUnknownCustomFields: req.AllowUnknownCustomFields,
+		UpdatedBy:                &actorID,
+		UpdatedAt:                time.Now(),
+	}
+
+	if err := s.repo.Save(ctx, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func normalizeFieldSpecs(specs []models.FieldSpec, details bool) ([]models.FieldSpec, error) {
+	seen := make(map[string]bool, len(specs))
+	out := make([]models.FieldSpec, len(specs))
+	for i, f := range specs {
+		f.Name = strings.TrimSpace(f.Name)
+		if seen[f.Name] {
+			return nil, fmt.Errorf("duplicate field %q", f.Name)
+		}
+		seen[f.Name] = true
+
+		if details {
+			t, ok := detailFieldTypes[f.Name]
+			if !ok {
+				return nil, fmt.Errorf("unknown details field %q", f.Name)
+			}
+			f.Type = t
+		} else if f.Type == "" {
+			return nil, fmt.Errorf("field %q needs a type", f.Name)
+		}
+
+		if len(f.Enum) > 0 && f.Type != models.FieldTypeString && f.Type != models.FieldTypeStringArray {
+			return nil, fmt.Errorf("field %q: enum is only allowed for string fields", f.Name)
+		}
+		if f.Format != "" && f.Type != models.FieldTypeString {
+			return nil, fmt.Errorf("field %q: format is only allowed for string fields", f.Name)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return nil, fmt.Errorf("field %q: min is greater than max", f.Name)
+		}
+		out[i] = f
+	}
+	return out, nil
+}
+
+func (s *achievementTypeSchemaService) Delete(ctx context.Context, achievementType string) error {
+	if _, err := s.GetByType(ctx, achievementType); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, achievementType)
+}
+
+// Validate memeriksa details dan customFields terhadap skema tipe prestasi
+// dan mengembalikan *models.SchemaValidationError berisi semua pelanggaran.
+func (s *achievementTypeSchemaService) Validate(ctx context.Context, achievement *models.Achievement) error {
+	invalid := func(errs ...models.FieldError) error {
+		return models.NewSchemaValidationError(errs)
+	}
+
+	if strings.TrimSpace(achievement.AchievementType) == "" {
+		return invalid(models.FieldError{Field: "achievementType", Message: "is required"})
+	}
+
+	schema, err := s.repo.FindByType(ctx, achievement.AchievementType)
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return invalid(models.FieldError{Field: "achievementType", Message: "is not a known achievement type"})
+	}
+
+	details, err := toJSONMap(achievement.Details)
+	if err != nil {
+		return err
+	}
+	// time.Time kosong tetap ikut di-marshal walau omitempty.
+	for k, v := range details {
+		if v == "0001-01-01T00:00:00Z" {
+			delete(details, k)
+		}
+	}
+
+	customFields, err := toJSONMap(achievement.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	var errs []models.FieldError
+	errs = append(errs, checkSection(models.SchemaSectionDetails, schema.Details, details, false, schema.AchievementType)...)
+	errs = append(errs, checkSection(models.SchemaSectionCustomFields, schema.CustomFields, customFields, schema.AllowUnknownCustomFields, schema.AchievementType)...)
+
+	if len(errs) > 0 {
+		return invalid(errs...)
+	}
+	return nil
+}
+
+// toJSONMap menyeragamkan nilai (termasuk tipe hasil decode BSON) menjadi
+// tipe JSON dasar.
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func checkSection(
+	section string,
+	specs []models.FieldSpec,
+	values map[string]interface{},
+	allowUnknown bool,
+	achievementType string,
+) []models.FieldError {
+
+	var errs []models.FieldError
+	declared := make(map[string]bool, len(specs))
+
+	for _, spec := range specs {
+		declared[spec.Name] = true
+		path := section + "." + spec.Name
+
+		v, ok := values[spec.Name]
+		if !ok || v == nil || v == "" {
+			if spec.Required {
+				errs = append(errs, models.FieldError{Field: path, Message: "is required"})
+			}
+			continue
+		}
+		if msg := checkValue(spec, v); msg != "" {
+			errs = append(errs, models.FieldError{Field: path, Message: msg})
+		}
+	}
+
+	if allowUnknown {
+		return errs
+	}
+
+	var unknown []string
+	for name := range values {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, models.FieldError{
+			Field:   section + "." + name,
+			Message: "is not allowed for achievement type " + achievementType,
+		})
+	}
+	return errs
+}
+
+var issnPattern = regexp.MustCompile(`^\d{4}-\d{3}[\dXx]$`)
+
+// checkValue mengembalikan pesan pelanggaran, atau string kosong jika valid.
+func checkValue(spec models.FieldSpec, v interface{}) string {
+	switch spec.Type {
+	case models.FieldTypeString:
+		s, ok := v.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if msg := checkRange(spec, float64(utf8.RuneCountInString(s)), "characters"); msg != "" {
+			return msg
+		}
+		if len(spec.Enum) > 0 && !containsString(spec.Enum, s) {
+			return "must be one of: " + strings.Join(spec.Enum, ", ")
+		}
+		return checkFormat(spec.Format, s)
+
+	case models.FieldTypeInteger, models.FieldTypeNumber:
+		n, ok := v.(float64)
+		if !ok {
+			return "must be a number"
+		}
+		if spec.Type == models.FieldTypeInteger && n != math.Trunc(n) {
+			return "must be an integer"
+		}
+		return checkRange(spec, n, "")
+
+	case models.FieldTypeBoolean:
+		if _, ok := v.(bool); !ok {
+			return "must be a boolean"
+		}
+
+	case models.FieldTypeDate:
+		s, ok := v.(string)
+		if !ok || !isDate(s) {
+			return "must be a date (YYYY-MM-DD or RFC 3339)"
+		}
+
+	case models.FieldTypeStringArray:
+		items, ok := v.([]interface{})
+		if !ok {
+			return "must be a list of strings"
+		}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok || strings.TrimSpace(s) == "" {
+				return "must be a list of non-empty strings"
+			}
+			if len(spec.Enum) > 0 && !containsString(spec.Enum, s) {
+				return "items must be one of: " + strings.Join(spec.Enum, ", ")
+			}
+		}
+		return checkRange(spec, float64(len(items)), "items")
+
+	case models.FieldTypePeriod:
+		p, ok := v.(map[string]interface{})
+		if !ok {
+			return "must be an object with start and end"
+		}
+		raw, _ := p["start"].(string)
+		start, okStart := parseDate(raw)
+		raw, _ = p["end"].(string)
+		end, okEnd := parseDate(raw)
+		if !okStart || !okEnd || start.IsZero() || end.IsZero() {
+			return "must have valid start and end dates"
+		}
+		if end.Before(start) {
+			return "end must not be before start"
+		}
+	}
+	return ""
+}
+
+func checkRange(spec models.FieldSpec, n float64, unit string) string {
+	suffix := ""
+	if unit != "" {
+		suffix = " " + unit
+	}
+	if spec.Min != nil && n < *spec.Min {
+		return fmt.Sprintf("must be at least %g%s", *spec.Min, suffix)
+	}
+	if spec.Max != nil && n > *spec.Max {
+		return fmt.Sprintf("must be at most %g%s", *spec.Max, suffix)
+	}
+	return ""
+}
+
+func checkFormat(format, s string) string {
+	switch format {
+	case "email":
+		if _, err := mail.ParseAddress(s); err != nil {
+			return "must be a valid email address"
+		}
+	case "url":
+		u, err := url.ParseRequestURI(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "must be a valid http(s) URL"
+		}
+	case "date":
+		if !isDate(s) {
+			return "must be a date (YYYY-MM-DD or RFC 3339)"
+		}
+	case "issn":
+		if !issnPattern.MatchString(s) {
+			return "must be an ISSN (NNNN-NNNC)"
+		}
+	}
+	return ""
+}
+
+func isDate(s string) bool {
+	_, ok := parseDate(s)
+	return ok
+}
+
+func parseDate(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+type ApprovalWorkflowService interface {
+	GetAll(ctx context.Context) ([]models.ApprovalWorkflow, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ApprovalWorkflow, error)
+	Create(ctx context.Context, req *models.ApprovalWorkflowRequest) (*models.ApprovalWorkflow, error)
+	Update(ctx context.Context, id uuid.UUID, req *models.ApprovalWorkflowRequest) (*models.ApprovalWorkflow, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type approvalWorkflowService struct {
+	repo     repository.ApprovalWorkflowRepository
+	roleRepo repository.RoleRepository
+}
+
+func NewApprovalWorkflowService(
+	repo repository.ApprovalWorkflowRepository,
+	roleRepo repository.RoleRepository,
+) ApprovalWorkflowService {
+	return &approvalWorkflowService{
+		repo:     repo,
+		roleRepo: roleRepo,
+	}
+}
+
+func (s *approvalWorkflowService) GetAll(ctx context.Context) ([]models.ApprovalWorkflow, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *approvalWorkflowService) GetByID(ctx context.Context, id uuid.UUID) (*models.ApprovalWorkflow, error) {
+	w, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, errors.New("approval workflow not found")
+	}
+	return w, nil
+}
+
+func (s *approvalWorkflowService) Create(ctx context.Context, req *models.ApprovalWorkflowRequest) (*models.ApprovalWorkflow, error) {
+	now := time.Now()
+	w := &models.ApprovalWorkflow{
+		ID:        uuid.New(),
+		IsActive:  true,
+		CreatedAt: now,
+	}
+	if err := s.apply(ctx, w, req); err != nil {
+		return nil, err
+	}
+	w.UpdatedAt = now
+
+	if err := s.repo.Save(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Update mengganti konfigurasi workflow. Nama, kecocokan dan status aktif
+// hanya memengaruhi pengajuan berikutnya; langkah penyetuju tidak boleh
+// diubah selama masih ada prestasi submitted yang berjalan di workflow ini.
+func (s *approvalWorkflowService) Update(ctx context.Context, id uuid.UUID, req *models.ApprovalWorkflowRequest) (*models.ApprovalWorkflow, error) {
+	w, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	oldSteps := w.Steps
+	if err := s.apply(ctx, w, req); err != nil {
+		return nil, err
+	}
+
+	if !sameApprovers(oldSteps, w.Steps) {
+		inProgress, err := s.repo.CountInProgress(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if inProgress > 0 {
+			return nil, repository.ErrApprovalWorkflowInUse
+		}
+	}
+	w.UpdatedAt = time.Now()
+
+	if err := s.repo.Save(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (s *approvalWorkflowService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// apply memvalidasi request lalu menyalinnya ke w; role penyetuju harus
+// ada di tabel roles.
+func (s *approvalWorkflowService) apply(ctx context.Context, w *models.ApprovalWorkflow, req *models.ApprovalWorkflowRequest) error {
+	steps := make([]models.ApprovalWorkflowStep, 0, len(req.Steps))
+	for i, st := range req.Steps {
+		role := trimmedOrNil(st.ApproverRole)
+		scope := trimmedOrNil(st.ApproverScope)
+		if role == nil && scope == nil {
+			return errors.New("each step needs an approver role or scope")
+		}
+
+		if role != nil {
+			existing, err := s.roleRepo.FindByName(ctx, *role)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return errors.New("approver role not found")
+			}
+		}
+
+		steps = append(steps, models.ApprovalWorkflowStep{
+			StepOrder:     i + 1,
+			Name:          strings.TrimSpace(st.Name),
+			ApproverRole:  role,
+			ApproverScope: scope,
+		})
+	}
+
+	w.Name = strings.TrimSpace(req.Name)
+	w.AchievementType = trimmedOrNil(req.AchievementType)
+	w.CompetitionLevel = trimmedOrNil(req.CompetitionLevel)
+	if req.IsActive != nil {
+		w.IsActive = *req.IsActive
+	}
+	w.Steps = steps
+	return nil
+}
+
+// sameApprovers membandingkan urutan dan penyetuju langkah; nama langkah
+// tidak memengaruhi siapa yang menyetujui.
+func sameApprovers(a, b []models.ApprovalWorkflowStep) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].StepOrder != b[i].StepOrder ||
+			!equalStringPtr(a[i].ApproverRole, b[i].ApproverRole) ||
+			!equalStringPtr(a[i].ApproverScope, b[i].ApproverScope) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"backend/app/mailer"
 	"backend/app/models"
+	"backend/app/oidc"
 	"backend/app/repository"
 	"backend/app/utils"
 
@@ -15,82 +21,349 @@ import (
 )
 
 type AuthService interface {
-	Login(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*models.RefreshTokenResponse, error)
+	// Login mengembalikan LoginResponse, atau MFAChallengeResponse jika user
+	// masih harus menyelesaikan langkah MFA lewat VerifyMFA.
+	Login(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, *models.MFAChallengeResponse, error)
+	VerifyMFA(ctx context.Context, req models.MFAVerifyRequest) (*models.LoginResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string, ipAddress string, userAgent string) (*models.RefreshTokenResponse, error)
 	Logout(ctx context.Context, req models.LogoutRequest) error
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserData, error)
+	ForgotPassword(ctx context.Context, req models.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error
+
+	// Register membuat akun mahasiswa nonaktif untuk NIM di allow-list dan
+	// mengirim link verifikasi email. Akun aktif setelah disetujui admin.
+	Register(ctx context.Context, req models.RegisterRequest) (*models.RegisterResponse, error)
+	VerifyEmail(ctx context.Context, req models.VerifyEmailRequest) error
+	// ResendVerification mengirim ulang link verifikasi untuk registrasi
+	// yang belum terverifikasi; email yang tidak dikenal tidak dianggap error.
+	ResendVerification(ctx context.Context, req models.ResendVerificationRequest) error
+
+	ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID *uuid.UUID) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, actorID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error
+
+	// Impersonate menerbitkan access token berumur pendek untuk targetID
+	// atas nama admin adminID, tanpa refresh token.
+	Impersonate(ctx context.Context, adminID uuid.UUID, targetID uuid.UUID, req models.ImpersonateRequest) (*models.ImpersonationResponse, error)
+
+	// OIDCAuthorize memulai login SSO dan mengembalikan URL authorize IdP.
+	// OIDCLogin menyelesaikannya dari code dan state callback, dengan hasil
+	// yang sama seperti Login.
+	OIDCAuthorize(ctx context.Context) (*models.OIDCAuthorizeResponse, error)
+	OIDCLogin(ctx context.Context, req models.OIDCCallbackRequest) (*models.LoginResponse, *models.MFAChallengeResponse, error)
+}
+
+// AuthConfig berisi pengaturan AuthService yang dibaca dari environment
+// saat startup.
+type AuthConfig struct {
+	// PasswordResetURL adalah halaman front end yang menerima ?token=...
+	PasswordResetURL string
+	PasswordResetTTL time.Duration
+
+	// Akun dikunci selama LockoutDuration setelah MaxFailedLogins kali gagal.
+	MaxFailedLogins int
+	LockoutDuration time.Duration
+
+	// Mulai gagal ke-LoginDelayAfter, percobaan berikutnya harus menunggu
+	// LoginDelayBase yang berlipat dua setiap kegagalan, maksimal LoginDelayMax.
+	LoginDelayAfter int
+	LoginDelayBase  time.Duration
+	LoginDelayMax   time.Duration
+
+	// Batas gagal login dari satu IP dalam IPThrottleWindow (semua username).
+	MaxFailedLoginsPerIP int
+	IPThrottleWindow     time.Duration
+
+	// Role yang wajib memakai MFA; user dengan role ini yang belum
+	// mendaftarkan authenticator diarahkan ke enrollment saat login.
+	MFARequiredRoles []string
+
+	// Masa berlaku token impersonasi admin.
+	ImpersonationTTL time.Duration
+
+	// Registrasi mandiri mahasiswa hanya aktif jika SelfRegistrationEnabled.
+	// EmailVerificationURL adalah halaman front end yang menerima ?token=...
+	SelfRegistrationEnabled bool
+	EmailVerificationURL    string
+	EmailVerificationTTL    time.Duration
+
+	// Login SSO: batas waktu dari authorize sampai callback, pemetaan claim
+	// ID token, dan apakah mahasiswa tanpa akun dibuatkan akun otomatis.
+	OIDCStateTTL     time.Duration
+	OIDCClaims       OIDCClaimMapping
+	OIDCProvisioning bool
+}
+
+// OIDCClaimMapping berisi nama claim ID token untuk setiap data user.
+// StudentClaim/StudentClaimValue (opsional) membatasi provisioning ke
+// identitas yang claim-nya memuat nilai tersebut, misalnya
+// affiliation=student.
+type OIDCClaimMapping struct {
+	Email        string
+	FullName     string
+	StudentID    string
+	ProgramStudy string
+	AcademicYear string
+
+	StudentClaim      string
+	StudentClaimValue string
+}
+
+const mfaChallengeTTL = 5 * time.Minute
+
+// LoginThrottleError dikembalikan Login saat percobaan ditolak sebelum
+// password diperiksa, baik karena akun terkunci maupun karena terlalu
+// banyak percobaan gagal.
+type LoginThrottleError struct {
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottleError) Error() string {
+	if e.Locked {
+		return "account temporarily locked"
+	}
+	return "too many login attempts"
 }
 
 type authService struct {
-	authrepo repository.AuthRepository
+	authrepo       repository.AuthRepository
+	securityEvents repository.SecurityEventRepository
+	mfaRepo        repository.MFARepository
+	registration   repository.RegistrationRepository
+	identities     repository.IdentityRepository
+	passwords      PasswordPolicyService
+	mailer         mailer.Mailer
+	authz          AuthzService
+	sso            oidc.Provider
+	cfg            AuthConfig
 }
 
-func NewAuthService(repo repository.AuthRepository) AuthService {
-	return &authService{authrepo: repo}
+func NewAuthService(
+	repo repository.AuthRepository,
+	securityEvents repository.SecurityEventRepository,
+	mfaRepo repository.MFARepository,
+	registration repository.RegistrationRepository,
+	identities repository.IdentityRepository,
+	passwords PasswordPolicyService,
+	mail mailer.Mailer,
+	authz AuthzService,
+	sso oidc.Provider,
+	cfg AuthConfig,
+) AuthService {
+	if cfg.PasswordResetTTL <= 0 {
+		cfg.PasswordResetTTL = 30 * time.Minute
+	}
+	if cfg.MaxFailedLogins <= 0 {
+		cfg.MaxFailedLogins = 5
+	}
+	if cfg.LockoutDuration <= 0 {
+		cfg.LockoutDuration = 15 * time.Minute
+	}
+	if cfg.LoginDelayAfter <= 0 {
+		cfg.LoginDelayAfter = 3
+	}
+	if cfg.LoginDelayBase <= 0 {
+		cfg.LoginDelayBase = time.Second
+	}
+	if cfg.LoginDelayMax <= 0 {
+		cfg.LoginDelayMax = 30 * time.Second
+	}
+	if cfg.MaxFailedLoginsPerIP <= 0 {
+		cfg.MaxFailedLoginsPerIP = 20
+	}
+	if cfg.IPThrottleWindow <= 0 {
+		cfg.IPThrottleWindow = 15 * time.Minute
+	}
+	if cfg.ImpersonationTTL <= 0 {
+		cfg.ImpersonationTTL = 15 * time.Minute
+	}
+	if cfg.EmailVerificationTTL <= 0 {
+		cfg.EmailVerificationTTL = 24 * time.Hour
+	}
+	if cfg.OIDCStateTTL <= 0 {
+		cfg.OIDCStateTTL = 10 * time.Minute
+	}
+	return &authService{
+		authrepo:       repo,
+		securityEvents: securityEvents,
+		mfaRepo:        mfaRepo,
+		registration:   registration,
+		identities:     identities,
+		passwords:      passwords,
+		mailer:         mail,
+		authz:          authz,
+		sso:            sso,
+		cfg:            cfg,
+	}
 }
 
 func (s *authService) Login(
 	ctx context.Context,
 	req models.LoginRequest,
-) (*models.LoginResponse, error) {
+) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
+
+	now := time.Now()
+
+	if req.IPAddress != "" {
+		failed, err := s.authrepo.CountFailedLoginsByIP(ctx, req.IPAddress, now.Add(-s.cfg.IPThrottleWindow))
+		if err != nil {
+			return nil, nil, err
+		}
+		if failed >= s.cfg.MaxFailedLoginsPerIP {
+			return nil, nil, &LoginThrottleError{RetryAfter: s.cfg.IPThrottleWindow}
+		}
+	}
 
 	user, err := s.authrepo.FindByUsername(ctx, req.Username)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		s.recordLoginAttempt(ctx, req, false)
+		return nil, nil, errors.New("invalid credentials")
+	}
+
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return nil, nil, &LoginThrottleError{Locked: true, RetryAfter: user.LockedUntil.Sub(now)}
+	}
+
+	if wait := s.loginDelay(user, now); wait > 0 {
+		return nil, nil, &LoginThrottleError{RetryAfter: wait}
 	}
 
 	if err := bcrypt.CompareHashAndPassword(
 		[]byte(user.PasswordHash),
 		[]byte(req.Password),
 	); err != nil {
-		return nil, errors.New("invalid credentials")
+		s.recordLoginAttempt(ctx, req, false)
+		if err := s.registerFailedLogin(ctx, user, req.IPAddress, models.SecurityEventLoginFailed); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("invalid credentials")
 	}
 
-	permissions, err := s.authrepo.GetPermissionsByRoleID(ctx, user.RoleID)
+	s.recordLoginAttempt(ctx, req, true)
+
+	// Password lama yang di-hash dengan cost lebih rendah di-hash ulang
+	// selagi plaintext-nya tersedia.
+	if utils.PasswordNeedsRehash(user.PasswordHash) {
+		if hashed, err := utils.HashPassword(req.Password); err != nil {
+			log.Println("❌ failed to rehash password:", err)
+		} else if err := s.authrepo.RehashPassword(ctx, user.ID, user.PasswordHash, hashed); err != nil {
+			log.Println("❌ failed to store rehashed password:", err)
+		}
+	}
+
+	return s.startSession(ctx, user, req.IPAddress, req.UserAgent)
+}
+
+// startSession dipanggil setelah identitas user terbukti (password atau
+// SSO): meminta langkah MFA jika perlu, atau langsung menerbitkan token.
+func (s *authService) startSession(
+	ctx context.Context,
+	user *models.User,
+	ipAddress string,
+	userAgent string,
+) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
+
+	mfa, err := s.mfaRepo.FindByUserID(ctx, user.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	claims := &models.JWTClaims{
-		UserID:      user.ID,
-		Username:    user.Username,
-		Role:        user.RoleName,
-		Permissions: permissions,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(12 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "sistem-prestasi-mahasiswa",
-		},
+	// Counter gagal login baru di-reset setelah langkah MFA selesai, supaya
+	// tebakan kode MFA tetap terkena lockout.
+	if mfa != nil && mfa.Enabled {
+		challenge, err := newMFAChallenge(user, models.TokenPurposeMFA)
+		return nil, challenge, err
+	}
+	if mfaRequiredForRole(s.cfg.MFARequiredRoles, user.RoleName) {
+		challenge, err := newMFAChallenge(user, models.TokenPurposeMFAEnroll)
+		return nil, challenge, err
 	}
 
-	refreshTokenClaims := &models.JWTClaims{
-		UserID: user.ID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "sistem-prestasi-mahasiswa",
-		},
+	resp, err := s.completeLogin(ctx, user, ipAddress, userAgent)
+	return resp, nil, err
+}
+
+// VerifyMFA menyelesaikan login dua langkah memakai token tantangan dari
+// Login dan kode TOTP atau kode pemulihan.
+func (s *authService) VerifyMFA(
+	ctx context.Context,
+	req models.MFAVerifyRequest,
+) (*models.LoginResponse, error) {
+
+	claims, err := utils.VerifyAccessToken(req.MFAToken)
+	if err != nil || (claims.Purpose != models.TokenPurposeMFA && claims.Purpose != models.TokenPurposeMFAEnroll) {
+		return nil, errors.New("invalid or expired mfa token")
+	}
+
+	user, err := s.authrepo.FindByUsername(ctx, claims.Username)
+	if err != nil || user.ID != claims.UserID {
+		return nil, errors.New("invalid or expired mfa token")
+	}
+
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return nil, &LoginThrottleError{Locked: true, RetryAfter: user.LockedUntil.Sub(now)}
 	}
 
-	refreshToken, err := utils.GenerateTokenWithClaims(refreshTokenClaims)
+	mfa, err := s.mfaRepo.FindByUserID(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	if mfa == nil || !mfa.Enabled {
+		return nil, errors.New("mfa is not enabled")
+	}
 
-	switch user.RoleName {
-	case "Mahasiswa":
-		studentID, err := s.authrepo.GetStudentIDByUserID(ctx, user.ID)
-		if err != nil {
-			return nil, errors.New("student profile not found")
+	ok, usedRecoveryCode, err := verifyMFACode(ctx, s.mfaRepo, mfa, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.registerFailedLogin(ctx, user, req.IPAddress, models.SecurityEventMFAFailed); err != nil {
+			return nil, err
 		}
-		claims.StudentID = &studentID
+		return nil, errors.New("invalid mfa code")
+	}
 
-	case "DosenWali":
-		lecturerID, err := s.authrepo.GetLecturerIDByUserID(ctx, user.ID)
-		if err != nil {
-			return nil, errors.New("lecturer profile not found")
+	if usedRecoveryCode {
+		if err := s.securityEvents.Create(ctx, models.SecurityEvent{
+			ID:        uuid.New(),
+			UserID:    &user.ID,
+			EventType: models.SecurityEventRecoveryCodeUsed,
+			Detail:    "login completed with a recovery code",
+			IPAddress: req.IPAddress,
+			CreatedAt: now,
+		}); err != nil {
+			return nil, err
 		}
-		claims.LecturerID = &lecturerID
+	}
+
+	return s.completeLogin(ctx, user, req.IPAddress, req.UserAgent)
+}
+
+// completeLogin me-reset counter gagal login lalu menerbitkan access token
+// dan refresh token (family baru).
+func (s *authService) completeLogin(
+	ctx context.Context,
+	user *models.User,
+	ipAddress string,
+	userAgent string,
+) (*models.LoginResponse, error) {
+
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		if err := s.authrepo.ResetFailedLogins(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Login selalu membuka family (sesi) baru; rotasi berikutnya tetap di
+	// family ini.
+	familyID := uuid.New()
+
+	claims, err := s.newAccessClaims(ctx, user, familyID)
+	if err != nil {
+		return nil, err
 	}
 
 	accessToken, err := utils.GenerateTokenWithClaims(claims)
@@ -98,13 +371,12 @@ func (s *authService) Login(
 		return nil, err
 	}
 
-	refToken := models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
-		CreatedAt: time.Now(),
+	refToken, err := newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, err
 	}
+	refToken.IPAddress = ipAddress
+	refToken.UserAgent = userAgent
 
 	if err := s.authrepo.StoreRefreshToken(ctx, refToken); err != nil {
 		return nil, err
@@ -118,13 +390,13 @@ func (s *authService) Login(
 			User         models.UserData `json:"user"`
 		}{
 			Token:        accessToken,
-			RefreshToken: refreshToken,
+			RefreshToken: refToken.Token,
 			User: models.UserData{
 				ID:          user.ID,
 				Username:    user.Username,
 				FullName:    user.FullName,
-				Role:        user.RoleName,
-				Permissions: permissions,
+				Role:        claims.Role,
+				Permissions: claims.Permissions,
 			},
 		},
 	}, nil
@@ -133,6 +405,8 @@ func (s *authService) Login(
 func (s *authService) RefreshToken(
 	ctx context.Context,
 	refreshToken string,
+	ipAddress string,
+	userAgent string,
 ) (*models.RefreshTokenResponse, error) {
 
 	stored, err := s.authrepo.GetRefreshToken(ctx, refreshToken)
@@ -140,6 +414,12 @@ func (s *authService) RefreshToken(
 		return nil, errors.New("invalid refresh token")
 	}
 
+	// Token yang sudah dirotasi dipakai lagi: anggap family bocor dan
+	// cabut semua token turunannya.
+	if stored.RevokedAt != nil {
+		return nil, s.handleRefreshTokenReuse(ctx, stored)
+	}
+
 	if time.Now().After(stored.ExpiresAt) {
 		return nil, errors.New("refresh token expired")
 	}
@@ -149,16 +429,66 @@ func (s *authService) RefreshToken(
 		return nil, err
 	}
 
-	permissions, err := s.authrepo.GetPermissionsByRoleID(ctx, user.RoleID)
+	claims, err := s.newAccessClaims(ctx, user, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	newAccessToken, err := utils.GenerateTokenWithClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	newRefToken, err := newRefreshToken(user.ID, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	newRefToken.IPAddress = ipAddress
+	newRefToken.UserAgent = userAgent
+
+	if err := s.authrepo.RotateRefreshToken(ctx, stored.ID, newRefToken); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenRevoked) {
+			return nil, s.handleRefreshTokenReuse(ctx, stored)
+		}
+		return nil, err
+	}
+
+	return &models.RefreshTokenResponse{
+		Status: "success",
+		Data: struct {
+			Token        string `json:"token"`
+			RefreshToken string `json:"refreshToken"`
+		}{
+			Token:        newAccessToken,
+			RefreshToken: newRefToken.Token,
+		},
+	}, nil
+}
+
+// newAccessClaims membangun claims access token dari keadaan otorisasi
+// terkini user, termasuk versi user/role yang dicek JWTMiddleware.
+func (s *authService) newAccessClaims(
+	ctx context.Context,
+	user *models.User,
+	sessionID uuid.UUID,
+) (*models.JWTClaims, error) {
+
+	state, err := s.authz.Current(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	if state == nil || !state.IsActive {
+		return nil, errors.New("user is inactive")
+	}
 
 	claims := &models.JWTClaims{
 		UserID:      user.ID,
 		Username:    user.Username,
-		Role:        user.RoleName,
-		Permissions: permissions,
+		Role:        state.RoleName,
+		Permissions: state.Permissions,
+		SessionID:   &sessionID,
+		UserVersion: state.UserVersion,
+		RoleVersion: state.RoleVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(12 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -166,7 +496,7 @@ func (s *authService) RefreshToken(
 		},
 	}
 
-	switch user.RoleName {
+	switch state.RoleName {
 	case "Mahasiswa":
 		studentID, err := s.authrepo.GetStudentIDByUserID(ctx, user.ID)
 		if err != nil {
@@ -182,28 +512,696 @@ func (s *authService) RefreshToken(
 		claims.LecturerID = &lecturerID
 	}
 
-	newAccessToken, err := utils.GenerateTokenWithClaims(claims)
-	if err != nil {
-		return nil, err
+	return claims, nil
+}
+
+func (s *authService) handleRefreshTokenReuse(
+	ctx context.Context,
+	stored *models.RefreshToken,
+) error {
+
+	if err := s.authrepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+		return err
 	}
 
-	return &models.RefreshTokenResponse{
-		Status: "success",
-		Data: struct {
-			Token        string `json:"token"`
-			RefreshToken string `json:"refreshToken"`
-		}{
-			Token:        newAccessToken,
-			RefreshToken: refreshToken,
-		},
-	}, nil
+	userID := stored.UserID
+	event := models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &userID,
+		EventType: models.SecurityEventRefreshTokenReuse,
+		Detail:    "revoked refresh token reused, family " + stored.FamilyID.String() + " revoked",
+		CreatedAt: time.Now(),
+	}
+	if err := s.securityEvents.Create(ctx, event); err != nil {
+		return err
+	}
+
+	return errors.New("refresh token reuse detected")
 }
 
 func (s *authService) Logout(
 	ctx context.Context,
 	req models.LogoutRequest,
 ) error {
-	return s.authrepo.DeleteRefreshToken(ctx, req.RefreshToken)
+
+	stored, err := s.authrepo.GetRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return errors.New("invalid refresh token")
+	}
+
+	return s.authrepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+}
+
+// ListSessions mengembalikan sesi aktif user. currentSessionID (claim sid
+// dari access token pemanggil) dipakai untuk menandai sesi yang sedang dipakai.
+func (s *authService) ListSessions(
+	ctx context.Context,
+	userID uuid.UUID,
+	currentSessionID *uuid.UUID,
+) ([]models.Session, error) {
+
+	sessions, err := s.authrepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if currentSessionID != nil {
+		for i := range sessions {
+			sessions[i].Current = sessions[i].ID == *currentSessionID
+		}
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession mencabut satu sesi milik userID. actorID adalah user yang
+// melakukan pencabutan (user itu sendiri atau admin). Access token sesi itu
+// langsung ditolak; sesi lain milik user cukup refresh sekali.
+func (s *authService) RevokeSession(
+	ctx context.Context,
+	userID uuid.UUID,
+	sessionID uuid.UUID,
+	actorID uuid.UUID,
+) error {
+
+	revoked, err := s.authrepo.RevokeUserSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("session not found")
+	}
+	s.authz.InvalidateUser(userID)
+
+	return s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &userID,
+		EventType: models.SecurityEventSessionRevoked,
+		Detail:    "session " + sessionID.String() + " revoked by " + actorID.String(),
+		CreatedAt: time.Now(),
+	})
+}
+
+// RevokeAllSessions mencabut semua sesi userID; access token yang sudah
+// terbit ikut ditolak.
+func (s *authService) RevokeAllSessions(
+	ctx context.Context,
+	userID uuid.UUID,
+	actorID uuid.UUID,
+) error {
+
+	if err := s.authrepo.RevokeAllRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+	s.authz.InvalidateUser(userID)
+
+	return s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &userID,
+		EventType: models.SecurityEventSessionsRevoked,
+		Detail:    "all sessions revoked by " + actorID.String(),
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *authService) Impersonate(
+	ctx context.Context,
+	adminID uuid.UUID,
+	targetID uuid.UUID,
+	req models.ImpersonateRequest,
+) (*models.ImpersonationResponse, error) {
+
+	admin, err := s.authrepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	target, err := s.authrepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Impersonasi sesama admin sama saja dengan berbagi hak admin tanpa
+	// jejak login; tidak diizinkan.
+	if target.RoleName == models.RoleAdmin {
+		return nil, errors.New("cannot impersonate an admin")
+	}
+
+	// Token impersonasi tidak terikat family refresh token mana pun.
+	claims, err := s.newAccessClaims(ctx, target, uuid.New())
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.cfg.ImpersonationTTL)
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	claims.Actor = &models.TokenActor{
+		UserID:   admin.ID,
+		Username: admin.Username,
+	}
+
+	token, err := utils.GenerateTokenWithClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := "impersonated by " + admin.Username + " (" + admin.ID.String() + "): " + req.Reason
+	for _, id := range []uuid.UUID{target.ID, admin.ID} {
+		userID := id
+		if err := s.securityEvents.Create(ctx, models.SecurityEvent{
+			ID:        uuid.New(),
+			UserID:    &userID,
+			EventType: models.SecurityEventImpersonation,
+			Detail:    detail + " [target " + target.Username + "]",
+			IPAddress: req.IPAddress,
+			CreatedAt: time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &models.ImpersonationResponse{Status: "success"}
+	resp.Data.Token = token
+	resp.Data.ExpiresAt = expiresAt
+	resp.Data.User = models.UserData{
+		ID:          target.ID,
+		Username:    target.Username,
+		FullName:    target.FullName,
+		Role:        claims.Role,
+		Permissions: claims.Permissions,
+	}
+	resp.Data.Impersonator = *claims.Actor
+	return resp, nil
+}
+
+// ForgotPassword mengirim link reset password jika email terdaftar. Untuk
+// mencegah enumerasi akun, email yang tidak dikenal tidak dianggap error.
+func (s *authService) ForgotPassword(
+	ctx context.Context,
+	req models.ForgotPasswordRequest,
+) error {
+
+	user, err := s.authrepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		return nil
+	}
+
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	resetToken := models.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: now.Add(s.cfg.PasswordResetTTL),
+		CreatedAt: now,
+	}
+
+	if err := s.authrepo.CreatePasswordResetToken(ctx, resetToken); err != nil {
+		return err
+	}
+
+	link := rawToken
+	if s.cfg.PasswordResetURL != "" {
+		link = s.cfg.PasswordResetURL + "?token=" + rawToken
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset password Sistem Pelaporan Prestasi",
+		Body: "Halo " + user.FullName + ",\n\n" +
+			"Kami menerima permintaan reset password untuk akun " + user.Username + ".\n" +
+			"Gunakan link berikut dalam " + s.cfg.PasswordResetTTL.String() + ":\n\n" +
+			link + "\n\n" +
+			"Abaikan email ini jika Anda tidak meminta reset password.",
+	}
+
+	// Kegagalan kirim tidak dikembalikan ke client agar respons tetap sama
+	// untuk email terdaftar maupun tidak.
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Println("❌ failed to send password reset email:", err)
+	}
+
+	return nil
+}
+
+func (s *authService) ResetPassword(
+	ctx context.Context,
+	req models.ResetPasswordRequest,
+) error {
+
+	stored, err := s.authrepo.GetPasswordResetToken(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return errors.New("invalid or expired reset token")
+	}
+
+	if stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return errors.New("invalid or expired reset token")
+	}
+
+	if err := s.passwords.Check(ctx, stored.UserID, req.NewPassword); err != nil {
+		return err
+	}
+
+	hashed, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authrepo.ResetPassword(ctx, stored.ID, stored.UserID, hashed); err != nil {
+		if errors.Is(err, repository.ErrPasswordResetTokenUsed) {
+			return errors.New("invalid or expired reset token")
+		}
+		return err
+	}
+
+	userID := stored.UserID
+	return s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &userID,
+		EventType: models.SecurityEventPasswordReset,
+		Detail:    "password reset via email token, all refresh tokens revoked",
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *authService) Register(
+	ctx context.Context,
+	req models.RegisterRequest,
+) (*models.RegisterResponse, error) {
+
+	if !s.cfg.SelfRegistrationEnabled {
+		return nil, errors.New("self-registration is disabled")
+	}
+
+	studentID := strings.TrimSpace(req.StudentID)
+	entry, err := s.registration.FindAllowlistEntry(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// NIM tidak terdaftar dan program studi yang tidak cocok sengaja diberi
+	// pesan yang sama agar allow-list tidak bisa ditebak.
+	if entry == nil ||
+		!strings.EqualFold(strings.TrimSpace(req.ProgramStudy), entry.ProgramStudy) ||
+		(entry.AcademicYear != "" && req.AcademicYear != "" && req.AcademicYear != entry.AcademicYear) {
+		return nil, errors.New("student id is not eligible for registration")
+	}
+	if entry.RegisteredUserID != nil {
+		return nil, errors.New("student id already registered")
+	}
+
+	switch taken, err := s.registration.IsUsernameOrEmailTaken(ctx, req.Username, req.Email); {
+	case err != nil:
+		return nil, err
+	case taken == "username":
+		return nil, errors.New("username already taken")
+	case taken == "email":
+		return nil, errors.New("email already registered")
+	}
+
+	if err := s.passwords.Check(ctx, uuid.Nil, req.Password, req.Username, req.Email, studentID); err != nil {
+		return nil, err
+	}
+
+	hashed, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &models.User{
+		ID:           uuid.New(),
+		Username:     req.Username,
+		Email:        req.Email,
+		PasswordHash: hashed,
+		FullName:     req.FullName,
+		CreatedAt:    now,
+	}
+
+	academicYear := entry.AcademicYear
+	if academicYear == "" {
+		academicYear = req.AcademicYear
+	}
+	student := &models.Student{
+		ID:           uuid.New(),
+		UserID:       user.ID,
+		StudentID:    entry.StudentID,
+		ProgramStudy: entry.ProgramStudy,
+		AcademicYear: academicYear,
+		CreatedAt:    now,
+	}
+
+	token := models.EmailVerificationToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: now.Add(s.cfg.EmailVerificationTTL),
+		CreatedAt: now,
+	}
+
+	if err := s.registration.CreateRegistration(ctx, user, student, token); err != nil {
+		if errors.Is(err, repository.ErrAllowlistEntryTaken) {
+			return nil, errors.New("student id already registered")
+		}
+		return nil, err
+	}
+
+	s.sendVerificationEmail(ctx, user.Email, user.FullName, student.StudentID, rawToken)
+
+	resp := &models.RegisterResponse{Status: "success"}
+	resp.Data.Username = user.Username
+	resp.Data.Email = user.Email
+	return resp, nil
+}
+
+func (s *authService) sendVerificationEmail(ctx context.Context, email, fullName, studentID, rawToken string) {
+	link := rawToken
+	if s.cfg.EmailVerificationURL != "" {
+		link = s.cfg.EmailVerificationURL + "?token=" + rawToken
+	}
+
+	msg := mailer.Message{
+		To:      email,
+		Subject: "Verifikasi email Sistem Pelaporan Prestasi",
+		Body: "Halo " + fullName + ",\n\n" +
+			"Terima kasih telah mendaftar dengan NIM " + studentID + ".\n" +
+			"Verifikasi email Anda dalam " + s.cfg.EmailVerificationTTL.String() + " melalui link berikut:\n\n" +
+			link + "\n\n" +
+			"Akun dapat digunakan setelah email terverifikasi dan disetujui admin.",
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Println("❌ failed to send verification email:", err)
+	}
+}
+
+// emailVerificationResendInterval membatasi pengiriman ulang link
+// verifikasi ke satu email.
+const emailVerificationResendInterval = time.Minute
+
+// ResendVerification menerbitkan token verifikasi baru (token lama yang
+// belum dipakai tidak berlaku lagi) untuk registrasi pending yang emailnya
+// belum terverifikasi, sehingga klaim NIM tidak tertahan setelah token
+// kedaluwarsa.
+func (s *authService) ResendVerification(
+	ctx context.Context,
+	req models.ResendVerificationRequest,
+) error {
+
+	if !s.cfg.SelfRegistrationEnabled {
+		return errors.New("self-registration is disabled")
+	}
+
+	pending, err := s.registration.FindPendingByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if pending == nil || pending.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	rawToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	token := models.EmailVerificationToken{
+		ID:        uuid.New(),
+		UserID:    pending.UserID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: now.Add(s.cfg.EmailVerificationTTL),
+		CreatedAt: now,
+	}
+	if err := s.registration.ReplaceEmailVerificationToken(ctx, token, emailVerificationResendInterval); err != nil {
+		if errors.Is(err, repository.ErrEmailVerificationThrottled) {
+			return nil
+		}
+		return err
+	}
+
+	s.sendVerificationEmail(ctx, pending.Email, pending.FullName, pending.StudentID, rawToken)
+	return nil
+}
+
+func (s *authService) VerifyEmail(
+	ctx context.Context,
+	req models.VerifyEmailRequest,
+) error {
+
+	stored, err := s.registration.GetEmailVerificationToken(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return errors.New("invalid or expired verification token")
+	}
+
+	if stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return errors.New("invalid or expired verification token")
+	}
+
+	if err := s.registration.VerifyEmail(ctx, stored.ID, stored.UserID); err != nil {
+		if errors.Is(err, repository.ErrEmailVerificationTokenUsed) {
+			return errors.New("invalid or expired verification token")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *authService) OIDCAuthorize(ctx context.Context) (*models.OIDCAuthorizeResponse, error) {
+	if s.sso == nil {
+		return nil, errors.New("sso login is disabled")
+	}
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := utils.GenerateRandomToken(48)
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := s.sso.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		log.Println("❌ oidc authorize failed:", err)
+		return nil, errors.New("sso provider unavailable")
+	}
+
+	now := time.Now()
+	if err := s.identities.CreateLoginState(ctx, models.OIDCLoginState{
+		ID:           uuid.New(),
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(s.cfg.OIDCStateTTL),
+		CreatedAt:    now,
+	}); err != nil {
+		return nil, err
+	}
+
+	resp := &models.OIDCAuthorizeResponse{Status: "success", StateExpiresAt: now.Add(s.cfg.OIDCStateTTL)}
+	resp.Data.AuthorizationURL = authURL
+	resp.Data.State = state
+	return resp, nil
+}
+
+func (s *authService) OIDCLogin(
+	ctx context.Context,
+	req models.OIDCCallbackRequest,
+) (*models.LoginResponse, *models.MFAChallengeResponse, error) {
+
+	if s.sso == nil {
+		return nil, nil, errors.New("sso login is disabled")
+	}
+
+	// State yang tidak berasal dari browser ini (login CSRF) ditolak sebelum
+	// dipakai.
+	if req.BrowserState == "" || subtle.ConstantTimeCompare([]byte(req.BrowserState), []byte(req.State)) != 1 {
+		return nil, nil, errors.New("invalid or expired sso state")
+	}
+
+	state, err := s.identities.ConsumeLoginState(ctx, utils.HashToken(req.State))
+	if err != nil {
+		return nil, nil, err
+	}
+	if state == nil {
+		return nil, nil, errors.New("invalid or expired sso state")
+	}
+
+	claims, err := s.sso.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		log.Println("❌ oidc code exchange failed:", err)
+		return nil, nil, errors.New("sso authentication failed")
+	}
+
+	user, err := s.resolveSSOUser(ctx, claims, req.IPAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.identities.TouchIdentity(ctx, s.sso.Issuer(), claims.String("sub")); err != nil {
+		return nil, nil, err
+	}
+
+	return s.startSession(ctx, user, req.IPAddress, req.UserAgent)
+}
+
+// resolveSSOUser mencari user untuk identitas SSO: lewat tautan yang sudah
+// ada, lalu NIM, lalu email yang sudah diverifikasi IdP. Jika tidak ada dan
+// provisioning aktif, akun mahasiswa dibuat dari claim.
+func (s *authService) resolveSSOUser(ctx context.Context, claims oidc.Claims, ipAddress string) (*models.User, error) {
+	issuer := s.sso.Issuer()
+	subject := claims.String("sub")
+	mapping := s.cfg.OIDCClaims
+
+	username, err := s.identities.FindUsernameByIdentity(ctx, issuer, subject)
+	if err != nil {
+		return nil, err
+	}
+	if username != "" {
+		return s.findActiveSSOUser(ctx, username)
+	}
+
+	studentID := claims.String(mapping.StudentID)
+	email := claims.String(mapping.Email)
+
+	if studentID != "" {
+		if username, err = s.identities.FindUsernameByStudentID(ctx, studentID); err != nil {
+			return nil, err
+		}
+	}
+	// Email yang belum diverifikasi IdP tidak dipakai untuk menautkan akun.
+	if username == "" && email != "" && claims.Bool("email_verified") {
+		if username, err = s.identities.FindUsernameByEmail(ctx, email); err != nil {
+			return nil, err
+		}
+	}
+
+	if username != "" {
+		user, err := s.findActiveSSOUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.identities.LinkIdentity(ctx, models.UserIdentity{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Issuer:    issuer,
+			Subject:   subject,
+			Email:     email,
+			CreatedAt: time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+		if err := s.securityEvents.Create(ctx, models.SecurityEvent{
+			ID:        uuid.New(),
+			UserID:    &user.ID,
+			EventType: models.SecurityEventSSOLinked,
+			Detail:    "sso identity " + subject + " from " + issuer + " linked",
+			IPAddress: ipAddress,
+			CreatedAt: time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	return s.provisionSSOStudent(ctx, claims, ipAddress)
+}
+
+func (s *authService) provisionSSOStudent(ctx context.Context, claims oidc.Claims, ipAddress string) (*models.User, error) {
+	mapping := s.cfg.OIDCClaims
+
+	if !s.cfg.OIDCProvisioning ||
+		(mapping.StudentClaim != "" && !claims.Contains(mapping.StudentClaim, mapping.StudentClaimValue)) {
+		return nil, errors.New("no account is linked to this sso identity")
+	}
+
+	studentID := claims.String(mapping.StudentID)
+	email := claims.String(mapping.Email)
+	fullName := claims.String(mapping.FullName)
+	programStudy := claims.String(mapping.ProgramStudy)
+	if studentID == "" || email == "" || fullName == "" || programStudy == "" || !claims.Bool("email_verified") {
+		return nil, errors.New("sso identity is missing required student claims")
+	}
+
+	// Akun SSO tidak punya password yang diketahui siapa pun; user tetap bisa
+	// memasang password lewat lupa password.
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashed, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &models.User{
+		ID:           uuid.New(),
+		Username:     studentID,
+		Email:        email,
+		PasswordHash: hashed,
+		FullName:     fullName,
+		CreatedAt:    now,
+	}
+	student := &models.Student{
+		ID:           uuid.New(),
+		UserID:       user.ID,
+		StudentID:    studentID,
+		ProgramStudy: programStudy,
+		AcademicYear: claims.String(mapping.AcademicYear),
+		CreatedAt:    now,
+	}
+	identity := models.UserIdentity{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Issuer:    s.sso.Issuer(),
+		Subject:   claims.String("sub"),
+		Email:     email,
+		CreatedAt: now,
+	}
+
+	if err := s.identities.ProvisionStudent(ctx, user, student, identity); err != nil {
+		if errors.Is(err, repository.ErrIdentityAccountExists) {
+			return nil, errors.New("an account with this username or email already exists")
+		}
+		return nil, err
+	}
+
+	if err := s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &user.ID,
+		EventType: models.SecurityEventSSOProvisioned,
+		Detail:    "student " + studentID + " provisioned from sso identity " + identity.Subject,
+		IPAddress: ipAddress,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.findActiveSSOUser(ctx, user.Username)
+}
+
+func (s *authService) findActiveSSOUser(ctx context.Context, username string) (*models.User, error) {
+	user, err := s.authrepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, errors.New("account is not active")
+	}
+	return user, nil
 }
 
 func (s *authService) GetProfile(
@@ -226,3 +1224,135 @@ func (s *authService) GetProfile(
 		Permissions: permissions,
 	}, nil
 }
+
+func (s *authService) recordLoginAttempt(
+	ctx context.Context,
+	req models.LoginRequest,
+	success bool,
+) {
+	attempt := models.LoginAttempt{
+		ID:        uuid.New(),
+		Username:  req.Username,
+		IPAddress: req.IPAddress,
+		Success:   success,
+		CreatedAt: time.Now(),
+	}
+	if err := s.authrepo.RecordLoginAttempt(ctx, attempt); err != nil {
+		log.Println("❌ failed to record login attempt:", err)
+	}
+}
+
+// registerFailedLogin menaikkan counter gagal login user dan mencatat
+// security event; event account_locked dicatat saat ambang lockout tercapai.
+func (s *authService) registerFailedLogin(
+	ctx context.Context,
+	user *models.User,
+	ipAddress string,
+	eventType string,
+) error {
+
+	updated, err := s.authrepo.RegisterFailedLogin(ctx, user.ID, s.cfg.MaxFailedLogins, s.cfg.LockoutDuration)
+	if err != nil {
+		return err
+	}
+
+	event := models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &user.ID,
+		EventType: eventType,
+		Detail:    fmt.Sprintf("failed login attempt %d", updated.FailedLoginAttempts),
+		IPAddress: ipAddress,
+		CreatedAt: time.Now(),
+	}
+	if updated.FailedLoginAttempts >= s.cfg.MaxFailedLogins {
+		event.EventType = models.SecurityEventAccountLocked
+		event.Detail = fmt.Sprintf(
+			"account locked for %s after %d failed login attempts",
+			s.cfg.LockoutDuration, updated.FailedLoginAttempts,
+		)
+	}
+
+	return s.securityEvents.Create(ctx, event)
+}
+
+// loginDelay menghitung sisa waktu tunggu progresif sebelum user boleh
+// mencoba login lagi.
+func (s *authService) loginDelay(user *models.User, now time.Time) time.Duration {
+	if user.LastFailedLoginAt == nil || user.FailedLoginAttempts < s.cfg.LoginDelayAfter {
+		return 0
+	}
+
+	delay := s.cfg.LoginDelayBase
+	for i := s.cfg.LoginDelayAfter; i < user.FailedLoginAttempts && delay < s.cfg.LoginDelayMax; i++ {
+		delay *= 2
+	}
+	if delay > s.cfg.LoginDelayMax {
+		delay = s.cfg.LoginDelayMax
+	}
+
+	return user.LastFailedLoginAt.Add(delay).Sub(now)
+}
+
+// newRefreshToken membuat refresh token baru di dalam family yang diberikan.
+// Claim ID (jti) dibuat unik agar dua token yang terbit pada detik yang sama
+// tetap berbeda.
+func newRefreshToken(userID uuid.UUID, familyID uuid.UUID) (models.RefreshToken, error) {
+	now := time.Now()
+	tokenID := uuid.New()
+
+	claims := &models.JWTClaims{
+		UserID:  userID,
+		Purpose: models.TokenPurposeRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(7 * 24 * time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "sistem-prestasi-mahasiswa",
+		},
+	}
+
+	token, err := utils.GenerateTokenWithClaims(claims)
+	if err != nil {
+		return models.RefreshToken{}, err
+	}
+
+	return models.RefreshToken{
+		ID:        tokenID,
+		UserID:    userID,
+		FamilyID:  familyID,
+		Token:     token,
+		ExpiresAt: now.Add(7 * 24 * time.Hour),
+		CreatedAt: now,
+	}, nil
+}
+
+// newMFAChallenge membuat token berumur pendek untuk langkah kedua login.
+// Token ini ditolak oleh middleware API karena memiliki purpose.
+func newMFAChallenge(user *models.User, purpose string) (*models.MFAChallengeResponse, error) {
+	now := time.Now()
+	expiresAt := now.Add(mfaChallengeTTL)
+
+	claims := &models.JWTClaims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.RoleName,
+		Purpose:  purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "sistem-prestasi-mahasiswa",
+		},
+	}
+
+	token, err := utils.GenerateTokenWithClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.MFAChallengeResponse{Status: "mfa_required"}
+	resp.Data.MFAToken = token
+	resp.Data.ExpiresAt = expiresAt
+	resp.Data.EnrollmentRequired = purpose == models.TokenPurposeMFAEnroll
+	return resp, nil
+}
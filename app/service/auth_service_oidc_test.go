@@ -0,0 +1,504 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/app/models"
+	"backend/app/oidc"
+	"backend/app/oidc/oidctest"
+	"backend/app/repository"
+	"backend/app/utils"
+
+	"github.com/google/uuid"
+)
+
+const ssoTestClientID = "sistem-prestasi"
+
+// ssoFixture menyambungkan authService ke IdP palsu dan repository di
+// memori. Semua role Mahasiswa diwajibkan MFA sehingga login SSO yang
+// berhasil berakhir di tantangan enrollment MFA, tanpa menyentuh penyimpanan
+// refresh token.
+type ssoFixture struct {
+	idp        *oidctest.Server
+	svc        AuthService
+	users      *fakeSSOUsers
+	identities *fakeIdentities
+	events     *fakeSecurityEvents
+}
+
+func newSSOFixture(t *testing.T, cfg AuthConfig) *ssoFixture {
+	t.Helper()
+
+	if err := utils.LoadJWTKeys(t.TempDir(), ""); err != nil {
+		t.Fatalf("LoadJWTKeys: %v", err)
+	}
+
+	idp := oidctest.NewServer(t, ssoTestClientID)
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:      idp.URL,
+		ClientID:    ssoTestClientID,
+		RedirectURL: "http://localhost:5173/auth/sso/callback",
+	})
+
+	users := &fakeSSOUsers{byUsername: map[string]*models.User{}}
+	identities := &fakeIdentities{
+		users:     users,
+		states:    map[string]models.OIDCLoginState{},
+		links:     map[string]string{},
+		byNIM:     map[string]string{},
+		byEmail:   map[string]string{},
+		provision: map[string]bool{},
+	}
+	events := &fakeSecurityEvents{}
+
+	cfg.MFARequiredRoles = []string{models.RoleMahasiswa}
+	cfg.OIDCClaims = OIDCClaimMapping{
+		Email:             "email",
+		FullName:          "name",
+		StudentID:         "nim",
+		ProgramStudy:      "program_study",
+		AcademicYear:      "academic_year",
+		StudentClaim:      cfg.OIDCClaims.StudentClaim,
+		StudentClaimValue: cfg.OIDCClaims.StudentClaimValue,
+	}
+
+	svc := NewAuthService(users, events, fakeNoMFA{}, nil, identities, nil, nil, nil, provider, cfg)
+	return &ssoFixture{idp: idp, svc: svc, users: users, identities: identities, events: events}
+}
+
+// login menjalankan alur SSO lengkap untuk claims: authorize, login di IdP,
+// lalu callback dari browser yang sama.
+func (f *ssoFixture) login(t *testing.T, claims map[string]interface{}) (*models.MFAChallengeResponse, error) {
+	t.Helper()
+
+	state, authURL := f.authorize(t)
+	code := f.idp.Authorize(t, authURL, claims)
+
+	_, challenge, err := f.svc.OIDCLogin(context.Background(), models.OIDCCallbackRequest{
+		Code:         code,
+		State:        state,
+		BrowserState: state,
+	})
+	return challenge, err
+}
+
+func (f *ssoFixture) authorize(t *testing.T) (string, string) {
+	t.Helper()
+
+	resp, err := f.svc.OIDCAuthorize(context.Background())
+	if err != nil {
+		t.Fatalf("OIDCAuthorize: %v", err)
+	}
+	return resp.Data.State, resp.Data.AuthorizationURL
+}
+
+func challengeUsername(t *testing.T, challenge *models.MFAChallengeResponse) string {
+	t.Helper()
+
+	if challenge == nil {
+		t.Fatal("no mfa challenge returned")
+	}
+	claims, err := utils.VerifyAccessToken(challenge.Data.MFAToken)
+	if err != nil {
+		t.Fatalf("VerifyAccessToken: %v", err)
+	}
+	return claims.Username
+}
+
+func TestOIDCAuthorizeSendsPKCEChallengeAndNonce(t *testing.T) {
+	f := newSSOFixture(t, AuthConfig{})
+
+	state, authURL := f.authorize(t)
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+
+	if q.Get("state") != state {
+		t.Errorf("state in url = %q, want %q", q.Get("state"), state)
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Errorf("missing S256 code challenge: %s", authURL)
+	}
+	if q.Get("nonce") == "" {
+		t.Error("missing nonce")
+	}
+
+	stored := f.identities.states[utils.HashToken(state)]
+	if stored.CodeVerifier == "" || strings.Contains(authURL, stored.CodeVerifier) {
+		t.Error("code verifier must be stored server-side and never sent in the authorize url")
+	}
+}
+
+func TestOIDCLoginRejectsStateFromAnotherBrowser(t *testing.T) {
+	f := newSSOFixture(t, AuthConfig{})
+	f.users.add("alice", "alice@example.com")
+	f.identities.links["subject-1"] = "alice"
+
+	state, authURL := f.authorize(t)
+	code := f.idp.Authorize(t, authURL, nil)
+
+	for _, browserState := range []string{"", "attacker-state"} {
+		_, _, err := f.svc.OIDCLogin(context.Background(), models.OIDCCallbackRequest{
+			Code: code, State: state, BrowserState: browserState,
+		})
+		if err == nil || err.Error() != "invalid or expired sso state" {
+			t.Errorf("browser state %q: err = %v, want invalid or expired sso state", browserState, err)
+		}
+	}
+
+	// State yang ditolak belum terpakai, jadi browser asli masih bisa
+	// menyelesaikan login.
+	_, challenge, err := f.svc.OIDCLogin(context.Background(), models.OIDCCallbackRequest{
+		Code: code, State: state, BrowserState: state,
+	})
+	if err != nil {
+		t.Fatalf("OIDCLogin: %v", err)
+	}
+	if got := challengeUsername(t, challenge); got != "alice" {
+		t.Errorf("username = %q, want alice", got)
+	}
+}
+
+func TestOIDCLoginStateIsSingleUse(t *testing.T) {
+	f := newSSOFixture(t, AuthConfig{})
+	f.users.add("alice", "alice@example.com")
+	f.identities.links["subject-1"] = "alice"
+
+	state, authURL := f.authorize(t)
+	req := models.OIDCCallbackRequest{Code: f.idp.Authorize(t, authURL, nil), State: state, BrowserState: state}
+
+	if _, _, err := f.svc.OIDCLogin(context.Background(), req); err != nil {
+		t.Fatalf("first OIDCLogin: %v", err)
+	}
+	if _, _, err := f.svc.OIDCLogin(context.Background(), req); err == nil || err.Error() != "invalid or expired sso state" {
+		t.Fatalf("replayed OIDCLogin: err = %v, want invalid or expired sso state", err)
+	}
+}
+
+func TestOIDCLoginRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+	}{
+		{"nonce mismatch", map[string]interface{}{"nonce": "replayed-nonce"}},
+		{"wrong issuer", map[string]interface{}{"iss": "https://evil.example.com"}},
+		{"wrong audience", map[string]interface{}{"aud": "another-client"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSSOFixture(t, AuthConfig{})
+			f.users.add("alice", "alice@example.com")
+			f.identities.links["subject-1"] = "alice"
+
+			_, err := f.login(t, tt.claims)
+			if err == nil || err.Error() != "sso authentication failed" {
+				t.Fatalf("err = %v, want sso authentication failed", err)
+			}
+			if len(f.identities.linked) != 0 || len(f.events.events) != 0 {
+				t.Error("rejected login must not link identities or record events")
+			}
+		})
+	}
+}
+
+func TestOIDCLoginLinksByStudentID(t *testing.T) {
+	f := newSSOFixture(t, AuthConfig{})
+	f.users.add("2101001", "budi@example.com")
+	f.identities.byNIM["2101001"] = "2101001"
+
+	challenge, err := f.login(t, map[string]interface{}{
+		"nim":   "2101001",
+		"email": "other@example.com",
+	})
+	if err != nil {
+		t.Fatalf("OIDCLogin: %v", err)
+	}
+	if got := challengeUsername(t, challenge); got != "2101001" {
+		t.Errorf("username = %q, want 2101001", got)
+	}
+	if got := f.identities.links["subject-1"]; got != "2101001" {
+		t.Errorf("identity linked to %q, want 2101001", got)
+	}
+	if !f.events.has(models.SecurityEventSSOLinked) {
+		t.Error("no sso_identity_linked event recorded")
+	}
+}
+
+func TestOIDCLoginLinksByVerifiedEmailOnly(t *testing.T) {
+	t.Run("verified", func(t *testing.T) {
+		f := newSSOFixture(t, AuthConfig{})
+		f.users.add("dewi", "dewi@example.com")
+		f.identities.byEmail["dewi@example.com"] = "dewi"
+
+		challenge, err := f.login(t, map[string]interface{}{
+			"email":          "dewi@example.com",
+			"email_verified": true,
+		})
+		if err != nil {
+			t.Fatalf("OIDCLogin: %v", err)
+		}
+		if got := challengeUsername(t, challenge); got != "dewi" {
+			t.Errorf("username = %q, want dewi", got)
+		}
+		if got := f.identities.links["subject-1"]; got != "dewi" {
+			t.Errorf("identity linked to %q, want dewi", got)
+		}
+	})
+
+	t.Run("unverified", func(t *testing.T) {
+		f := newSSOFixture(t, AuthConfig{})
+		f.users.add("dewi", "dewi@example.com")
+		f.identities.byEmail["dewi@example.com"] = "dewi"
+
+		_, err := f.login(t, map[string]interface{}{
+			"email":          "dewi@example.com",
+			"email_verified": false,
+		})
+		if err == nil || err.Error() != "no account is linked to this sso identity" {
+			t.Fatalf("err = %v, want no account is linked to this sso identity", err)
+		}
+		if len(f.identities.linked) != 0 {
+			t.Error("unverified email must not link an account")
+		}
+	})
+}
+
+func TestOIDCLoginProvisionsStudent(t *testing.T) {
+	studentClaims := map[string]interface{}{
+		"nim":            "2101002",
+		"email":          "citra@example.com",
+		"email_verified": true,
+		"name":           "Citra",
+		"program_study":  "Informatika",
+		"groups":         []interface{}{"mahasiswa"},
+	}
+
+	t.Run("provisioning disabled", func(t *testing.T) {
+		f := newSSOFixture(t, AuthConfig{})
+
+		_, err := f.login(t, studentClaims)
+		if err == nil || err.Error() != "no account is linked to this sso identity" {
+			t.Fatalf("err = %v, want no account is linked to this sso identity", err)
+		}
+	})
+
+	t.Run("student claim matches", func(t *testing.T) {
+		f := newSSOFixture(t, AuthConfig{
+			OIDCProvisioning: true,
+			OIDCClaims:       OIDCClaimMapping{StudentClaim: "groups", StudentClaimValue: "Mahasiswa"},
+		})
+
+		challenge, err := f.login(t, studentClaims)
+		if err != nil {
+			t.Fatalf("OIDCLogin: %v", err)
+		}
+		if got := challengeUsername(t, challenge); got != "2101002" {
+			t.Errorf("username = %q, want 2101002", got)
+		}
+		if !f.identities.provision["2101002"] {
+			t.Error("student was not provisioned")
+		}
+		if !f.events.has(models.SecurityEventSSOProvisioned) {
+			t.Error("no sso_user_provisioned event recorded")
+		}
+	})
+
+	t.Run("student claim missing", func(t *testing.T) {
+		f := newSSOFixture(t, AuthConfig{
+			OIDCProvisioning: true,
+			OIDCClaims:       OIDCClaimMapping{StudentClaim: "groups", StudentClaimValue: "Mahasiswa"},
+		})
+
+		claims := map[string]interface{}{}
+		for k, v := range studentClaims {
+			claims[k] = v
+		}
+		claims["groups"] = []interface{}{"staff"}
+
+		_, err := f.login(t, claims)
+		if err == nil || err.Error() != "no account is linked to this sso identity" {
+			t.Fatalf("err = %v, want no account is linked to this sso identity", err)
+		}
+		if len(f.identities.provision) != 0 {
+			t.Error("identity without the student claim must not be provisioned")
+		}
+	})
+
+	t.Run("email not verified", func(t *testing.T) {
+		f := newSSOFixture(t, AuthConfig{OIDCProvisioning: true})
+
+		claims := map[string]interface{}{}
+		for k, v := range studentClaims {
+			claims[k] = v
+		}
+		claims["email_verified"] = false
+
+		_, err := f.login(t, claims)
+		if err == nil || err.Error() != "sso identity is missing required student claims" {
+			t.Fatalf("err = %v, want sso identity is missing required student claims", err)
+		}
+	})
+}
+
+// fakeSSOUsers menyimpan user aktif untuk FindByUsername. Method
+// AuthRepository lain tidak dipakai alur SSO sampai tantangan MFA.
+type fakeSSOUsers struct {
+	repository.AuthRepository
+
+	mu         sync.Mutex
+	byUsername map[string]*models.User
+}
+
+func (r *fakeSSOUsers) add(username, email string) *models.User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u := &models.User{
+		ID:       uuid.New(),
+		Username: username,
+		Email:    email,
+		RoleID:   uuid.New(),
+		RoleName: models.RoleMahasiswa,
+		IsActive: true,
+	}
+	r.byUsername[username] = u
+	return u
+}
+
+func (r *fakeSSOUsers) usernameByID(id uuid.UUID) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for username, u := range r.byUsername {
+		if u.ID == id {
+			return username
+		}
+	}
+	return ""
+}
+
+func (r *fakeSSOUsers) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if u, ok := r.byUsername[username]; ok {
+		return u, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+type fakeIdentities struct {
+	users *fakeSSOUsers
+
+	mu        sync.Mutex
+	states    map[string]models.OIDCLoginState
+	links     map[string]string
+	byNIM     map[string]string
+	byEmail   map[string]string
+	provision map[string]bool
+	linked    []models.UserIdentity
+}
+
+func (r *fakeIdentities) CreateLoginState(ctx context.Context, state models.OIDCLoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *fakeIdentities) ConsumeLoginState(ctx context.Context, stateHash string) (*models.OIDCLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.states[stateHash]
+	if !ok || state.UsedAt != nil || time.Now().After(state.ExpiresAt) {
+		return nil, nil
+	}
+	now := time.Now()
+	state.UsedAt = &now
+	r.states[stateHash] = state
+	return &state, nil
+}
+
+func (r *fakeIdentities) FindUsernameByIdentity(ctx context.Context, issuer, subject string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.links[subject], nil
+}
+
+func (r *fakeIdentities) FindUsernameByEmail(ctx context.Context, email string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.byEmail[strings.ToLower(email)], nil
+}
+
+func (r *fakeIdentities) FindUsernameByStudentID(ctx context.Context, studentID string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.byNIM[studentID], nil
+}
+
+func (r *fakeIdentities) LinkIdentity(ctx context.Context, identity models.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.links[identity.Subject] = r.users.usernameByID(identity.UserID)
+	r.linked = append(r.linked, identity)
+	return nil
+}
+
+func (r *fakeIdentities) TouchIdentity(ctx context.Context, issuer, subject string) error {
+	return nil
+}
+
+func (r *fakeIdentities) ProvisionStudent(ctx context.Context, user *models.User, student *models.Student, identity models.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.provision[student.StudentID] = true
+	r.links[identity.Subject] = user.Username
+	r.users.add(user.Username, user.Email)
+	return nil
+}
+
+type fakeSecurityEvents struct {
+	repository.SecurityEventRepository
+
+	mu     sync.Mutex
+	events []models.SecurityEvent
+}
+
+func (r *fakeSecurityEvents) Create(ctx context.Context, event models.SecurityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeSecurityEvents) has(eventType string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.events {
+		if e.EventType == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeNoMFA struct {
+	repository.MFARepository
+}
+
+func (fakeNoMFA) FindByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	return nil, nil
+}
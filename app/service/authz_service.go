@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+// AuthzService menyimpan AuthzState per user di memori. Perubahan RBAC di
+// proses ini langsung menghapus cache (InvalidateUser/InvalidateAll);
+// proses lain menyusul setelah ttl habis.
+type AuthzService interface {
+	Current(ctx context.Context, userID uuid.UUID) (*models.AuthzState, error)
+	InvalidateUser(userID uuid.UUID)
+	InvalidateAll()
+}
+
+type authzEntry struct {
+	state    *models.AuthzState
+	loadedAt time.Time
+}
+
+type authzService struct {
+	repo repository.AuthzRepository
+	ttl  time.Duration
+
+	mu      sync.RWMutex
+	entries map[uuid.UUID]authzEntry
+}
+
+func NewAuthzService(repo repository.AuthzRepository, ttl time.Duration) AuthzService {
+	if ttl <= 0 {
+		ttl = 10 * time.Second
+	}
+	return &authzService{
+		repo:    repo,
+		ttl:     ttl,
+		entries: map[uuid.UUID]authzEntry{},
+	}
+}
+
+// Current mengembalikan nil jika user sudah tidak ada.
+func (s *authzService) Current(ctx context.Context, userID uuid.UUID) (*models.AuthzState, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[userID]
+	s.mu.RUnlock()
+
+	if ok && time.Since(entry.loadedAt) < s.ttl {
+		return entry.state, nil
+	}
+
+	state, err := s.repo.GetState(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.entries[userID] = authzEntry{state: state, loadedAt: time.Now()}
+	s.mu.Unlock()
+
+	return state, nil
+}
+
+func (s *authzService) InvalidateUser(userID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.entries, userID)
+	s.mu.Unlock()
+}
+
+// InvalidateAll dipakai saat role atau permission berubah, karena satu role
+// bisa dimiliki banyak user.
+func (s *authzService) InvalidateAll() {
+	s.mu.Lock()
+	s.entries = map[uuid.UUID]authzEntry{}
+	s.mu.Unlock()
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+)
+
+type CatalogService interface {
+	GetCatalog(ctx context.Context, catalog, locale string, includeInactive bool) ([]models.CatalogItem, error)
+	SaveItem(ctx context.Context, catalog, code string, req *models.CatalogItemRequest) (*models.CatalogItem, error)
+	Normalize(ctx context.Context, achievement *models.Achievement) ([]models.FieldError, error)
+	NormalizeStored(ctx context.Context, dryRun bool) (*models.CatalogNormalizationReport, error)
+}
+
+type catalogService struct {
+	repo            repository.CatalogRepository
+	achievementRepo repository.AchievementRepository
+}
+
+func NewCatalogService(repo repository.CatalogRepository, achievementRepo repository.AchievementRepository) CatalogService {
+	return &catalogService{repo: repo, achievementRepo: achievementRepo}
+}
+
+// catalogField adalah field prestasi yang nilainya diambil dari katalog.
+// Path dipakai sebagai nama field di MongoDB dan di pesan error.
+type catalogField struct {
+	catalog string
+	path    string
+	value   func(a *models.Achievement) *string
+}
+
+var catalogFields = []catalogField{
+	{models.CatalogAchievementType, "achievementType", func(a *models.Achievement) *string { return &a.AchievementType }},
+	{models.CatalogCompetitionLevel, "details.competitionLevel", func(a *models.Achievement) *string { return &a.Details.CompetitionLevel }},
+	{models.CatalogMedalType, "details.medalType", func(a *models.Achievement) *string { return &a.Details.MedalType }},
+	{models.CatalogPublicationType, "details.publicationType", func(a *models.Achievement) *string { return &a.Details.PublicationType }},
+}
+
+func knownCatalog(catalog string) bool {
+	for _, f := range catalogFields {
+		if f.catalog == catalog {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogIndex memetakan code dan alias (huruf kecil) ke item per katalog.
+type catalogIndex map[string]map[string]*models.CatalogItem
+
+func newCatalogIndex(items []models.CatalogItem) catalogIndex {
+	idx := catalogIndex{}
+	for i := range items {
+		item := &items[i]
+		if idx[item.Catalog] == nil {
+			idx[item.Catalog] = map[string]*models.CatalogItem{}
+		}
+		idx[item.Catalog][strings.ToLower(item.Code)] = item
+		for _, alias := range item.Aliases {
+			idx[item.Catalog][strings.ToLower(alias)] = item
+		}
+	}
+	return idx
+}
+
+func (idx catalogIndex) lookup(catalog, value string) *models.CatalogItem {
+	return idx[catalog][strings.ToLower(strings.TrimSpace(value))]
+}
+
+func (idx catalogIndex) activeCodes(catalog string) []string {
+	seen := map[string]bool{}
+	active := []*models.CatalogItem{}
+	for _, item := range idx[catalog] {
+		if item.IsActive && !seen[item.Code] {
+			seen[item.Code] = true
+			active = append(active, item)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].SortOrder != active[j].SortOrder {
+			return active[i].SortOrder < active[j].SortOrder
+		}
+		return active[i].Code < active[j].Code
+	})
+
+	codes := make([]string, len(active))
+	for i, item := range active {
+		codes[i] = item.Code
+	}
+	return codes
+}
+
+// GetCatalog mengembalikan isi satu katalog sesuai urutan, dengan Label
+// dalam locale yang diminta (fallback ke locale bawaan, lalu code).
+func (s *catalogService) GetCatalog(
+	ctx context.Context,
+	catalog, locale string,
+	includeInactive bool,
+) ([]models.CatalogItem, error) {
+
+	if !knownCatalog(catalog) {
+		return nil, errors.New("catalog not found")
+	}
+
+	items, err := s.repo.FindByCatalog(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.CatalogItem, 0, len(items))
+	for _, item := range items {
+		if !item.IsActive && !includeInactive {
+			continue
+		}
+		item.Label = localizedLabel(item, locale)
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func localizedLabel(item models.CatalogItem, locale string) string {
+	if label, ok := item.Labels[locale]; ok {
+		return label
+	}
+	if label, ok := item.Labels[models.DefaultCatalogLocale]; ok {
+		return label
+	}
+	return item.Code
+}
+
+var catalogCodePattern = regexp.MustCompile(`^[a-z0-9_-]{1,50}$`)
+
+// SaveItem membuat atau mengganti satu nilai katalog. Code dan alias harus
+// unik di dalam katalog agar normalisasi tidak ambigu.
+func (s *catalogService) SaveItem(
+	ctx context.Context,
+	catalog, code string,
+	req *models.CatalogItemRequest,
+) (*models.CatalogItem, error) {
+
+	if !knownCatalog(catalog) {
+		return nil, errors.New("catalog not found")
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !catalogCodePattern.MatchString(code) {
+		return nil, errors.New("invalid catalog code")
+	}
+
+	items, err := s.repo.FindByCatalog(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &models.CatalogItem{
+		Catalog:   catalog,
+		Code:      code,
+		Labels:    req.Labels,
+		Aliases:   []string{},
+		SortOrder: req.SortOrder,
+		IsActive:  true,
+		UpdatedAt: time.Now(),
+	}
+
+	others := make([]models.CatalogItem, 0, len(items))
+	for _, existing := range items {
+		if existing.Code == code {
+			item.IsActive = existing.IsActive
+			continue
+		}
+		others = append(others, existing)
+	}
+	if req.IsActive != nil {
+		item.IsActive = *req.IsActive
+	}
+
+	idx := newCatalogIndex(others)
+	if owner := idx.lookup(catalog, code); owner != nil {
+		return nil, fmt.Errorf("catalog code %q is already used by %q", code, owner.Code)
+	}
+
+	seen := map[string]bool{code: true}
+	for _, alias := range req.Aliases {
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		if seen[alias] {
+			continue
+		}
+		if owner := idx.lookup(catalog, alias); owner != nil {
+			return nil, fmt.Errorf("catalog alias %q is already used by %q", alias, owner.Code)
+		}
+		seen[alias] = true
+		item.Aliases = append(item.Aliases, alias)
+	}
+
+	if err := s.repo.Save(ctx, item); err != nil {
+		return nil, err
+	}
+
+	item.Label = localizedLabel(*item, models.DefaultCatalogLocale)
+	return item, nil
+}
+
+// Normalize mengganti nilai field katalog prestasi dengan code resminya
+// (mencocokkan code atau alias tanpa membedakan huruf besar/kecil) dan
+// mengembalikan pelanggaran untuk nilai yang tidak dikenal atau nonaktif.
+func (s *catalogService) Normalize(
+	ctx context.Context,
+	achievement *models.Achievement,
+) ([]models.FieldError, error) {
+
+	items, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx := newCatalogIndex(items)
+
+	var errs []models.FieldError
+	for _, f := range catalogFields {
+		v := f.value(achievement)
+		if strings.TrimSpace(*v) == "" {
+			continue
+		}
+
+		item := idx.lookup(f.catalog, *v)
+		switch {
+		case item == nil:
+			errs = append(errs, models.FieldError{
+				Field:   f.path,
+				Message: "must be one of: " + strings.Join(idx.activeCodes(f.catalog), ", "),
+			})
+		case !item.IsActive:
+			errs = append(errs, models.FieldError{Field: f.path, Message: "is no longer accepted"})
+		default:
+			*v = item.Code
+		}
+	}
+	return errs, nil
+}
+
+// NormalizeStored memetakan nilai lama di MongoDB ke code katalog. Nilai
+// yang tidak cocok dilaporkan dan dibiarkan. Dengan dryRun, hanya
+// menghitung dokumen yang akan diubah.
+func (s *catalogService) NormalizeStored(ctx context.Context, dryRun bool) (*models.CatalogNormalizationReport, error) {
+	items, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx := newCatalogIndex(items)
+
+	report := &models.CatalogNormalizationReport{
+		DryRun:    dryRun,
+		Changes:   []models.CatalogNormalization{},
+		Unmatched: []models.CatalogUnmatchedValue{},
+	}
+
+	for _, f := range catalogFields {
+		values, err := s.achievementRepo.DistinctValues(ctx, f.path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range values {
+			if strings.TrimSpace(v) == "" {
+				continue
+			}
+
+			item := idx.lookup(f.catalog, v)
+			if item != nil && item.Code == v {
+				continue
+			}
+
+			if item == nil {
+				count, err := s.achievementRepo.CountFieldValue(ctx, f.path, v)
+				if err != nil {
+					return nil, err
+				}
+				report.Unmatched = append(report.Unmatched, models.CatalogUnmatchedValue{Field: f.path, Value: v, Count: count})
+				continue
+			}
+
+			var count int64
+			if dryRun {
+				count, err = s.achievementRepo.CountFieldValue(ctx, f.path, v)
+			} else {
+				count, err = s.achievementRepo.ReplaceFieldValue(ctx, f.path, v, item.Code)
+			}
+			if err != nil {
+				return nil, err
+			}
+			report.Changes = append(report.Changes, models.CatalogNormalization{Field: f.path, From: v, To: item.Code, Count: count})
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+	"backend/app/utils"
+
+	"github.com/google/uuid"
+)
+
+const recoveryCodeCount = 10
+
+type MFAService interface {
+	Setup(ctx context.Context, userID uuid.UUID, username string) (*models.MFASetupResponse, error)
+	Enable(ctx context.Context, userID uuid.UUID, code string) (*models.MFARecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID uuid.UUID, role string, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*models.MFARecoveryCodesResponse, error)
+}
+
+type mfaService struct {
+	repo           repository.MFARepository
+	securityEvents repository.SecurityEventRepository
+	issuer         string
+	requiredRoles  []string
+}
+
+func NewMFAService(
+	repo repository.MFARepository,
+	securityEvents repository.SecurityEventRepository,
+	issuer string,
+	requiredRoles []string,
+) MFAService {
+	return &mfaService{
+		repo:           repo,
+		securityEvents: securityEvents,
+		issuer:         issuer,
+		requiredRoles:  requiredRoles,
+	}
+}
+
+// Setup membuat secret TOTP baru yang belum aktif. Secret baru aktif setelah
+// user membuktikan authenticator-nya lewat Enable.
+func (s *mfaService) Setup(
+	ctx context.Context,
+	userID uuid.UUID,
+	username string,
+) (*models.MFASetupResponse, error) {
+
+	existing, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Enabled {
+		return nil, errors.New("mfa already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpsertPending(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &models.MFASetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.issuer, username, secret),
+	}, nil
+}
+
+func (s *mfaService) Enable(
+	ctx context.Context,
+	userID uuid.UUID,
+	code string,
+) (*models.MFARecoveryCodesResponse, error) {
+
+	mfa, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, errors.New("mfa setup not started")
+	}
+	if mfa.Enabled {
+		return nil, errors.New("mfa already enabled")
+	}
+
+	step, ok := utils.ValidateTOTP(mfa.Secret, code, time.Now(), 1)
+	if !ok {
+		return nil, errors.New("invalid mfa code")
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Enable(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordEvent(ctx, userID, models.SecurityEventMFAEnabled, "totp enabled"); err != nil {
+		return nil, err
+	}
+
+	return &models.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) Disable(
+	ctx context.Context,
+	userID uuid.UUID,
+	role string,
+	code string,
+) error {
+
+	if mfaRequiredForRole(s.requiredRoles, role) {
+		return errors.New("mfa is mandatory for role " + role)
+	}
+
+	mfa, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return errors.New("mfa is not enabled")
+	}
+
+	ok, _, err := verifyMFACode(ctx, s.repo, mfa, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid mfa code")
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.recordEvent(ctx, userID, models.SecurityEventMFADisabled, "totp disabled by user")
+}
+
+func (s *mfaService) RegenerateRecoveryCodes(
+	ctx context.Context,
+	userID uuid.UUID,
+	code string,
+) (*models.MFARecoveryCodesResponse, error) {
+
+	mfa, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return nil, errors.New("mfa is not enabled")
+	}
+
+	step, ok := utils.ValidateTOTP(mfa.Secret, code, time.Now(), 1)
+	if !ok {
+		return nil, errors.New("invalid mfa code")
+	}
+	fresh, err := s.repo.MarkStepUsed(ctx, userID, step)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, errors.New("invalid mfa code")
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &models.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) recordEvent(ctx context.Context, userID uuid.UUID, eventType string, detail string) error {
+	return s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &userID,
+		EventType: eventType,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	})
+}
+
+// verifyMFACode menerima kode TOTP atau kode pemulihan. Kode TOTP yang sudah
+// pernah dipakai ditolak; kode pemulihan hanya berlaku sekali.
+func verifyMFACode(
+	ctx context.Context,
+	repo repository.MFARepository,
+	mfa *models.UserMFA,
+	code string,
+) (ok bool, usedRecoveryCode bool, err error) {
+
+	if step, valid := utils.ValidateTOTP(mfa.Secret, code, time.Now(), 1); valid {
+		fresh, err := repo.MarkStepUsed(ctx, mfa.UserID, step)
+		return fresh, false, err
+	}
+
+	used, err := repo.UseRecoveryCode(ctx, mfa.UserID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	return used, used, err
+}
+
+func mfaRequiredForRole(requiredRoles []string, role string) bool {
+	for _, r := range requiredRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = utils.HashToken(utils.NormalizeRecoveryCode(c))
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"backend/app/models"
+	"backend/app/repository"
+	"backend/app/utils"
+
+	"github.com/google/uuid"
+)
+
+// bcrypt hanya memakai 72 byte pertama password.
+const maxPasswordBytes = 72
+
+// PasswordPolicyError berisi semua aturan yang dilanggar, agar klien bisa
+// menampilkan semuanya sekaligus.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password does not meet policy: " + strings.Join(e.Violations, "; ")
+}
+
+// PasswordPolicyService memeriksa password baru terhadap aturan (panjang,
+// jenis karakter, daftar terlarang, tidak sama dengan username/NIM) dan
+// riwayat password user.
+type PasswordPolicyService interface {
+	Policy() models.PasswordPolicy
+	// Check memeriksa password untuk user yang sudah ada (riwayat dan
+	// identitas diambil dari database). Untuk user baru, isi userID dengan
+	// uuid.Nil dan kirim identitasnya lewat identifiers.
+	Check(ctx context.Context, userID uuid.UUID, password string, identifiers ...string) error
+}
+
+type passwordPolicyService struct {
+	repo   repository.PasswordRepository
+	policy models.PasswordPolicy
+	banned map[string]bool
+}
+
+func NewPasswordPolicyService(repo repository.PasswordRepository, policy models.PasswordPolicy) PasswordPolicyService {
+	if policy.MinLength <= 0 {
+		policy.MinLength = 8
+	}
+	if policy.MaxLength <= 0 || policy.MaxLength > maxPasswordBytes {
+		policy.MaxLength = maxPasswordBytes
+	}
+
+	banned := map[string]bool{}
+	for _, p := range policy.BannedPasswords {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			banned[p] = true
+		}
+	}
+
+	return &passwordPolicyService{repo: repo, policy: policy, banned: banned}
+}
+
+func (s *passwordPolicyService) Policy() models.PasswordPolicy {
+	return s.policy
+}
+
+func (s *passwordPolicyService) Check(
+	ctx context.Context,
+	userID uuid.UUID,
+	password string,
+	identifiers ...string,
+) error {
+
+	if userID != uuid.Nil {
+		stored, err := s.repo.GetIdentifiers(ctx, userID)
+		if err != nil {
+			return err
+		}
+		identifiers = append(identifiers, stored...)
+	}
+
+	violations := s.validate(password, identifiers)
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	if userID != uuid.Nil && s.policy.HistorySize > 0 {
+		hashes, err := s.repo.RecentHashes(ctx, userID, s.policy.HistorySize)
+		if err != nil {
+			return err
+		}
+		for _, h := range hashes {
+			if utils.CheckPasswordHash(h, password) == nil {
+				return &PasswordPolicyError{Violations: []string{
+					"must not reuse any of the last " + strconv.Itoa(s.policy.HistorySize) + " passwords",
+				}}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *passwordPolicyService) validate(password string, identifiers []string) []string {
+	var violations []string
+
+	if n := len([]rune(password)); n < s.policy.MinLength {
+		violations = append(violations, "must be at least "+strconv.Itoa(s.policy.MinLength)+" characters")
+	}
+	if len(password) > s.policy.MaxLength {
+		violations = append(violations, "must be at most "+strconv.Itoa(s.policy.MaxLength)+" bytes")
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if s.policy.RequireUpper && !upper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if s.policy.RequireLower && !lower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if s.policy.RequireDigit && !digit {
+		violations = append(violations, "must contain a digit")
+	}
+	if s.policy.RequireSymbol && !symbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(password))
+	if s.banned[normalized] {
+		violations = append(violations, "is too common")
+	}
+
+	for _, id := range identifiers {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if id == "" {
+			continue
+		}
+		// Email dibandingkan dengan bagian sebelum @ juga.
+		local := id
+		if i := strings.Index(id, "@"); i > 0 {
+			local = id[:i]
+		}
+		if normalized == id || normalized == local {
+			violations = append(violations, "must not be the same as your username, email or student ID")
+			break
+		}
+	}
+
+	return violations
+}
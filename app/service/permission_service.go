@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+type PermissionService interface {
+	GetAllPermissions(ctx context.Context) ([]models.Permission, error)
+	GetPermissionByID(ctx context.Context, id uuid.UUID) (*models.Permission, error)
+	CreatePermission(ctx context.Context, req *models.CreatePermissionRequest) (*models.Permission, error)
+	UpdatePermission(ctx context.Context, id uuid.UUID, req *models.UpdatePermissionRequest) (*models.Permission, error)
+	DeletePermission(ctx context.Context, id uuid.UUID) error
+}
+
+type permissionService struct {
+	permissionRepo repository.PermissionRepository
+	authz          AuthzService
+}
+
+func NewPermissionService(permissionRepo repository.PermissionRepository, authz AuthzService) PermissionService {
+	return &permissionService{permissionRepo: permissionRepo, authz: authz}
+}
+
+func (s *permissionService) GetAllPermissions(ctx context.Context) ([]models.Permission, error) {
+	permissions, err := s.permissionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if permissions == nil {
+		permissions = []models.Permission{}
+	}
+	return permissions, nil
+}
+
+func (s *permissionService) GetPermissionByID(ctx context.Context, id uuid.UUID) (*models.Permission, error) {
+	return s.findPermission(ctx, id)
+}
+
+func (s *permissionService) CreatePermission(
+	ctx context.Context,
+	req *models.CreatePermissionRequest,
+) (*models.Permission, error) {
+
+	permission := &models.Permission{
+		ID:          uuid.New(),
+		Resource:    strings.TrimSpace(req.Resource),
+		Action:      strings.TrimSpace(req.Action),
+		Description: req.Description,
+	}
+	permission.Name = permission.Resource + ":" + permission.Action
+
+	if err := s.ensureUniqueName(ctx, permission); err != nil {
+		return nil, err
+	}
+
+	if err := s.permissionRepo.Create(ctx, permission); err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (s *permissionService) UpdatePermission(
+	ctx context.Context,
+	id uuid.UUID,
+	req *models.UpdatePermissionRequest,
+) (*models.Permission, error) {
+
+	permission, err := s.findPermission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	permission.Resource = strings.TrimSpace(req.Resource)
+	permission.Action = strings.TrimSpace(req.Action)
+	permission.Name = permission.Resource + ":" + permission.Action
+	permission.Description = req.Description
+
+	if err := s.ensureUniqueName(ctx, permission); err != nil {
+		return nil, err
+	}
+
+	if err := s.permissionRepo.Update(ctx, permission); err != nil {
+		return nil, err
+	}
+	s.authz.InvalidateAll()
+	return permission, nil
+}
+
+func (s *permissionService) DeletePermission(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.findPermission(ctx, id); err != nil {
+		return err
+	}
+
+	count, err := s.permissionRepo.CountRoles(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("permission is still assigned to roles")
+	}
+
+	return s.permissionRepo.Delete(ctx, id)
+}
+
+func (s *permissionService) findPermission(ctx context.Context, id uuid.UUID) (*models.Permission, error) {
+	permission, err := s.permissionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if permission == nil {
+		return nil, errors.New("permission not found")
+	}
+	return permission, nil
+}
+
+func (s *permissionService) ensureUniqueName(ctx context.Context, permission *models.Permission) error {
+	if strings.ContainsAny(permission.Resource+permission.Action, ": ") {
+		return errors.New("resource and action must not contain spaces or ':'")
+	}
+
+	existing, err := s.permissionRepo.FindByName(ctx, permission.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != permission.ID {
+		return errors.New("permission already exists")
+	}
+	return nil
+}
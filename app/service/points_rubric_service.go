@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+type PointsRubricService interface {
+	GetAll(ctx context.Context) ([]models.PointsRubric, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PointsRubric, error)
+	Create(ctx context.Context, req *models.CreatePointsRubricRequest, actorID uuid.UUID) (*models.PointsRubric, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Suggest(ctx context.Context, achievement *models.Achievement, at time.Time) (*models.PointsSuggestion, error)
+	GetOverrides(ctx context.Context, referenceID uuid.UUID) ([]models.PointsOverride, error)
+}
+
+type pointsRubricService struct {
+	repo repository.PointsRubricRepository
+}
+
+func NewPointsRubricService(repo repository.PointsRubricRepository) PointsRubricService {
+	return &pointsRubricService{repo: repo}
+}
+
+func (s *pointsRubricService) GetAll(ctx context.Context) ([]models.PointsRubric, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *pointsRubricService) GetByID(ctx context.Context, id uuid.UUID) (*models.PointsRubric, error) {
+	rb, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rb == nil {
+		return nil, errors.New("points rubric not found")
+	}
+	return rb, nil
+}
+
+// Create membuat versi rubrik baru. Versi lama tidak diubah agar prestasi
+// yang sudah dinilai tetap bisa ditelusuri ke rubrik yang dipakai.
+func (s *pointsRubricService) Create(
+	ctx context.Context,
+	req *models.CreatePointsRubricRequest,
+	actorID uuid.UUID,
+) (*models.PointsRubric, error) {
+
+	seen := make(map[int]bool, len(req.TeamFactors))
+	for _, f := range req.TeamFactors {
+		if seen[f.MinTeamSize] {
+			return nil, errors.New("duplicate team factor for the same team size")
+		}
+		seen[f.MinTeamSize] = true
+	}
+
+	rules := make([]models.PointsRubricRule, len(req.Rules))
+	for i, rule := range req.Rules {
+		rule.ID = uuid.New()
+		rule.AchievementType = strings.TrimSpace(rule.AchievementType)
+		rule.CompetitionLevel = trimmedOrNil(rule.CompetitionLevel)
+		rule.MedalType = trimmedOrNil(rule.MedalType)
+		rule.PublicationType = trimmedOrNil(rule.PublicationType)
+		rules[i] = rule
+	}
+
+	rb := &models.PointsRubric{
+		ID:            uuid.New(),
+		Name:          strings.TrimSpace(req.Name),
+		EffectiveFrom: req.EffectiveFrom,
+		CreatedBy:     &actorID,
+		CreatedAt:     time.Now(),
+		Rules:         rules,
+		TeamFactors:   req.TeamFactors,
+	}
+	if rb.TeamFactors == nil {
+		rb.TeamFactors = []models.TeamFactor{}
+	}
+
+	if err := s.repo.Create(ctx, rb); err != nil {
+		return nil, err
+	}
+	return rb, nil
+}
+
+// Delete hanya mengizinkan versi yang belum berlaku; versi yang sudah
+// berlaku mungkin sudah dipakai untuk menilai prestasi.
+func (s *pointsRubricService) Delete(ctx context.Context, id uuid.UUID) error {
+	rb, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !rb.EffectiveFrom.After(time.Now()) {
+		return errors.New("points rubric already in effect cannot be deleted")
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Suggest menghitung poin dari rubrik yang berlaku pada waktu at: aturan
+// dengan kriteria terisi paling banyak yang cocok (lalu poin tertinggi),
+// dikali faktor tim. Ukuran tim diambil dari details.teamSize, atau jumlah
+// penulis untuk publikasi.
+func (s *pointsRubricService) Suggest(
+	ctx context.Context,
+	achievement *models.Achievement,
+	at time.Time,
+) (*models.PointsSuggestion, error) {
+
+	teamSize := achievement.Details.TeamSize
+	if teamSize <= 0 {
+		teamSize = len(achievement.Details.Authors)
+	}
+	if teamSize <= 0 {
+		teamSize = 1
+	}
+
+	suggestion := &models.PointsSuggestion{TeamSize: teamSize, Multiplier: 1}
+
+	rb, err := s.repo.FindEffective(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	if rb == nil {
+		return suggestion, nil
+	}
+	suggestion.RubricID = &rb.ID
+	suggestion.RubricVersion = rb.Version
+
+	var best *models.PointsRubricRule
+	bestScore := -1
+	for i := range rb.Rules {
+		score, ok := matchRule(&rb.Rules[i], achievement)
+		if !ok {
+			continue
+		}
+		if score > bestScore || (score == bestScore && rb.Rules[i].Points > best.Points) {
+			best, bestScore = &rb.Rules[i], score
+		}
+	}
+	if best == nil {
+		return suggestion, nil
+	}
+
+	bestFactor := 0
+	for _, f := range rb.TeamFactors {
+		if f.MinTeamSize <= teamSize && f.MinTeamSize > bestFactor {
+			bestFactor = f.MinTeamSize
+			suggestion.Multiplier = f.Multiplier
+		}
+	}
+
+	points := math.Round(best.Points*suggestion.Multiplier*100) / 100
+	suggestion.RuleID = &best.ID
+	suggestion.BasePoints = best.Points
+	suggestion.Points = &points
+	return suggestion, nil
+}
+
+func (s *pointsRubricService) GetOverrides(ctx context.Context, referenceID uuid.UUID) ([]models.PointsOverride, error) {
+	return s.repo.GetOverrides(ctx, referenceID)
+}
+
+// matchRule mengembalikan jumlah kriteria terisi yang cocok, atau false jika
+// ada kriteria yang tidak cocok.
+func matchRule(rule *models.PointsRubricRule, a *models.Achievement) (int, bool) {
+	if !strings.EqualFold(rule.AchievementType, a.AchievementType) {
+		return 0, false
+	}
+
+	score := 0
+	for _, c := range []struct {
+		want *string
+		got  string
+	}{
+		{rule.CompetitionLevel, a.Details.CompetitionLevel},
+		{rule.MedalType, a.Details.MedalType},
+		{rule.PublicationType, a.Details.PublicationType},
+	} {
+		if c.want == nil {
+			continue
+		}
+		if !strings.EqualFold(*c.want, c.got) {
+			return 0, false
+		}
+		score++
+	}
+
+	if rule.Rank != nil {
+		if *rule.Rank != a.Details.Rank {
+			return 0, false
+		}
+		score++
+	}
+
+	return score, true
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"backend/app/mailer"
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+// RegistrationService mengelola sisi admin registrasi mandiri: allow-list
+// NIM dan persetujuan akun yang menunggu.
+type RegistrationService interface {
+	// ImportAllowlistCSV membaca CSV "nim,program_study[,academic_year]"
+	// (baris header opsional). Baris yang tidak valid dilewati dan dilaporkan.
+	ImportAllowlistCSV(ctx context.Context, r io.Reader, adminID uuid.UUID) (int, []string, error)
+	ListAllowlist(ctx context.Context, page, limit int) ([]models.RegistrationAllowlistEntry, error)
+	DeleteAllowlistEntry(ctx context.Context, studentID string) error
+
+	ListPending(ctx context.Context, page, limit int) ([]models.PendingRegistration, error)
+	Approve(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) error
+	// Reject menghapus akun yang menunggu dan melepas klaim NIM-nya.
+	Reject(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) error
+}
+
+type registrationService struct {
+	repo           repository.RegistrationRepository
+	securityEvents repository.SecurityEventRepository
+	mailer         mailer.Mailer
+	authz          AuthzService
+}
+
+func NewRegistrationService(
+	repo repository.RegistrationRepository,
+	securityEvents repository.SecurityEventRepository,
+	mail mailer.Mailer,
+	authz AuthzService,
+) RegistrationService {
+	return &registrationService{
+		repo:           repo,
+		securityEvents: securityEvents,
+		mailer:         mail,
+		authz:          authz,
+	}
+}
+
+func (s *registrationService) ImportAllowlistCSV(
+	ctx context.Context,
+	r io.Reader,
+	adminID uuid.UUID,
+) (int, []string, error) {
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []models.RegistrationAllowlistEntry
+	skipped := []string{}
+	seen := map[string]bool{}
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		if line == 1 && len(record) > 0 && isAllowlistHeader(record[0]) {
+			continue
+		}
+
+		entry, reason := parseAllowlistRecord(record)
+		if reason == "" && seen[entry.StudentID] {
+			reason = "duplicate student id"
+		}
+		if reason != "" {
+			skipped = append(skipped, fmt.Sprintf("line %d: %s", line, reason))
+			continue
+		}
+
+		seen[entry.StudentID] = true
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return 0, skipped, nil
+	}
+
+	if err := s.repo.UpsertAllowlist(ctx, entries, adminID); err != nil {
+		return 0, nil, err
+	}
+	return len(entries), skipped, nil
+}
+
+func isAllowlistHeader(first string) bool {
+	switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(first, "\ufeff"))) {
+	case "nim", "student_id", "studentid":
+		return true
+	}
+	return false
+}
+
+func parseAllowlistRecord(record []string) (models.RegistrationAllowlistEntry, string) {
+	var entry models.RegistrationAllowlistEntry
+	if len(record) < 2 {
+		return entry, "expected nim,program_study[,academic_year]"
+	}
+
+	entry.StudentID = strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff"))
+	entry.ProgramStudy = strings.TrimSpace(record[1])
+	if len(record) > 2 {
+		entry.AcademicYear = strings.TrimSpace(record[2])
+	}
+
+	switch {
+	case entry.StudentID == "" || len(entry.StudentID) > 20:
+		return entry, "student id must be 1-20 characters"
+	case entry.ProgramStudy == "" || len(entry.ProgramStudy) > 100:
+		return entry, "program study must be 1-100 characters"
+	case len(entry.AcademicYear) > 10:
+		return entry, "academic year must be at most 10 characters"
+	}
+	return entry, ""
+}
+
+func (s *registrationService) ListAllowlist(
+	ctx context.Context,
+	page, limit int,
+) ([]models.RegistrationAllowlistEntry, error) {
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 50
+	}
+	return s.repo.ListAllowlist(ctx, limit, (page-1)*limit)
+}
+
+func (s *registrationService) DeleteAllowlistEntry(ctx context.Context, studentID string) error {
+	deleted, err := s.repo.DeleteAllowlistEntry(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("allowlist entry not found")
+	}
+	return nil
+}
+
+func (s *registrationService) ListPending(
+	ctx context.Context,
+	page, limit int,
+) ([]models.PendingRegistration, error) {
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	return s.repo.ListPending(ctx, limit, (page-1)*limit)
+}
+
+func (s *registrationService) Approve(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) error {
+	pending, err := s.repo.FindPending(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return errors.New("registration not found")
+	}
+	if pending.EmailVerifiedAt == nil {
+		return errors.New("email not verified")
+	}
+
+	if err := s.repo.Approve(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrRegistrationNotPending) {
+			return errors.New("registration not found")
+		}
+		return err
+	}
+	s.authz.InvalidateUser(userID)
+
+	if err := s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &userID,
+		EventType: models.SecurityEventRegistration,
+		Detail:    "self-registration approved by admin " + adminID.String(),
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      pending.Email,
+		Subject: "Akun Sistem Pelaporan Prestasi telah aktif",
+		Body: "Halo " + pending.FullName + ",\n\n" +
+			"Pendaftaran Anda dengan NIM " + pending.StudentID + " telah disetujui.\n" +
+			"Silakan login menggunakan username " + pending.Username + ".",
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Println("❌ failed to send registration approval email:", err)
+	}
+
+	return nil
+}
+
+func (s *registrationService) Reject(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) error {
+	pending, err := s.repo.FindPending(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return errors.New("registration not found")
+	}
+
+	if err := s.repo.Reject(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrRegistrationNotPending) {
+			return errors.New("registration not found")
+		}
+		return err
+	}
+	s.authz.InvalidateUser(userID)
+
+	// Akun sudah dihapus, jadi event dicatat atas nama admin.
+	if err := s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &adminID,
+		EventType: models.SecurityEventRegistrationRejected,
+		Detail:    "self-registration of " + pending.Username + " (NIM " + pending.StudentID + ") rejected",
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      pending.Email,
+		Subject: "Pendaftaran Sistem Pelaporan Prestasi ditolak",
+		Body: "Halo " + pending.FullName + ",\n\n" +
+			"Pendaftaran Anda dengan NIM " + pending.StudentID + " tidak disetujui.\n" +
+			"Hubungi admin jika menurut Anda ini keliru.",
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Println("❌ failed to send registration rejection email:", err)
+	}
+
+	return nil
+}
@@ -16,6 +16,14 @@ type ReportService interface {
 	GetStudentReport(ctx context.Context, requesterRole string, requesterID uuid.UUID, studentID uuid.UUID) (*models.StudentReportResponse, error)
 }
 
+// ErrReportAccessDenied dikembalikan saat pemanggil meminta laporan di luar
+// scope-nya.
+var ErrReportAccessDenied = errors.New("access denied")
+
+// ErrInvalidReportRole dikembalikan saat role pemanggil tidak punya tampilan
+// statistik.
+var ErrInvalidReportRole = errors.New("invalid role")
+
 type reportService struct {
 	reportRepo  repository.ReportRepository
 	studentRepo repository.StudentLecturerRepository
@@ -40,17 +48,21 @@ func (s *reportService) GetStatistics(
 ) (*models.AchievementStatisticsResponse, error) { // Tambah prefix models.
 
 	// FR-011: Admin → Full Access [cite: 30, 253]
-	if role == "Admin" {
+	// Service account (dashboard fakultas, SIAKAD) dibatasi lewat scope
+	// API key, bukan lewat role, sehingga melihat statistik global.
+	if role == models.RoleAdmin || role == models.RoleServiceAccount {
 		return s.getGlobalStatistics(ctx, start, end)
 	}
 
 	// FR-011: Mahasiswa → Only Own [cite: 30, 253]
-	if role == "Mahasiswa" {
-		// Pastikan method ini ada di repository.StudentLecturerRepository
-		student, err := s.studentRepo.GetStudentByID(ctx, userID)
+	if role == models.RoleMahasiswa {
+		student, err := s.studentRepo.GetStudentByUserID(ctx, userID)
 		if err != nil {
 			return nil, err
 		}
+		if student == nil {
+			return nil, ErrReportAccessDenied
+		}
 
 		point, err := s.reportRepo.GetStudentTotalPoint(ctx, student.ID)
 		if err != nil {
@@ -70,7 +82,7 @@ func (s *reportService) GetStatistics(
 
 	// FR-011: Dosen Wali → Advisees [cite: 30, 253]
 	// FR-011: Dosen Wali → Advisees [cite: 253]
-	if role == "Dosen Wali" {
+	if role == models.RoleDosenWali {
 		// 1. Kita harus tau dulu lecturerID si dosen ini dari userID-nya
 		// Pastikan lo punya fungsi GetLecturerByUserID di repository
 		lecturer, err := s.studentRepo.GetLecturerByUserID(ctx, userID)
@@ -107,7 +119,7 @@ func (s *reportService) GetStatistics(
 		}, nil
 	}
 
-	return nil, errors.New("invalid role")
+	return nil, ErrInvalidReportRole
 }
 
 func (s *reportService) getGlobalStatistics(
@@ -152,20 +164,31 @@ func (s *reportService) GetStudentReport(
 	studentID uuid.UUID,
 ) (*models.StudentReportResponse, error) { // Tambah prefix models.
 
+	switch requesterRole {
+	case models.RoleAdmin, models.RoleServiceAccount:
+
 	// Mahasiswa hanya boleh lihat milik sendiri [cite: 253]
-	if requesterRole == "Mahasiswa" {
-		student, err := s.studentRepo.GetStudentByID(ctx, requesterID)
-		if err != nil || student.ID != studentID {
-			return nil, errors.New("access denied")
+	case models.RoleMahasiswa:
+		student, err := s.studentRepo.GetStudentByUserID(ctx, requesterID)
+		if err != nil {
+			return nil, err
+		}
+		if student == nil || student.ID != studentID {
+			return nil, ErrReportAccessDenied
 		}
-	}
 
 	// Dosen wali harus pembimbingnya [cite: 208, 253]
-	if requesterRole == "Dosen Wali" {
+	case models.RoleDosenWali:
 		ok, err := s.studentRepo.IsAdvisorOf(ctx, requesterID, studentID)
-		if err != nil || !ok {
-			return nil, errors.New("access denied")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrReportAccessDenied
 		}
+
+	default:
+		return nil, ErrReportAccessDenied
 	}
 
 	point, err := s.reportRepo.GetStudentTotalPoint(ctx, studentID)
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+// reportFixture berisi dua mahasiswa; hanya student dibimbing advisor.
+type reportFixture struct {
+	svc ReportService
+
+	student, otherStudent uuid.UUID
+	studentUser, advisor  uuid.UUID
+}
+
+func newReportFixture() *reportFixture {
+	f := &reportFixture{
+		student:      uuid.New(),
+		otherStudent: uuid.New(),
+		studentUser:  uuid.New(),
+		advisor:      uuid.New(),
+	}
+	lecturer := uuid.New()
+
+	students := fakeReportStudents{
+		fakeAdvisors: fakeAdvisors{advisees: map[uuid.UUID]map[uuid.UUID]bool{
+			f.advisor: {f.student: true},
+		}},
+		byUserID: map[uuid.UUID]models.StudentDetailResponse{
+			f.studentUser: {ID: f.student, UserID: f.studentUser, FullName: "Budi"},
+		},
+		lecturers: map[uuid.UUID]models.LecturerDetailResponse{
+			f.advisor: {ID: lecturer, UserID: f.advisor},
+		},
+		advisees: map[uuid.UUID][]models.StudentDetailResponse{
+			lecturer: {{ID: f.student, FullName: "Budi"}},
+		},
+	}
+	f.svc = NewReportService(fakeReports{points: map[uuid.UUID]float64{f.student: 40, f.otherStudent: 25}}, students)
+	return f
+}
+
+func TestGetStudentReportScope(t *testing.T) {
+	f := newReportFixture()
+
+	tests := []struct {
+		name   string
+		role   string
+		userID uuid.UUID
+		target uuid.UUID
+		want   error
+	}{
+		{"student own report", models.RoleMahasiswa, f.studentUser, f.student, nil},
+		{"student other report", models.RoleMahasiswa, f.studentUser, f.otherStudent, ErrReportAccessDenied},
+		{"user without student profile", models.RoleMahasiswa, uuid.New(), f.student, ErrReportAccessDenied},
+		{"advisor of student", models.RoleDosenWali, f.advisor, f.student, nil},
+		{"advisor of other student", models.RoleDosenWali, f.advisor, f.otherStudent, ErrReportAccessDenied},
+		{"admin", models.RoleAdmin, uuid.New(), f.otherStudent, nil},
+		{"service account", models.RoleServiceAccount, uuid.New(), f.otherStudent, nil},
+		{"other role", "Kaprodi", uuid.New(), f.student, ErrReportAccessDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := f.svc.GetStudentReport(context.Background(), tt.role, tt.userID, tt.target)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if err == nil && report.StudentID != tt.target {
+				t.Errorf("report student = %s, want %s", report.StudentID, tt.target)
+			}
+		})
+	}
+}
+
+func TestGetStatisticsByRole(t *testing.T) {
+	f := newReportFixture()
+	ctx := context.Background()
+	end := time.Now()
+
+	t.Run("student sees own points", func(t *testing.T) {
+		stats, err := f.svc.GetStatistics(ctx, models.RoleMahasiswa, f.studentUser, time.Time{}, end)
+		if err != nil {
+			t.Fatalf("GetStatistics: %v", err)
+		}
+		if len(stats.TopStudents) != 1 || stats.TopStudents[0].StudentID != f.student || stats.TopStudents[0].TotalPoint != 40 {
+			t.Errorf("top students = %+v, want only %s with 40 points", stats.TopStudents, f.student)
+		}
+	})
+
+	t.Run("user without student profile", func(t *testing.T) {
+		_, err := f.svc.GetStatistics(ctx, models.RoleMahasiswa, uuid.New(), time.Time{}, end)
+		if !errors.Is(err, ErrReportAccessDenied) {
+			t.Fatalf("err = %v, want %v", err, ErrReportAccessDenied)
+		}
+	})
+
+	t.Run("advisor sees advisees", func(t *testing.T) {
+		stats, err := f.svc.GetStatistics(ctx, models.RoleDosenWali, f.advisor, time.Time{}, end)
+		if err != nil {
+			t.Fatalf("GetStatistics: %v", err)
+		}
+		if len(stats.TopStudents) != 1 || stats.TopStudents[0].StudentID != f.student {
+			t.Errorf("top students = %+v, want only advisee %s", stats.TopStudents, f.student)
+		}
+	})
+
+	for _, role := range []string{models.RoleAdmin, models.RoleServiceAccount} {
+		t.Run(role+" sees global statistics", func(t *testing.T) {
+			stats, err := f.svc.GetStatistics(ctx, role, uuid.New(), time.Time{}, end)
+			if err != nil {
+				t.Fatalf("GetStatistics: %v", err)
+			}
+			if len(stats.TopStudents) != 2 {
+				t.Errorf("top students = %+v, want both students", stats.TopStudents)
+			}
+		})
+	}
+
+	t.Run("other role", func(t *testing.T) {
+		_, err := f.svc.GetStatistics(ctx, "Kaprodi", uuid.New(), time.Time{}, end)
+		if !errors.Is(err, ErrInvalidReportRole) {
+			t.Fatalf("err = %v, want %v", err, ErrInvalidReportRole)
+		}
+	})
+}
+
+// fakeReportStudents melengkapi fakeAdvisors dengan profil mahasiswa per
+// user dan mahasiswa bimbingan per dosen.
+type fakeReportStudents struct {
+	fakeAdvisors
+
+	byUserID  map[uuid.UUID]models.StudentDetailResponse
+	lecturers map[uuid.UUID]models.LecturerDetailResponse
+	advisees  map[uuid.UUID][]models.StudentDetailResponse
+}
+
+func (r fakeReportStudents) GetStudentByUserID(ctx context.Context, userID uuid.UUID) (*models.StudentDetailResponse, error) {
+	student, ok := r.byUserID[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &student, nil
+}
+
+func (r fakeReportStudents) GetLecturerByUserID(ctx context.Context, userID uuid.UUID) (*models.LecturerDetailResponse, error) {
+	lecturer, ok := r.lecturers[userID]
+	if !ok {
+		return nil, errors.New("lecturer not found")
+	}
+	return &lecturer, nil
+}
+
+func (r fakeReportStudents) GetLecturerAdvisees(ctx context.Context, lecturerID uuid.UUID) ([]models.StudentDetailResponse, error) {
+	return r.advisees[lecturerID], nil
+}
+
+// fakeReports menghitung statistik dari total poin per mahasiswa.
+type fakeReports struct {
+	repository.ReportRepository
+
+	points map[uuid.UUID]float64
+}
+
+func (r fakeReports) GetStudentTotalPoint(ctx context.Context, studentID uuid.UUID) (float64, error) {
+	return r.points[studentID], nil
+}
+
+func (r fakeReports) GetAchievementCountByType(ctx context.Context) ([]models.AchievementTypeStat, error) {
+	return nil, nil
+}
+
+func (r fakeReports) GetAchievementCountByPeriod(ctx context.Context, start time.Time, end time.Time) ([]models.AchievementPeriodStat, error) {
+	return nil, nil
+}
+
+func (r fakeReports) GetTopStudents(ctx context.Context, limit int) ([]models.TopStudentStat, error) {
+	top := make([]models.TopStudentStat, 0, len(r.points))
+	for id, point := range r.points {
+		top = append(top, models.TopStudentStat{StudentID: id, TotalPoint: point})
+	}
+	return top, nil
+}
+
+func (r fakeReports) GetCompetitionLevelDistribution(ctx context.Context) ([]models.CompetitionLevelStat, error) {
+	return nil, nil
+}
+
+func (r fakeReports) GetCountByTypeFiltered(ctx context.Context, studentIDs []uuid.UUID) ([]models.AchievementTypeStat, error) {
+	return nil, nil
+}
+
+func (r fakeReports) GetLevelDistributionFiltered(ctx context.Context, studentIDs []uuid.UUID) ([]models.CompetitionLevelStat, error) {
+	return nil, nil
+}
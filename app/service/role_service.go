@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+type RoleService interface {
+	GetAllRoles(ctx context.Context) ([]models.RoleResponse, error)
+	GetRoleByID(ctx context.Context, id uuid.UUID) (*models.RoleResponse, error)
+	CreateRole(ctx context.Context, req *models.CreateRoleRequest) (*models.RoleResponse, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, req *models.UpdateRoleRequest) (*models.RoleResponse, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	AssignPermissions(ctx context.Context, id uuid.UUID, req *models.AssignPermissionsRequest) (*models.RoleResponse, error)
+}
+
+type roleService struct {
+	roleRepo       repository.RoleRepository
+	permissionRepo repository.PermissionRepository
+	authz          AuthzService
+}
+
+func NewRoleService(
+	roleRepo repository.RoleRepository,
+	permissionRepo repository.PermissionRepository,
+	authz AuthzService,
+) RoleService {
+	return &roleService{
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+		authz:          authz,
+	}
+}
+
+func (s *roleService) GetAllRoles(ctx context.Context) ([]models.RoleResponse, error) {
+	roles, err := s.roleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.RoleResponse{}
+	for i := range roles {
+		resp, err := s.mapToResponse(ctx, &roles[i])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *resp)
+	}
+	return responses, nil
+}
+
+func (s *roleService) GetRoleByID(ctx context.Context, id uuid.UUID) (*models.RoleResponse, error) {
+	role, err := s.findRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapToResponse(ctx, role)
+}
+
+func (s *roleService) CreateRole(ctx context.Context, req *models.CreateRoleRequest) (*models.RoleResponse, error) {
+	name := strings.TrimSpace(req.Name)
+
+	existing, err := s.roleRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("role name already exists")
+	}
+
+	role := &models.Role{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: req.Description,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return s.mapToResponse(ctx, role)
+}
+
+func (s *roleService) UpdateRole(ctx context.Context, id uuid.UUID, req *models.UpdateRoleRequest) (*models.RoleResponse, error) {
+	role, err := s.findRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name != role.Name {
+		if isBuiltInRole(role.Name) {
+			return nil, errors.New("built-in role cannot be renamed")
+		}
+
+		existing, err := s.roleRepo.FindByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && existing.ID != role.ID {
+			return nil, errors.New("role name already exists")
+		}
+	}
+
+	role.Name = name
+	role.Description = req.Description
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+	s.authz.InvalidateAll()
+
+	return s.mapToResponse(ctx, role)
+}
+
+func (s *roleService) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	role, err := s.findRole(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if isBuiltInRole(role.Name) {
+		return errors.New("built-in role cannot be deleted")
+	}
+
+	count, err := s.roleRepo.CountUsers(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("role is still assigned to users")
+	}
+
+	if err := s.roleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.authz.InvalidateAll()
+	return nil
+}
+
+// AssignPermissions mengganti seluruh permission role dengan daftar yang
+// diberikan. Daftar kosong berarti mencabut semua permission.
+func (s *roleService) AssignPermissions(
+	ctx context.Context,
+	id uuid.UUID,
+	req *models.AssignPermissionsRequest,
+) (*models.RoleResponse, error) {
+
+	role, err := s.findRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := uniqueUUIDs(req.PermissionIDs)
+	if len(ids) > 0 {
+		count, err := s.permissionRepo.CountExisting(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		if count != len(ids) {
+			return nil, errors.New("one or more permissions not found")
+		}
+	}
+
+	if err := s.roleRepo.ReplacePermissions(ctx, id, ids); err != nil {
+		return nil, err
+	}
+	s.authz.InvalidateAll()
+
+	return s.mapToResponse(ctx, role)
+}
+
+func (s *roleService) findRole(ctx context.Context, id uuid.UUID) (*models.Role, error) {
+	role, err := s.roleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, errors.New("role not found")
+	}
+	return role, nil
+}
+
+func (s *roleService) mapToResponse(ctx context.Context, role *models.Role) (*models.RoleResponse, error) {
+	permissions, err := s.roleRepo.GetPermissions(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.roleRepo.CountUsers(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		UserCount:   count,
+		CreatedAt:   role.CreatedAt,
+	}, nil
+}
+
+func isBuiltInRole(name string) bool {
+	switch name {
+	case models.RoleAdmin, models.RoleMahasiswa, models.RoleDosenWali:
+		return true
+	}
+	return false
+}
+
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
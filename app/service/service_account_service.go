@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/app/models"
+	"backend/app/repository"
+	"backend/app/utils"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyTouchInterval membatasi seberapa sering last_used_at ditulis.
+const apiKeyTouchInterval = time.Minute
+
+var errInvalidAPIKey = errors.New("invalid or expired api key")
+
+// ServiceAccountService mengelola service account untuk klien mesin dan
+// API key-nya. Setiap key membawa daftar permission sendiri; role user tidak
+// berlaku untuk service account.
+type ServiceAccountService interface {
+	ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error)
+	GetServiceAccount(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error)
+	CreateServiceAccount(ctx context.Context, req *models.CreateServiceAccountRequest, adminID uuid.UUID) (*models.ServiceAccount, error)
+	UpdateServiceAccount(ctx context.Context, id uuid.UUID, req *models.UpdateServiceAccountRequest) (*models.ServiceAccount, error)
+	DeleteServiceAccount(ctx context.Context, id uuid.UUID) error
+
+	ListAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]models.APIKey, error)
+	// CreateAPIKey mengembalikan key utuh; key hanya disimpan sebagai hash
+	// sehingga tidak bisa ditampilkan lagi.
+	CreateAPIKey(ctx context.Context, serviceAccountID uuid.UUID, req *models.CreateAPIKeyRequest, adminID uuid.UUID) (string, *models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, serviceAccountID, keyID, adminID uuid.UUID) error
+
+	// Authenticate memeriksa key dari header request dan mengembalikan
+	// claims sintetis untuk middleware.
+	Authenticate(ctx context.Context, rawKey string, ip string) (*models.JWTClaims, error)
+}
+
+type serviceAccountService struct {
+	repo           repository.ServiceAccountRepository
+	permissionRepo repository.PermissionRepository
+	securityEvents repository.SecurityEventRepository
+}
+
+func NewServiceAccountService(
+	repo repository.ServiceAccountRepository,
+	permissionRepo repository.PermissionRepository,
+	securityEvents repository.SecurityEventRepository,
+) ServiceAccountService {
+	return &serviceAccountService{
+		repo:           repo,
+		permissionRepo: permissionRepo,
+		securityEvents: securityEvents,
+	}
+}
+
+func (s *serviceAccountService) ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *serviceAccountService) GetServiceAccount(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error) {
+	account, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("service account not found")
+	}
+	return account, nil
+}
+
+func (s *serviceAccountService) CreateServiceAccount(
+	ctx context.Context,
+	req *models.CreateServiceAccountRequest,
+	adminID uuid.UUID,
+) (*models.ServiceAccount, error) {
+
+	name := strings.TrimSpace(req.Name)
+	existing, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("service account name already exists")
+	}
+
+	now := time.Now()
+	account := &models.ServiceAccount{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		IsActive:    true,
+		CreatedBy:   &adminID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (s *serviceAccountService) UpdateServiceAccount(
+	ctx context.Context,
+	id uuid.UUID,
+	req *models.UpdateServiceAccountRequest,
+) (*models.ServiceAccount, error) {
+
+	account, err := s.GetServiceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		account.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.IsActive != nil {
+		account.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+	return s.GetServiceAccount(ctx, id)
+}
+
+func (s *serviceAccountService) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetServiceAccount(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *serviceAccountService) ListAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]models.APIKey, error) {
+	if _, err := s.GetServiceAccount(ctx, serviceAccountID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListKeys(ctx, serviceAccountID)
+}
+
+func (s *serviceAccountService) CreateAPIKey(
+	ctx context.Context,
+	serviceAccountID uuid.UUID,
+	req *models.CreateAPIKeyRequest,
+	adminID uuid.UUID,
+) (string, *models.APIKey, error) {
+
+	account, err := s.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return "", nil, err
+	}
+	if !account.IsActive {
+		return "", nil, errors.New("service account is inactive")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return "", nil, errors.New("expiresAt must be in the future")
+	}
+
+	permissions, err := s.validatePermissions(ctx, req.Permissions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	prefix, rawKey, err := generateAPIKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := &models.APIKey{
+		ID:               uuid.New(),
+		ServiceAccountID: account.ID,
+		Name:             strings.TrimSpace(req.Name),
+		Prefix:           prefix,
+		KeyHash:          utils.HashToken(rawKey),
+		Permissions:      permissions,
+		ExpiresAt:        req.ExpiresAt,
+		CreatedBy:        &adminID,
+		CreatedAt:        time.Now(),
+	}
+	if err := s.repo.CreateKey(ctx, key); err != nil {
+		return "", nil, err
+	}
+
+	if err := s.recordKeyEvent(ctx, adminID, models.SecurityEventAPIKeyCreated, account, key); err != nil {
+		return "", nil, err
+	}
+
+	return rawKey, key, nil
+}
+
+func (s *serviceAccountService) RevokeAPIKey(ctx context.Context, serviceAccountID, keyID, adminID uuid.UUID) error {
+	account, err := s.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return err
+	}
+
+	key, err := s.repo.FindKey(ctx, serviceAccountID, keyID)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return errors.New("api key not found")
+	}
+
+	revoked, err := s.repo.RevokeKey(ctx, keyID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("api key already revoked")
+	}
+
+	return s.recordKeyEvent(ctx, adminID, models.SecurityEventAPIKeyRevoked, account, key)
+}
+
+func (s *serviceAccountService) Authenticate(ctx context.Context, rawKey string, ip string) (*models.JWTClaims, error) {
+	prefix, ok := parseAPIKeyPrefix(rawKey)
+	if !ok {
+		return nil, errInvalidAPIKey
+	}
+
+	key, err := s.repo.FindKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, errInvalidAPIKey
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(utils.HashToken(rawKey))) != 1 {
+		return nil, errInvalidAPIKey
+	}
+	if key.RevokedAt != nil || !key.ServiceAccountActive {
+		return nil, errInvalidAPIKey
+	}
+	if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+		return nil, errInvalidAPIKey
+	}
+
+	if err := s.repo.TouchKey(ctx, key.ID, ip, apiKeyTouchInterval); err != nil {
+		return nil, err
+	}
+
+	// Key lama bisa memuat permission di luar APIKeyScopes; yang tidak
+	// diizinkan dibuang.
+	permissions := []string{}
+	for _, p := range key.Permissions {
+		if models.APIKeyScopes[p] {
+			permissions = append(permissions, p)
+		}
+	}
+
+	return &models.JWTClaims{
+		UserID:      key.ServiceAccountID,
+		Username:    key.ServiceAccountName,
+		Role:        models.RoleServiceAccount,
+		Permissions: permissions,
+		APIKeyID:    &key.ID,
+	}, nil
+}
+
+// validatePermissions memastikan setiap permission ada di tabel permissions,
+// termasuk scope yang boleh dipakai API key, dan membuang duplikat.
+func (s *serviceAccountService) validatePermissions(ctx context.Context, names []string) ([]string, error) {
+	seen := map[string]bool{}
+	permissions := []string{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if !models.APIKeyScopes[name] {
+			return nil, errors.New("permission not allowed for api keys")
+		}
+
+		p, err := s.permissionRepo.FindByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			return nil, errors.New("one or more permissions not found")
+		}
+		permissions = append(permissions, name)
+	}
+	return permissions, nil
+}
+
+// Event dicatat atas nama admin karena security_events merujuk ke users.
+func (s *serviceAccountService) recordKeyEvent(
+	ctx context.Context,
+	adminID uuid.UUID,
+	eventType string,
+	account *models.ServiceAccount,
+	key *models.APIKey,
+) error {
+	return s.securityEvents.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &adminID,
+		EventType: eventType,
+		Detail:    "service account " + account.Name + ", key " + key.Prefix + " (" + strings.Join(key.Permissions, ", ") + ")",
+		CreatedAt: time.Now(),
+	})
+}
+
+// generateAPIKey menghasilkan key "spp_<prefix>_<rahasia>". Prefix dipakai
+// untuk lookup dan boleh ditampilkan; hanya hash dari key utuh yang disimpan.
+func generateAPIKey() (string, string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix := hex.EncodeToString(b)
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	return prefix, models.APIKeyPrefix + prefix + "_" + secret, nil
+}
+
+func parseAPIKeyPrefix(rawKey string) (string, bool) {
+	if !strings.HasPrefix(rawKey, models.APIKeyPrefix) {
+		return "", false
+	}
+	rest := strings.TrimPrefix(rawKey, models.APIKeyPrefix)
+	i := strings.Index(rest, "_")
+	if i <= 0 || i == len(rest)-1 {
+		return "", false
+	}
+	return rest[:i], true
+}
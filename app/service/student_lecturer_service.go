@@ -13,12 +13,15 @@ import (
 type StudentLecturerService interface {
 	GetStudents(ctx context.Context) ([]models.StudentDetailResponse, error)
 	GetStudentDetail(ctx context.Context, studentID uuid.UUID) (*models.StudentDetailResponse, error)
-	GetStudentAchievements(ctx context.Context, studentID uuid.UUID) ([]models.StudentAchievementResponse, error)
+	GetStudentAchievements(ctx context.Context, studentID uuid.UUID, actor models.AchievementActor) ([]models.StudentAchievementResponse, error)
 	UpdateAdvisor(ctx context.Context, studentID uuid.UUID, advisorID *uuid.UUID) error
 
 	GetLecturers(ctx context.Context) ([]models.LecturerDetailResponse, error)
 	GetLecturerAdvisees(ctx context.Context, lecturerID uuid.UUID) ([]models.StudentDetailResponse, error)
 }
+
+var errStudentNotFound = errors.New("student not found")
+
 type studentLecturerService struct {
 	repo repository.StudentLecturerRepository
 }
@@ -53,17 +56,43 @@ func (s *studentLecturerService) GetStudentDetail(
 	return student, nil
 }
 
+// GetStudentAchievements mengembalikan prestasi seorang mahasiswa. Scope
+// pemanggil sama dengan akses prestasi: mahasiswa hanya miliknya sendiri,
+// dosen wali hanya mahasiswa bimbingannya, Admin semua, dan service account
+// dibatasi lewat scope API key. Di luar scope mahasiswa dianggap tidak ada.
 func (s *studentLecturerService) GetStudentAchievements(
 	ctx context.Context,
 	studentID uuid.UUID,
+	actor models.AchievementActor,
 ) ([]models.StudentAchievementResponse, error) {
 
+	switch actor.Role {
+	case models.RoleAdmin, models.RoleServiceAccount:
+
+	case models.RoleMahasiswa:
+		if actor.StudentID == nil || *actor.StudentID != studentID {
+			return nil, errStudentNotFound
+		}
+
+	case models.RoleDosenWali:
+		ok, err := s.repo.IsAdvisorOf(ctx, actor.UserID, studentID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errStudentNotFound
+		}
+
+	default:
+		return nil, errStudentNotFound
+	}
+
 	exists, err := s.repo.GetStudentByID(ctx, studentID)
 	if err != nil {
 		return nil, err
 	}
 	if exists == nil {
-		return nil, errors.New("student not found")
+		return nil, errStudentNotFound
 	}
 
 	return s.repo.GetStudentAchievements(ctx, studentID)
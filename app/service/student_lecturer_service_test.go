@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+)
+
+// fakeStudents melengkapi fakeAdvisors dengan data mahasiswa dan
+// prestasinya.
+type fakeStudents struct {
+	fakeAdvisors
+
+	achievements map[uuid.UUID][]models.StudentAchievementResponse
+}
+
+func (r fakeStudents) GetStudentByID(ctx context.Context, id uuid.UUID) (*models.StudentDetailResponse, error) {
+	if _, ok := r.achievements[id]; !ok {
+		return nil, nil
+	}
+	return &models.StudentDetailResponse{ID: id}, nil
+}
+
+func (r fakeStudents) GetStudentAchievements(ctx context.Context, studentID uuid.UUID) ([]models.StudentAchievementResponse, error) {
+	return r.achievements[studentID], nil
+}
+
+func TestGetStudentAchievementsScope(t *testing.T) {
+	student, otherStudent := uuid.New(), uuid.New()
+	advisor := uuid.New()
+
+	svc := NewStudentLecturerService(fakeStudents{
+		fakeAdvisors: fakeAdvisors{advisees: map[uuid.UUID]map[uuid.UUID]bool{
+			advisor: {student: true},
+		}},
+		achievements: map[uuid.UUID][]models.StudentAchievementResponse{
+			student:      {{}},
+			otherStudent: {{}},
+		},
+	})
+
+	tests := []struct {
+		name  string
+		actor models.AchievementActor
+		want  error
+	}{
+		{"owner", models.AchievementActor{UserID: uuid.New(), Role: models.RoleMahasiswa, StudentID: &student}, nil},
+		{"other student", models.AchievementActor{UserID: uuid.New(), Role: models.RoleMahasiswa, StudentID: &otherStudent}, errStudentNotFound},
+		{"student without profile", models.AchievementActor{UserID: uuid.New(), Role: models.RoleMahasiswa}, errStudentNotFound},
+		{"advisor", models.AchievementActor{UserID: advisor, Role: models.RoleDosenWali}, nil},
+		{"non-advisor", models.AchievementActor{UserID: uuid.New(), Role: models.RoleDosenWali}, errStudentNotFound},
+		{"admin", models.AchievementActor{UserID: uuid.New(), Role: models.RoleAdmin}, nil},
+		{"service account", models.AchievementActor{UserID: uuid.New(), Role: models.RoleServiceAccount}, nil},
+		{"other role", models.AchievementActor{UserID: uuid.New(), Role: "Kaprodi"}, errStudentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.GetStudentAchievements(context.Background(), student, tt.actor)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if err == nil && len(got) != 1 {
+				t.Errorf("got %d achievements, want 1", len(got))
+			}
+		})
+	}
+}
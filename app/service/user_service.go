@@ -7,9 +7,9 @@ import (
 
 	"backend/app/models"
 	"backend/app/repository"
+	"backend/app/utils"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type UserService interface {
@@ -19,15 +19,31 @@ type UserService interface {
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	GetAllUsers(ctx context.Context, page, limit int) ([]models.UserResponse, error)
 	UpdateUserRole(ctx context.Context, id uuid.UUID, req *models.UpdateUserRoleRequest) error
+	UnlockUser(ctx context.Context, id uuid.UUID, adminID uuid.UUID) error
+	GetSecurityEvents(ctx context.Context, id uuid.UUID, page, limit int) ([]models.SecurityEvent, error)
 }
 
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo          repository.UserRepository
+	securityEventRepo repository.SecurityEventRepository
+	passwordRepo      repository.PasswordRepository
+	passwords         PasswordPolicyService
+	authz             AuthzService
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
+func NewUserService(
+	userRepo repository.UserRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	passwordRepo repository.PasswordRepository,
+	passwords PasswordPolicyService,
+	authz AuthzService,
+) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:          userRepo,
+		securityEventRepo: securityEventRepo,
+		passwordRepo:      passwordRepo,
+		passwords:         passwords,
+		authz:             authz,
 	}
 }
 
@@ -36,10 +52,14 @@ func (s *userService) CreateUser(
 	req *models.CreateUserRequest,
 ) (*models.UserResponse, error) {
 
-	hashedPassword, _ := bcrypt.GenerateFromPassword(
-		[]byte(req.Password),
-		bcrypt.DefaultCost,
-	)
+	if err := s.passwords.Check(ctx, uuid.Nil, req.Password, req.Username, req.Email, req.StudentID, req.LecturerID); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
 
 	userID := uuid.New()
 
@@ -53,7 +73,7 @@ func (s *userService) CreateUser(
 		ID:           userID,
 		Username:     req.Username,
 		Email:        req.Email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		FullName:     req.FullName,
 		RoleID:       req.RoleID,
 		IsActive:     true,
@@ -125,6 +145,7 @@ func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, req *models.
 		return err
 	}
 
+	wasActive := user.IsActive
 	if req.FullName != nil {
 		user.FullName = *req.FullName
 	}
@@ -141,13 +162,106 @@ func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, req *models.
 		user.IsActive = *req.IsActive
 	}
 
+	if err := s.ensureNotLastAdmin(ctx, user, wasActive); err != nil {
+		return err
+	}
+
+	// Username dan email baru sudah diterapkan ke user di atas, jadi password
+	// baru diperiksa terhadap nilai baru; nilai lama diambil policy dari
+	// database.
+	var hashedPassword string
+	if req.Password != nil {
+		if err := s.passwords.Check(ctx, id, *req.Password, user.Username, user.Email); err != nil {
+			return err
+		}
+		if hashedPassword, err = utils.HashPassword(*req.Password); err != nil {
+			return err
+		}
+	}
+
 	user.UpdatedAt = time.Now()
 
-	return s.userRepo.Update(ctx, user)
+	// Profil dan password ditulis dalam satu transaksi; password yang
+	// diganti admin sekaligus mencabut semua sesi user.
+	tx, err := s.userRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+		return err
+	}
+	if hashedPassword != "" {
+		if err := s.passwordRepo.ReplacePasswordTx(ctx, tx, id, hashedPassword); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if hashedPassword != "" {
+		if err := s.securityEventRepo.Create(ctx, models.SecurityEvent{
+			ID:        uuid.New(),
+			UserID:    &id,
+			EventType: models.SecurityEventPasswordChanged,
+			Detail:    "password changed by admin, all refresh tokens revoked",
+			CreatedAt: time.Now(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.authz.InvalidateUser(user.ID)
+	return nil
 }
 
 func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	return s.userRepo.Delete(ctx, id)
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	wasActive := user.IsActive
+	user.IsActive = false
+	if err := s.ensureNotLastAdmin(ctx, user, wasActive); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.authz.InvalidateUser(id)
+	return nil
+}
+
+// ensureNotLastAdmin menolak perubahan (ganti role, nonaktif, hapus) yang
+// membuat sistem tidak lagi memiliki Admin aktif. user berisi keadaan
+// setelah perubahan, sedangkan RoleName dan wasActive masih keadaan sebelum
+// perubahan; Admin yang sudah nonaktif tidak dihitung.
+func (s *userService) ensureNotLastAdmin(ctx context.Context, user *models.User, wasActive bool) error {
+	if user.RoleName != models.RoleAdmin || !wasActive {
+		return nil
+	}
+
+	newRole, err := s.userRepo.GetRoleNameByID(ctx, user.RoleID)
+	if err != nil {
+		return errors.New("role not found")
+	}
+	if newRole == models.RoleAdmin && user.IsActive {
+		return nil
+	}
+
+	count, err := s.userRepo.CountActiveByRoleName(ctx, models.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return errors.New("cannot remove the last active admin")
+	}
+	return nil
 }
 
 func (s *userService) mapToResponse(
@@ -168,6 +282,9 @@ func (s *userService) mapToResponse(
 		Role:        user.RoleName,
 		IsActive:    user.IsActive,
 		Permissions: perms,
+
+		FailedLoginAttempts: user.FailedLoginAttempts,
+		LockedUntil:         user.LockedUntil,
 	}, nil
 }
 
@@ -204,8 +321,56 @@ func (s *userService) UpdateUserRole(ctx context.Context, id uuid.UUID, req *mod
 		return err
 	}
 
+	wasActive := user.IsActive
 	user.RoleID = req.RoleID
+	if err := s.ensureNotLastAdmin(ctx, user, wasActive); err != nil {
+		return err
+	}
+
 	user.UpdatedAt = time.Now()
 
-	return s.userRepo.Update(ctx, user)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	s.authz.InvalidateUser(user.ID)
+	return nil
+}
+
+func (s *userService) UnlockUser(ctx context.Context, id uuid.UUID, adminID uuid.UUID) error {
+	if _, err := s.userRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Unlock(ctx, id); err != nil {
+		return err
+	}
+
+	return s.securityEventRepo.Create(ctx, models.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &id,
+		EventType: models.SecurityEventAccountUnlocked,
+		Detail:    "account unlocked by admin " + adminID.String(),
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *userService) GetSecurityEvents(
+	ctx context.Context,
+	id uuid.UUID,
+	page, limit int,
+) ([]models.SecurityEvent, error) {
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.securityEventRepo.FindByUserID(ctx, id, limit, (page-1)*limit)
 }
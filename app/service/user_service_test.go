@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"backend/app/models"
+	"backend/app/repository"
+
+	"github.com/google/uuid"
+)
+
+// fakeUserStore hanya menyediakan FindByID; UpdateUser yang ditolak policy
+// password tidak sampai menulis ke database.
+type fakeUserStore struct {
+	repository.UserRepository
+
+	user models.User
+}
+
+func (r fakeUserStore) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	user := r.user
+	return &user, nil
+}
+
+// fakePasswordStore mengembalikan identitas yang tersimpan di database.
+type fakePasswordStore struct {
+	repository.PasswordRepository
+
+	identifiers []string
+}
+
+func (r fakePasswordStore) GetIdentifiers(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return r.identifiers, nil
+}
+
+func (r fakePasswordStore) RecentHashes(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	return nil, nil
+}
+
+func TestUpdateUserChecksPasswordAgainstNewIdentity(t *testing.T) {
+	user := models.User{
+		ID:       uuid.New(),
+		Username: "budi",
+		Email:    "budi@kampus.ac.id",
+		RoleName: models.RoleMahasiswa,
+		IsActive: true,
+	}
+	passwords := fakePasswordStore{identifiers: []string{user.Username, user.Email}}
+	policy := NewPasswordPolicyService(passwords, models.PasswordPolicy{MinLength: 4})
+	svc := NewUserService(fakeUserStore{user: user}, nil, passwords, policy, nil)
+
+	tests := []struct {
+		name     string
+		username string
+		email    string
+		password string
+	}{
+		{"new username", "Budi.Santoso2024", "budi@kampus.ac.id", "budi.santoso2024"},
+		{"new email", "budi", "b.santoso@kampus.ac.id", "b.santoso"},
+		{"old username", "Budi.Santoso2024", "budi@kampus.ac.id", "Budi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.UpdateUser(context.Background(), user.ID, &models.UpdateUserRequest{
+				Username: &tt.username,
+				Email:    &tt.email,
+				Password: &tt.password,
+			})
+
+			var policyErr *PasswordPolicyError
+			if !errors.As(err, &policyErr) || !strings.Contains(policyErr.Error(), "same as your username") {
+				t.Fatalf("err = %v, want identity violation", err)
+			}
+		})
+	}
+}
@@ -2,8 +2,20 @@ package utils
 
 import "golang.org/x/crypto/bcrypt"
 
+// passwordCost adalah cost bcrypt untuk hash baru; diatur lewat
+// SetPasswordCost saat startup (BCRYPT_COST).
+var passwordCost = bcrypt.DefaultCost
+
+// SetPasswordCost mengganti cost bcrypt. Nilai di luar rentang bcrypt
+// diabaikan.
+func SetPasswordCost(cost int) {
+	if cost >= bcrypt.MinCost && cost <= bcrypt.MaxCost {
+		passwordCost = cost
+	}
+}
+
 func HashPassword(password string) (string, error) {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
 	if err != nil {
 		return "", err
 	}
@@ -13,3 +25,13 @@ func HashPassword(password string) (string, error) {
 func CheckPasswordHash(hash, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
+
+// PasswordNeedsRehash bernilai true jika hash dibuat dengan cost lebih
+// rendah dari pengaturan saat ini atau bukan hash bcrypt yang dikenali.
+func PasswordNeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return cost < passwordCost
+}
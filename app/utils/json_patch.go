@@ -0,0 +1,317 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrPatchTestFailed dikembalikan saat operasi "test" JSON Patch tidak
+// cocok dengan dokumen.
+var ErrPatchTestFailed = errors.New("patch test operation failed")
+
+// PatchOperation adalah satu operasi JSON Patch (RFC 6902). Value disimpan
+// mentah agar "value": null bisa dibedakan dari value yang tidak dikirim.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MergePatch menerapkan JSON Merge Patch (RFC 7396) pada dokumen hasil
+// json.Unmarshal ke interface{}: null menghapus key, objek digabung
+// rekursif, nilai lain mengganti.
+func MergePatch(doc, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	docObj, ok := doc.(map[string]interface{})
+	if !ok {
+		docObj = map[string]interface{}{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(docObj, key)
+			continue
+		}
+		docObj[key] = MergePatch(docObj[key], value)
+	}
+	return docObj
+}
+
+// ApplyPatch menerapkan operasi JSON Patch (RFC 6902) secara berurutan pada
+// dokumen hasil json.Unmarshal ke interface{}. Jika satu operasi gagal,
+// error dikembalikan dan hasil harus dibuang.
+func ApplyPatch(doc interface{}, ops []PatchOperation) (interface{}, error) {
+	for i, op := range ops {
+		var err error
+		doc, err = applyOperation(doc, op)
+		if err != nil {
+			if errors.Is(err, ErrPatchTestFailed) {
+				return nil, fmt.Errorf("%w: operation %d (%s)", ErrPatchTestFailed, i, op.Path)
+			}
+			return nil, fmt.Errorf("invalid patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func applyOperation(doc interface{}, op PatchOperation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := func() (interface{}, error) {
+		if len(op.Value) == 0 {
+			return nil, errors.New("missing value")
+		}
+		var v interface{}
+		err := json.Unmarshal(op.Value, &v)
+		return v, err
+	}
+
+	switch op.Op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+
+	case "remove":
+		doc, _, err := removeValue(doc, path)
+		return doc, err
+
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return v, nil
+		}
+		if doc, _, err = removeValue(doc, path); err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+
+	case "move":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		doc, v, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+
+	case "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		v, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, deepCopy(v))
+
+	case "test":
+		want, err := value()
+		if err != nil {
+			return nil, err
+		}
+		got, err := getValue(doc, path)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			return nil, ErrPatchTestFailed
+		}
+		return doc, nil
+	}
+
+	return nil, errors.New("unknown op")
+}
+
+// parsePointer mengurai JSON Pointer (RFC 6901); "" berarti seluruh dokumen.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("path must start with /")
+	}
+
+	parts := strings.Split(pointer[1:], "/")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+	return parts, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if idx > max {
+		return 0, fmt.Errorf("array index %d out of range", idx)
+	}
+	return idx, nil
+}
+
+func getValue(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			v, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path not found at %q", token)
+			}
+			doc = v
+		case []interface{}:
+			idx, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[idx]
+		default:
+			return nil, fmt.Errorf("path not found at %q", token)
+		}
+	}
+	return doc, nil
+}
+
+// addValue mengembalikan dokumen baru karena menyisipkan ke array bisa
+// mengganti slice induknya.
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parentPath, last := path[:len(path)-1], path[len(path)-1]
+	parent, err := getValue(doc, parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node), true)
+		if err != nil {
+			return nil, err
+		}
+		node = append(node, nil)
+		copy(node[idx+1:], node[idx:])
+		node[idx] = value
+		return setValue(doc, parentPath, node)
+	}
+	return nil, errors.New("parent is not an object or array")
+}
+
+// removeValue menghapus nilai di path dan mengembalikan dokumen baru
+// beserta nilai yang dihapus.
+func removeValue(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+
+	parentPath, last := path[:len(path)-1], path[len(path)-1]
+	parent, err := getValue(doc, parentPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		v, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path not found at %q", last)
+		}
+		delete(node, last)
+		return doc, v, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		v := node[idx]
+		node = append(node[:idx:idx], node[idx+1:]...)
+		doc, err = setValue(doc, parentPath, node)
+		return doc, v, err
+	}
+	return nil, nil, errors.New("parent is not an object or array")
+}
+
+func setValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		node[idx] = value
+	}
+	return doc, nil
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(node))
+		for k, child := range node {
+			out[k] = deepCopy(child)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(node))
+		for i, child := range node {
+			out[i] = deepCopy(child)
+		}
+		return out
+	}
+	return v
+}
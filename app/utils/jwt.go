@@ -3,7 +3,6 @@ package utils
 import (
 	"backend/app/models"
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -23,38 +22,24 @@ func GenerateToken(userID uuid.UUID, username string, roleName string) (string,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	secret := os.Getenv("JWT_SECRET")
-
-	if secret == "" {
-		return "", errors.New("JWT_SECRET environment variable is not set")
-	}
-
-	return token.SignedString([]byte(secret))
+	return GenerateTokenWithClaims(&claims)
 }
 
+// GenerateTokenWithClaims menandatangani claims dengan key aktif dan
+// menaruh kid-nya di header token.
 func GenerateTokenWithClaims(claims *models.JWTClaims) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", errors.New("JWT_SECRET not set")
+	key, err := activeJWTKey()
+	if err != nil {
+		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
 }
 
 func VerifyAccessToken(tokenString string) (*models.JWTClaims, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, errors.New("JWT_SECRET is not set")
-	}
-
-	token, err := jwt.ParseWithClaims(tokenString, &models.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &models.JWTClaims{}, JWTKeyFunc)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"backend/app/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key JWT dibaca dari satu direktori (JWT_KEY_DIR). Setiap file *.pem adalah
+// satu key dengan kid = nama file tanpa ekstensi:
+//
+//   - <kid>.pem     private key RSA (RS256) atau Ed25519 (EdDSA), PKCS#8/PKCS#1
+//   - <kid>.pub.pem public key saja, hanya untuk verifikasi
+//
+// Token ditandatangani dengan key aktif (JWT_ACTIVE_KID, atau private key
+// terbaru bila kosong), sedangkan verifikasi menerima semua key di
+// direktori. Rotasi: tambahkan key baru dan jadikan aktif, biarkan key lama
+// (boleh hanya public key-nya) sampai semua token lama kedaluwarsa, lalu
+// hapus.
+
+type jwtKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+	modTime time.Time
+}
+
+type jwtKeySet struct {
+	active *jwtKey
+	keys   map[string]*jwtKey
+}
+
+var loadedJWTKeys atomic.Pointer[jwtKeySet]
+
+// LoadJWTKeys membaca semua key dari dir. Jika dir belum berisi private key,
+// satu key RSA baru dibuat agar aplikasi tetap bisa berjalan secara lokal.
+func LoadJWTKeys(dir string, activeKID string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	set, err := readJWTKeys(dir)
+	if err != nil {
+		return err
+	}
+
+	if !set.hasPrivateKey() {
+		key, err := generateJWTKey(dir)
+		if err != nil {
+			return err
+		}
+		log.Println("🔑 No JWT signing key found, generated", filepath.Join(dir, key.kid+".pem"))
+		set.keys[key.kid] = key
+	}
+
+	if activeKID != "" {
+		key, ok := set.keys[activeKID]
+		if !ok || key.private == nil {
+			return fmt.Errorf("JWT_ACTIVE_KID %q has no private key in %s", activeKID, dir)
+		}
+		set.active = key
+	} else {
+		set.active = set.newestPrivateKey()
+	}
+
+	loadedJWTKeys.Store(set)
+	log.Printf("🔑 Loaded %d JWT key(s), signing with kid %q (%s)", len(set.keys), set.active.kid, set.active.method.Alg())
+	return nil
+}
+
+// JWTKeyFunc memilih public key berdasarkan header kid dan memastikan
+// algoritma token sesuai dengan jenis key.
+func JWTKeyFunc(token *jwt.Token) (interface{}, error) {
+	set := loadedJWTKeys.Load()
+	if set == nil {
+		return nil, errors.New("JWT keys are not loaded")
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := set.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown JWT kid %q", kid)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+
+	return key.public, nil
+}
+
+// JWKS mengembalikan semua public key dalam format JWK Set.
+func JWKS() models.JWKSet {
+	set := loadedJWTKeys.Load()
+	result := models.JWKSet{Keys: []models.JWK{}}
+	if set == nil {
+		return result
+	}
+
+	kids := make([]string, 0, len(set.keys))
+	for kid := range set.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	for _, kid := range kids {
+		key := set.keys[kid]
+		jwk := models.JWK{Use: "sig", Alg: key.method.Alg(), Kid: key.kid}
+
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+
+		result.Keys = append(result.Keys, jwk)
+	}
+
+	return result
+}
+
+func activeJWTKey() (*jwtKey, error) {
+	set := loadedJWTKeys.Load()
+	if set == nil || set.active == nil {
+		return nil, errors.New("JWT keys are not loaded")
+	}
+	return set.active, nil
+}
+
+func readJWTKeys(dir string) (*jwtKeySet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &jwtKeySet{keys: map[string]*jwtKey{}}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".pem") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		key, err := parseJWTKeyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		// Private key menang bila <kid>.pem dan <kid>.pub.pem sama-sama ada.
+		if existing, ok := set.keys[key.kid]; ok && existing.private != nil {
+			continue
+		}
+		set.keys[key.kid] = key
+	}
+
+	return set, nil
+}
+
+func parseJWTKeyFile(path string) (*jwtKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	name := filepath.Base(path)
+	key := &jwtKey{
+		kid:     strings.TrimSuffix(strings.TrimSuffix(name, ".pem"), ".pub"),
+		modTime: info.ModTime(),
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T (use RSA or Ed25519)", parsed)
+	}
+
+	if rsaKey, ok := key.public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < 2048 {
+		return nil, errors.New("RSA key must be at least 2048 bits")
+	}
+
+	return key, nil
+}
+
+func generateJWTKey(dir string) (*jwtKey, error) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	kid := now.UTC().Format("20060102-150405")
+	path := filepath.Join(dir, kid+".pem")
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+
+	return &jwtKey{
+		kid:     kid,
+		method:  jwt.SigningMethodRS256,
+		private: private,
+		public:  &private.PublicKey,
+		modTime: now,
+	}, nil
+}
+
+func (s *jwtKeySet) hasPrivateKey() bool {
+	for _, k := range s.keys {
+		if k.private != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *jwtKeySet) newestPrivateKey() *jwtKey {
+	var newest *jwtKey
+	for _, k := range s.keys {
+		if k.private == nil {
+			continue
+		}
+		if newest == nil || k.modTime.After(newest.modTime) ||
+			(k.modTime.Equal(newest.modTime) && k.kid > newest.kid) {
+			newest = k
+		}
+	}
+	return newest
+}
@@ -0,0 +1,50 @@
+// Command normalize-catalogs memetakan nilai lama achievementType,
+// details.competitionLevel, details.medalType dan details.publicationType di
+// MongoDB ke code katalog master data (lewat code atau alias, tanpa
+// membedakan huruf besar/kecil). Dijalankan sekali setelah migrasi katalog:
+//
+//	go run ./cmd/normalize-catalogs -dry-run
+//	go run ./cmd/normalize-catalogs
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"backend/app/repository"
+	"backend/app/service"
+	"backend/config"
+	"backend/database"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report what would change")
+	flag.Parse()
+
+	config.LoadENV()
+	database.ConnectPostgres()
+	database.ConnectMongo()
+
+	catalogService := service.NewCatalogService(
+		repository.NewCatalogRepository(database.PostgresDB),
+		repository.NewAchievementRepository(database.MongoDB),
+	)
+
+	report, err := catalogService.NormalizeStored(context.Background(), *dryRun)
+	if err != nil {
+		log.Fatal("❌ Normalization failed: ", err)
+	}
+
+	verb := "updated"
+	if report.DryRun {
+		verb = "would update"
+	}
+	for _, c := range report.Changes {
+		log.Printf("%s: %q -> %q (%s %d documents)", c.Field, c.From, c.To, verb, c.Count)
+	}
+	for _, u := range report.Unmatched {
+		log.Printf("⚠️  %s: %q matches no catalog code or alias (%d documents)", u.Field, u.Value, u.Count)
+	}
+	log.Printf("✅ Done: %d values remapped, %d unmatched", len(report.Changes), len(report.Unmatched))
+}
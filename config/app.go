@@ -1,13 +1,16 @@
 package config
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"os"
+	"time"
 
 	"backend/app/repository"
 	"backend/app/service"
+	"backend/app/utils"
 	"backend/database"
+	"backend/middleware"
 	"backend/routes"
 
 	"github.com/gofiber/fiber/v2"
@@ -19,7 +22,10 @@ type Application struct {
 
 func InitApp() *Application {
 	LoadENV()
-	fmt.Println("SECRET KEY:", os.Getenv("JWT_SECRET"))
+
+	if err := utils.LoadJWTKeys(GetEnv("JWT_KEY_DIR", "./keys"), GetEnv("JWT_ACTIVE_KID", "")); err != nil {
+		log.Fatal("❌ Failed to load JWT keys: ", err)
+	}
 
 	database.ConnectPostgres()
 	database.ConnectMongo()
@@ -29,6 +35,15 @@ func InitApp() *Application {
 
 	userRepo := repository.NewUserRepository(postgresDB)
 	authRepo := repository.NewAuthRepository(postgresDB)
+	securityEventRepo := repository.NewSecurityEventRepository(postgresDB)
+	mfaRepo := repository.NewMFARepository(postgresDB)
+	roleRepo := repository.NewRoleRepository(postgresDB)
+	permissionRepo := repository.NewPermissionRepository(postgresDB)
+	authzRepo := repository.NewAuthzRepository(postgresDB)
+	registrationRepo := repository.NewRegistrationRepository(postgresDB)
+	serviceAccountRepo := repository.NewServiceAccountRepository(postgresDB)
+	identityRepo := repository.NewIdentityRepository(postgresDB)
+	passwordRepo := repository.NewPasswordRepository(postgresDB)
 
 	achievementRepo := repository.NewAchievementRepository(mongoDB)
 	achievementRefRepo := repository.NewAchievementReferenceRepository(postgresDB)
@@ -37,20 +52,78 @@ func InitApp() *Application {
 
 	reportRepo := repository.NewReportRepository(postgresDB, mongoDB)
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("❌ JWT_SECRET is not set")
-	}
+	mfaRequiredRoles := GetEnvList("MFA_REQUIRED_ROLES", nil)
+
+	authzService := service.NewAuthzService(authzRepo, GetEnvDuration("AUTHZ_CACHE_TTL", 10*time.Second))
+	middleware.UseAuthzSource(authzService)
+	middleware.UseSecurityEventRecorder(securityEventRepo)
+
+	mail := NewMailer()
+	passwordPolicyService := service.NewPasswordPolicyService(passwordRepo, NewPasswordPolicy())
+
+	authService := service.NewAuthService(authRepo, securityEventRepo, mfaRepo, registrationRepo, identityRepo, passwordPolicyService, mail, authzService, NewOIDCProvider(), service.AuthConfig{
+		PasswordResetURL: GetEnv("PASSWORD_RESET_URL", ""),
+		PasswordResetTTL: GetEnvDuration("PASSWORD_RESET_TTL", 30*time.Minute),
+
+		MaxFailedLogins: GetEnvInt("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+		LockoutDuration: GetEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+
+		LoginDelayAfter: GetEnvInt("LOGIN_DELAY_AFTER", 3),
+		LoginDelayBase:  GetEnvDuration("LOGIN_DELAY_BASE", time.Second),
+		LoginDelayMax:   GetEnvDuration("LOGIN_DELAY_MAX", 30*time.Second),
+
+		MaxFailedLoginsPerIP: GetEnvInt("LOGIN_IP_MAX_FAILED_ATTEMPTS", 20),
+		IPThrottleWindow:     GetEnvDuration("LOGIN_IP_WINDOW", 15*time.Minute),
+
+		MFARequiredRoles: mfaRequiredRoles,
 
-	authService := service.NewAuthService(authRepo)
-	userService := service.NewUserService(userRepo)
+		ImpersonationTTL: GetEnvDuration("IMPERSONATION_TTL", 15*time.Minute),
 
-	achievementService := service.NewAchievementService(achievementRepo)
+		SelfRegistrationEnabled: GetEnvBool("SELF_REGISTRATION_ENABLED", false),
+		EmailVerificationURL:    GetEnv("EMAIL_VERIFICATION_URL", ""),
+		EmailVerificationTTL:    GetEnvDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+
+		OIDCStateTTL:     GetEnvDuration("OIDC_STATE_TTL", 10*time.Minute),
+		OIDCProvisioning: GetEnvBool("OIDC_JIT_PROVISIONING", false),
+		OIDCClaims: service.OIDCClaimMapping{
+			Email:             GetEnv("OIDC_CLAIM_EMAIL", "email"),
+			FullName:          GetEnv("OIDC_CLAIM_FULL_NAME", "name"),
+			StudentID:         GetEnv("OIDC_CLAIM_STUDENT_ID", "nim"),
+			ProgramStudy:      GetEnv("OIDC_CLAIM_PROGRAM_STUDY", "program_study"),
+			AcademicYear:      GetEnv("OIDC_CLAIM_ACADEMIC_YEAR", "academic_year"),
+			StudentClaim:      GetEnv("OIDC_STUDENT_CLAIM", ""),
+			StudentClaimValue: GetEnv("OIDC_STUDENT_CLAIM_VALUE", ""),
+		},
+	})
+	mfaService := service.NewMFAService(
+		mfaRepo,
+		securityEventRepo,
+		GetEnv("MFA_ISSUER", "Sistem Pelaporan Prestasi"),
+		mfaRequiredRoles,
+	)
+	userService := service.NewUserService(userRepo, securityEventRepo, passwordRepo, passwordPolicyService, authzService)
+	roleService := service.NewRoleService(roleRepo, permissionRepo, authzService)
+	permissionService := service.NewPermissionService(permissionRepo, authzService)
+	registrationService := service.NewRegistrationService(registrationRepo, securityEventRepo, mail, authzService)
+	serviceAccountService := service.NewServiceAccountService(serviceAccountRepo, permissionRepo, securityEventRepo)
+	middleware.UseAPIKeySource(serviceAccountService)
+
+	approvalWorkflowRepo := repository.NewApprovalWorkflowRepository(postgresDB)
+	achievementTypeSchemaService := service.NewAchievementTypeSchemaService(repository.NewAchievementTypeSchemaRepository(postgresDB))
+	catalogService := service.NewCatalogService(repository.NewCatalogRepository(postgresDB), achievementRepo)
+	achievementService := service.NewAchievementService(achievementRepo, achievementRefRepo, studentLecturerRepo, approvalWorkflowRepo, achievementTypeSchemaService, catalogService)
+	approvalWorkflowService := service.NewApprovalWorkflowService(approvalWorkflowRepo, roleRepo)
+	pointsRubricService := service.NewPointsRubricService(repository.NewPointsRubricRepository(postgresDB))
 	achievementReferenceService :=
 		service.NewAchievementReferenceService(
 			achievementRefRepo,
 			achievementRepo,
+			approvalWorkflowRepo,
+			studentLecturerRepo,
+			pointsRubricService,
+			time.Duration(GetEnvInt("ACHIEVEMENT_REVIEW_SLA_DAYS", 7))*24*time.Hour,
 		)
+	startPointsSync(achievementReferenceService, GetEnvDuration("POINTS_SYNC_INTERVAL", time.Minute))
 	studentLecturerService := service.NewStudentLecturerService(studentLecturerRepo)
 	reportService := service.NewReportService(reportRepo, studentLecturerRepo)
 
@@ -58,7 +131,7 @@ func InitApp() *Application {
 		AppName: "Pelaporan-Prestasi",
 	})
 
-	routes.SetupRoutes(
+	if err := routes.SetupRoutes(
 		app,
 		userService,
 		authService,
@@ -66,9 +139,46 @@ func InitApp() *Application {
 		achievementReferenceService,
 		studentLecturerService,
 		reportService,
-	)
+		mfaService,
+		roleService,
+		permissionService,
+		registrationService,
+		serviceAccountService,
+		passwordPolicyService,
+		approvalWorkflowService,
+		pointsRubricService,
+		achievementTypeSchemaService,
+		catalogService,
+	); err != nil {
+		log.Fatal("❌ Failed to set up routes: ", err)
+	}
 
 	log.Println("🚀 Application running on port:", os.Getenv("APP_PORT"))
 
 	return &Application{App: app}
 }
+
+// startPointsSync menjalankan SyncPendingPoints secara berkala agar poin
+// prestasi yang gagal ditulis ke MongoDB saat verifikasi diperbaiki.
+// Interval 0 mematikan job.
+func startPointsSync(s service.AchievementReferenceService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			synced, err := s.SyncPendingPoints(context.Background())
+			if err != nil {
+				log.Println("❌ points sync failed:", err)
+				continue
+			}
+			if synced > 0 {
+				log.Printf("✅ points sync: %d achievements updated", synced)
+			}
+		}
+	}()
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"log"
+
+	"backend/app/mailer"
+)
+
+// NewMailer memilih implementasi mailer dari MAIL_DRIVER ("smtp" atau "log").
+func NewMailer() mailer.Mailer {
+	driver := GetEnv("MAIL_DRIVER", "log")
+
+	switch driver {
+	case "smtp":
+		host := GetEnv("SMTP_HOST", "")
+		if host == "" {
+			log.Fatal("❌ SMTP_HOST is not set")
+		}
+		return mailer.NewSMTPMailer(
+			host,
+			GetEnv("SMTP_PORT", "587"),
+			GetEnv("SMTP_USERNAME", ""),
+			GetEnv("SMTP_PASSWORD", ""),
+			GetEnv("MAIL_FROM", "no-reply@pelaporan-prestasi.local"),
+		)
+	case "log":
+		return mailer.NewLogMailer(GetEnv("MAIL_LOG_FILE", ""))
+	default:
+		log.Fatalf("❌ unknown MAIL_DRIVER %q", driver)
+		return nil
+	}
+}
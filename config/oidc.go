@@ -0,0 +1,31 @@
+package config
+
+import (
+	"log"
+	"strings"
+
+	"backend/app/oidc"
+)
+
+// NewOIDCProvider mengembalikan nil jika OIDC_ENABLED tidak aktif, sehingga
+// endpoint SSO menolak request dan hanya login password yang tersedia.
+func NewOIDCProvider() oidc.Provider {
+	if !GetEnvBool("OIDC_ENABLED", false) {
+		return nil
+	}
+
+	issuer := GetEnv("OIDC_ISSUER", "")
+	clientID := GetEnv("OIDC_CLIENT_ID", "")
+	redirectURL := GetEnv("OIDC_REDIRECT_URL", "")
+	if issuer == "" || clientID == "" || redirectURL == "" {
+		log.Fatal("❌ OIDC_ISSUER, OIDC_CLIENT_ID and OIDC_REDIRECT_URL must be set when OIDC_ENABLED=true")
+	}
+
+	return oidc.NewProvider(oidc.Config{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: GetEnv("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:  redirectURL,
+		Scopes:       strings.Fields(GetEnv("OIDC_SCOPES", "openid email profile")),
+	})
+}
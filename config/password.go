@@ -0,0 +1,54 @@
+package config
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"strings"
+
+	"backend/app/models"
+	"backend/app/utils"
+)
+
+// defaultBannedPasswords selalu ditolak, ditambah isi PASSWORD_BANNED_FILE.
+var defaultBannedPasswords = []string{
+	"password", "password1", "password123", "passw0rd", "12345678", "123456789",
+	"1234567890", "qwerty123", "qwertyuiop", "11111111", "00000000", "abcd1234",
+	"iloveyou", "admin123", "welcome1", "letmein1", "mahasiswa", "mahasiswa123",
+	"dosen123", "rahasia123", "bismillah", "indonesia",
+}
+
+// NewPasswordPolicy membaca aturan password dari environment dan mengatur
+// cost bcrypt untuk hash baru (BCRYPT_COST).
+func NewPasswordPolicy() models.PasswordPolicy {
+	utils.SetPasswordCost(GetEnvInt("BCRYPT_COST", 12))
+
+	banned := append([]string{}, defaultBannedPasswords...)
+	if path := GetEnv("PASSWORD_BANNED_FILE", ""); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatal("❌ Failed to read PASSWORD_BANNED_FILE: ", err)
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+				banned = append(banned, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Fatal("❌ Failed to read PASSWORD_BANNED_FILE: ", err)
+		}
+	}
+
+	return models.PasswordPolicy{
+		MinLength:       GetEnvInt("PASSWORD_MIN_LENGTH", 8),
+		RequireUpper:    GetEnvBool("PASSWORD_REQUIRE_UPPERCASE", true),
+		RequireLower:    GetEnvBool("PASSWORD_REQUIRE_LOWERCASE", true),
+		RequireDigit:    GetEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+		RequireSymbol:   GetEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		HistorySize:     GetEnvInt("PASSWORD_HISTORY_SIZE", 5),
+		BannedPasswords: banned,
+	}
+}
@@ -0,0 +1,23 @@
+-- Refresh token rotation: setiap refresh menghasilkan token baru dalam satu
+-- "family". Token lama tidak dihapus tetapi ditandai revoked sehingga
+-- pemakaian ulang dapat dideteksi.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS family_id   UUID,
+    ADD COLUMN IF NOT EXISTS revoked_at  TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS replaced_by UUID REFERENCES refresh_tokens(id) ON DELETE SET NULL;
+
+UPDATE refresh_tokens SET family_id = id WHERE family_id IS NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS security_events (
+    id         UUID PRIMARY KEY,
+    user_id    UUID REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    detail     TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user_id ON security_events (user_id, created_at DESC);
@@ -0,0 +1,17 @@
+-- Pelacakan gagal login per akun (lockout sementara) dan per IP.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS failed_login_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_failed_login_at  TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS locked_until          TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id         UUID PRIMARY KEY,
+    username   VARCHAR(100) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL,
+    success    BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip_created ON login_attempts (ip_address, created_at DESC);
+
+ALTER TABLE security_events ADD COLUMN IF NOT EXISTS ip_address VARCHAR(64);
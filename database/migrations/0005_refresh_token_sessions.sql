@@ -0,0 +1,7 @@
+-- Informasi klien untuk daftar sesi aktif. Satu sesi = satu family refresh
+-- token; baris aktif terakhir menyimpan IP dan user agent pemakaian terakhir.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS ip_address VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS user_agent TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_active ON refresh_tokens (user_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,65 @@
+-- Permission "resource:action" untuk setiap endpoint di tabel route
+-- (routes/route.go) beserta pemberian awal ke role bawaan. Aman dijalankan
+-- ulang; permission/role yang sudah ada tidak diubah.
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), p.resource || ':' || p.action, p.resource, p.action, p.description
+FROM (VALUES
+    ('user', 'read', 'Lihat daftar dan detail user'),
+    ('user', 'create', 'Buat user'),
+    ('user', 'update', 'Ubah data user'),
+    ('user', 'delete', 'Hapus user'),
+    ('user', 'assign_role', 'Ganti role user'),
+    ('user', 'unlock', 'Buka kunci akun user'),
+    ('security_event', 'read', 'Lihat security event user'),
+    ('session', 'read', 'Lihat sesi login user lain'),
+    ('session', 'revoke', 'Cabut sesi login user lain'),
+    ('role', 'read', 'Lihat role'),
+    ('role', 'create', 'Buat role'),
+    ('role', 'update', 'Ubah role'),
+    ('role', 'delete', 'Hapus role'),
+    ('role', 'assign_permission', 'Atur permission role'),
+    ('permission', 'read', 'Lihat permission'),
+    ('permission', 'create', 'Buat permission'),
+    ('permission', 'update', 'Ubah permission'),
+    ('permission', 'delete', 'Hapus permission'),
+    ('achievement', 'read', 'Lihat prestasi'),
+    ('achievement', 'create', 'Buat prestasi'),
+    ('achievement', 'update', 'Ubah prestasi dan lampiran'),
+    ('achievement', 'delete', 'Hapus prestasi'),
+    ('achievement', 'submit', 'Ajukan prestasi untuk verifikasi'),
+    ('achievement', 'verify', 'Verifikasi prestasi'),
+    ('achievement', 'reject', 'Tolak prestasi'),
+    ('student', 'read', 'Lihat data mahasiswa'),
+    ('student', 'assign_advisor', 'Atur dosen wali mahasiswa'),
+    ('lecturer', 'read', 'Lihat data dosen dan mahasiswa bimbingan'),
+    ('report', 'read', 'Lihat laporan dan statistik')
+) AS p (resource, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions x WHERE x.name = p.resource || ':' || p.action);
+
+-- Admin mendapat semua permission di atas.
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name LIKE '%:%'
+WHERE r.name = 'Admin'
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM (VALUES
+    ('Mahasiswa', 'achievement:read'),
+    ('Mahasiswa', 'achievement:create'),
+    ('Mahasiswa', 'achievement:update'),
+    ('Mahasiswa', 'achievement:delete'),
+    ('Mahasiswa', 'achievement:submit'),
+    ('Mahasiswa', 'report:read'),
+    ('DosenWali', 'achievement:read'),
+    ('DosenWali', 'achievement:verify'),
+    ('DosenWali', 'achievement:reject'),
+    ('DosenWali', 'student:read'),
+    ('DosenWali', 'lecturer:read'),
+    ('DosenWali', 'report:read')
+) AS g (role_name, permission_name)
+JOIN roles r ON r.name = g.role_name
+JOIN permissions p ON p.name = g.permission_name
+WHERE NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
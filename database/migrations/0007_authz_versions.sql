@@ -0,0 +1,9 @@
+-- Versi otorisasi. roles.permissions_version naik setiap kali permission
+-- sebuah role berubah; users.authz_version naik ketika role atau status
+-- aktif user berubah. Access token membawa kedua versi ini (claim rv/uv)
+-- sehingga middleware dapat mendeteksi token yang sudah basi.
+ALTER TABLE roles
+    ADD COLUMN IF NOT EXISTS permissions_version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS authz_version BIGINT NOT NULL DEFAULT 1;
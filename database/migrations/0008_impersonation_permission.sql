@@ -0,0 +1,15 @@
+-- Permission untuk endpoint impersonasi admin (POST /users/:id/impersonate).
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'user:impersonate', 'user', 'impersonate', 'Masuk sebagai user lain untuk investigasi'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'user:impersonate');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'user:impersonate'
+WHERE r.name = 'Admin'
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+-- Bump versi agar token Admin yang sedang aktif langsung mendapat
+-- permission baru (lihat 0007_authz_versions.sql).
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name = 'Admin';
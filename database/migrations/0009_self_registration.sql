@@ -0,0 +1,41 @@
+-- Registrasi mandiri mahasiswa: allow-list NIM dari admin, verifikasi email,
+-- lalu persetujuan admin (users.is_active).
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS registration_allowlist (
+    student_id         VARCHAR(20) PRIMARY KEY,
+    program_study      VARCHAR(100) NOT NULL,
+    academic_year      VARCHAR(10),
+    registered_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    uploaded_by        UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id         UUID PRIMARY KEY,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), p.resource || ':' || p.action, p.resource, p.action, p.description
+FROM (VALUES
+    ('registration', 'read', 'Lihat allow-list dan registrasi yang menunggu persetujuan'),
+    ('registration', 'manage', 'Unggah allow-list dan setujui registrasi')
+) AS p (resource, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions x WHERE x.name = p.resource || ':' || p.action);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name IN ('registration:read', 'registration:manage')
+WHERE r.name = 'Admin'
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name = 'Admin';
@@ -0,0 +1,46 @@
+-- Service account untuk klien mesin (dashboard fakultas, integrasi SIAKAD)
+-- dan API key-nya. Key hanya disimpan sebagai hash SHA-256; prefix dipakai
+-- untuk mencari key dan ditampilkan di daftar key.
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id          UUID PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    is_active   BOOLEAN NOT NULL DEFAULT true,
+    created_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id                 UUID PRIMARY KEY,
+    service_account_id UUID NOT NULL REFERENCES service_accounts(id) ON DELETE CASCADE,
+    name               VARCHAR(100) NOT NULL,
+    prefix             VARCHAR(16) NOT NULL UNIQUE,
+    key_hash           VARCHAR(64) NOT NULL,
+    permissions        TEXT[] NOT NULL DEFAULT '{}',
+    expires_at         TIMESTAMP,
+    revoked_at         TIMESTAMP,
+    last_used_at       TIMESTAMP,
+    last_used_ip       VARCHAR(64),
+    created_by         UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_service_account_id ON api_keys (service_account_id);
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), p.resource || ':' || p.action, p.resource, p.action, p.description
+FROM (VALUES
+    ('service_account', 'read', 'Lihat service account dan API key'),
+    ('service_account', 'manage', 'Kelola service account, buat dan cabut API key')
+) AS p (resource, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions x WHERE x.name = p.resource || ':' || p.action);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name IN ('service_account:read', 'service_account:manage')
+WHERE r.name = 'Admin'
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name = 'Admin';
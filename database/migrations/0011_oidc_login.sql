@@ -0,0 +1,27 @@
+-- Login SSO lewat OpenID Connect (authorization code + PKCE).
+-- oidc_login_states menyimpan state, nonce dan code verifier per percobaan
+-- login (sekali pakai); user_identities menautkan subject IdP ke users.
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    id            UUID PRIMARY KEY,
+    state_hash    VARCHAR(64) NOT NULL UNIQUE,
+    nonce         VARCHAR(128) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at    TIMESTAMP NOT NULL,
+    used_at       TIMESTAMP,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_oidc_login_states_expires_at ON oidc_login_states (expires_at);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id            UUID PRIMARY KEY,
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer        VARCHAR(255) NOT NULL,
+    subject       VARCHAR(255) NOT NULL,
+    email         VARCHAR(255),
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMP,
+    UNIQUE (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities (user_id);
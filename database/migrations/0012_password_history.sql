@@ -0,0 +1,11 @@
+-- Riwayat hash password untuk mencegah pemakaian ulang password lama.
+-- Hash lama disalin ke sini setiap kali password diganti (update admin,
+-- reset password); rehash otomatis saat login tidak dicatat.
+CREATE TABLE IF NOT EXISTS password_history (
+    id            UUID PRIMARY KEY,
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user_id ON password_history (user_id, created_at DESC);
@@ -0,0 +1,43 @@
+-- Riwayat perubahan status prestasi (append-only). Setiap transisi ditulis
+-- dalam transaksi yang sama dengan perubahan achievement_references.
+-- actor_id sengaja tanpa foreign key agar riwayat tidak ikut berubah saat
+-- user dihapus.
+CREATE TABLE IF NOT EXISTS achievement_status_history (
+    id                       UUID PRIMARY KEY,
+    achievement_reference_id UUID NOT NULL REFERENCES achievement_references(id),
+    from_status              VARCHAR(30),
+    to_status                VARCHAR(30) NOT NULL,
+    actor_id                 UUID,
+    note                     TEXT,
+    points                   NUMERIC(10, 2),
+    created_at               TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_status_history_reference
+    ON achievement_status_history (achievement_reference_id, created_at);
+
+CREATE OR REPLACE FUNCTION achievement_status_history_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'achievement_status_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_achievement_status_history_append_only ON achievement_status_history;
+CREATE TRIGGER trg_achievement_status_history_append_only
+    BEFORE UPDATE OR DELETE ON achievement_status_history
+    FOR EACH ROW EXECUTE FUNCTION achievement_status_history_append_only();
+
+-- Riwayat awal untuk prestasi yang sudah ada, sebatas yang bisa direkonstruksi
+-- dari kolom achievement_references (poin tersimpan di MongoDB).
+INSERT INTO achievement_status_history (id, achievement_reference_id, from_status, to_status, actor_id, note, created_at)
+SELECT gen_random_uuid(), ar.id, h.from_status, h.to_status, h.actor_id, h.note, h.created_at
+FROM achievement_references ar
+CROSS JOIN LATERAL (VALUES
+    (NULL, 'draft', NULL::uuid, NULL, ar.created_at, true),
+    ('draft', 'submitted', NULL::uuid, NULL, ar.submitted_at, ar.submitted_at IS NOT NULL),
+    ('submitted', 'verified', ar.verified_by, NULL, ar.verified_at, ar.status = 'verified' AND ar.verified_at IS NOT NULL),
+    ('submitted', 'rejected', NULL::uuid, ar.rejection_note, ar.updated_at, ar.status = 'rejected'),
+    ('draft', 'deleted', NULL::uuid, NULL, ar.updated_at, ar.status = 'deleted')
+) AS h (from_status, to_status, actor_id, note, created_at, applies)
+WHERE h.applies
+  AND NOT EXISTS (SELECT 1 FROM achievement_status_history x WHERE x.achievement_reference_id = ar.id);
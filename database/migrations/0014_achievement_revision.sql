@@ -0,0 +1,51 @@
+-- Status revision_requested: dosen wali mengembalikan prestasi ke mahasiswa
+-- untuk diperbaiki lalu diajukan ulang. Aturan transisi ada di
+-- app/models/achievement_workflow.go.
+ALTER TABLE achievement_references ADD COLUMN IF NOT EXISTS revision_note TEXT;
+
+-- Kolom status bisa berupa enum atau VARCHAR dengan CHECK, tergantung versi
+-- skema awal; keduanya disesuaikan agar menerima status baru.
+DO $$
+DECLARE
+    status_type TEXT;
+    con RECORD;
+BEGIN
+    SELECT t.typname INTO status_type
+    FROM pg_attribute a
+    JOIN pg_type t ON t.oid = a.atttypid
+    WHERE a.attrelid = 'achievement_references'::regclass
+      AND a.attname = 'status'
+      AND t.typtype = 'e';
+
+    IF status_type IS NOT NULL THEN
+        EXECUTE format('ALTER TYPE %I ADD VALUE IF NOT EXISTS %L', status_type, 'revision_requested');
+    END IF;
+
+    FOR con IN
+        SELECT c.conname
+        FROM pg_constraint c
+        WHERE c.conrelid = 'achievement_references'::regclass
+          AND c.contype = 'c'
+          AND pg_get_constraintdef(c.oid) LIKE '%status%'
+    LOOP
+        EXECUTE format('ALTER TABLE achievement_references DROP CONSTRAINT %I', con.conname);
+    END LOOP;
+
+    IF status_type IS NULL THEN
+        ALTER TABLE achievement_references ADD CONSTRAINT achievement_references_status_check
+            CHECK (status IN ('draft', 'submitted', 'verified', 'rejected', 'revision_requested', 'deleted'));
+    END IF;
+END $$;
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), 'achievement:request_revision', 'achievement', 'request_revision', 'Kembalikan prestasi ke mahasiswa untuk revisi'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'achievement:request_revision');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'achievement:request_revision'
+WHERE r.name IN ('Admin', 'DosenWali')
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name IN ('Admin', 'DosenWali');
@@ -0,0 +1,52 @@
+-- Alur persetujuan bertingkat yang diatur admin. Workflow dipilih saat
+-- prestasi diajukan berdasarkan achievementType dan details.competitionLevel
+-- (kolom NULL = berlaku untuk semua nilai); yang paling spesifik menang.
+-- Prestasi tanpa workflow yang cocok tetap diverifikasi dalam satu langkah.
+CREATE TABLE IF NOT EXISTS approval_workflows (
+    id                UUID PRIMARY KEY,
+    name              VARCHAR(100) NOT NULL,
+    achievement_type  VARCHAR(50),
+    competition_level VARCHAR(50),
+    is_active         BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_workflows_match
+    ON approval_workflows (LOWER(COALESCE(achievement_type, '')), LOWER(COALESCE(competition_level, '')));
+
+-- Setiap langkah menyebut role penyetuju, scope terhadap mahasiswa
+-- (advisor = dosen wali mahasiswa, program = dosen di program studi
+-- mahasiswa), atau keduanya.
+CREATE TABLE IF NOT EXISTS approval_workflow_steps (
+    workflow_id    UUID NOT NULL REFERENCES approval_workflows(id) ON DELETE CASCADE,
+    step_order     INT NOT NULL,
+    name           VARCHAR(100) NOT NULL,
+    approver_role  VARCHAR(50),
+    approver_scope VARCHAR(20) CHECK (approver_scope IN ('advisor', 'program')),
+    PRIMARY KEY (workflow_id, step_order),
+    CHECK (approver_role IS NOT NULL OR approver_scope IS NOT NULL)
+);
+
+-- Workflow yang dipakai dan langkah yang sedang berjalan dicatat saat
+-- pengajuan, agar perubahan aturan pencocokan tidak memindahkan prestasi
+-- yang sudah diajukan ke workflow lain.
+ALTER TABLE achievement_references ADD COLUMN IF NOT EXISTS approval_workflow_id UUID REFERENCES approval_workflows(id) ON DELETE SET NULL;
+ALTER TABLE achievement_references ADD COLUMN IF NOT EXISTS current_step INT;
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), p.resource || ':' || p.action, p.resource, p.action, p.description
+FROM (VALUES
+    ('approval_workflow', 'read', 'Lihat alur persetujuan prestasi'),
+    ('approval_workflow', 'manage', 'Atur alur persetujuan prestasi')
+) AS p (resource, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions x WHERE x.name = p.resource || ':' || p.action);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name IN ('approval_workflow:read', 'approval_workflow:manage')
+WHERE r.name = 'Admin'
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name = 'Admin';
@@ -0,0 +1,78 @@
+-- Rubrik poin prestasi yang dikelola admin. Setiap versi berisi aturan
+-- lengkap dan berlaku mulai effective_from; prestasi dinilai dengan versi
+-- yang berlaku saat diajukan. Versi yang sudah berlaku tidak diubah, admin
+-- membuat versi baru.
+CREATE TABLE IF NOT EXISTS points_rubrics (
+    id             UUID PRIMARY KEY,
+    version        INT NOT NULL UNIQUE,
+    name           VARCHAR(100) NOT NULL,
+    effective_from TIMESTAMP NOT NULL,
+    created_by     UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_rubrics_effective
+    ON points_rubrics (effective_from DESC);
+
+-- Kolom kriteria NULL berarti cocok dengan nilai apa pun; aturan dengan
+-- kriteria terisi paling banyak yang dipakai.
+CREATE TABLE IF NOT EXISTS points_rubric_rules (
+    id                UUID PRIMARY KEY,
+    rubric_id         UUID NOT NULL REFERENCES points_rubrics(id) ON DELETE CASCADE,
+    achievement_type  VARCHAR(50) NOT NULL,
+    competition_level VARCHAR(50),
+    rank              INT,
+    medal_type        VARCHAR(50),
+    publication_type  VARCHAR(50),
+    points            NUMERIC(10, 2) NOT NULL CHECK (points >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_rubric_rules_rubric
+    ON points_rubric_rules (rubric_id, achievement_type);
+
+-- Pengali poin untuk prestasi tim: faktor dengan min_team_size terbesar
+-- yang tidak melebihi ukuran tim dipakai. Tanpa faktor, pengali = 1.
+CREATE TABLE IF NOT EXISTS points_rubric_team_factors (
+    rubric_id     UUID NOT NULL REFERENCES points_rubrics(id) ON DELETE CASCADE,
+    min_team_size INT NOT NULL CHECK (min_team_size >= 1),
+    multiplier    NUMERIC(5, 4) NOT NULL CHECK (multiplier >= 0),
+    PRIMARY KEY (rubric_id, min_team_size)
+);
+
+-- Setiap poin yang berbeda dari saran rubrik wajib disertai justifikasi
+-- dan dicatat di sini (append-only), dalam transaksi yang sama dengan
+-- persetujuan.
+CREATE TABLE IF NOT EXISTS achievement_points_overrides (
+    id                       UUID PRIMARY KEY,
+    achievement_reference_id UUID NOT NULL REFERENCES achievement_references(id),
+    rubric_id                UUID REFERENCES points_rubrics(id) ON DELETE SET NULL,
+    suggested_points         NUMERIC(10, 2),
+    awarded_points           NUMERIC(10, 2) NOT NULL,
+    justification            TEXT NOT NULL,
+    actor_id                 UUID NOT NULL,
+    created_at               TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_points_overrides_reference
+    ON achievement_points_overrides (achievement_reference_id, created_at);
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), p.resource || ':' || p.action, p.resource, p.action, p.description
+FROM (VALUES
+    ('points_rubric', 'read', 'Lihat rubrik poin prestasi'),
+    ('points_rubric', 'manage', 'Kelola versi rubrik poin prestasi')
+) AS p (resource, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions x WHERE x.name = p.resource || ':' || p.action);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM (VALUES
+    ('Admin', 'points_rubric:read'),
+    ('Admin', 'points_rubric:manage'),
+    ('DosenWali', 'points_rubric:read')
+) AS g (role_name, permission_name)
+JOIN roles r ON r.name = g.role_name
+JOIN permissions p ON p.name = g.permission_name
+WHERE NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name IN ('Admin', 'DosenWali');
@@ -0,0 +1,91 @@
+-- Skema per achievementType yang diatur admin: field details dan
+-- customFields yang boleh/wajib diisi beserta tipe, enum dan formatnya.
+-- Dipakai untuk validasi saat prestasi dibuat/diubah dan untuk merender
+-- form di front end. Tipe tanpa skema ditolak.
+CREATE TABLE IF NOT EXISTS achievement_type_schemas (
+    achievement_type            VARCHAR(50) PRIMARY KEY,
+    allow_unknown_custom_fields BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by                  UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at                  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- min_value/max_value berlaku untuk nilai angka, panjang string, atau
+-- jumlah item string[]. Field details yang tidak terdaftar tidak boleh
+-- diisi untuk tipe tersebut.
+CREATE TABLE IF NOT EXISTS achievement_type_schema_fields (
+    achievement_type VARCHAR(50) NOT NULL REFERENCES achievement_type_schemas(achievement_type) ON DELETE CASCADE,
+    section          VARCHAR(20) NOT NULL CHECK (section IN ('details', 'customFields')),
+    name             VARCHAR(50) NOT NULL,
+    label            VARCHAR(100) NOT NULL DEFAULT '',
+    field_type       VARCHAR(20) NOT NULL CHECK (field_type IN ('string', 'integer', 'number', 'boolean', 'date', 'string[]', 'period')),
+    required         BOOLEAN NOT NULL DEFAULT FALSE,
+    enum_values      TEXT[],
+    format           VARCHAR(20) CHECK (format IN ('email', 'url', 'date', 'issn')),
+    min_value        NUMERIC,
+    max_value        NUMERIC,
+    sort_order       INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (achievement_type, section, name)
+);
+
+INSERT INTO achievement_type_schemas (achievement_type)
+VALUES ('academic'), ('competition'), ('organization'), ('publication'), ('certification'), ('other')
+ON CONFLICT (achievement_type) DO NOTHING;
+
+INSERT INTO achievement_type_schema_fields (
+    achievement_type, section, name, label, field_type, required, enum_values, format, min_value, max_value, sort_order
+)
+SELECT f.achievement_type, 'details', f.name, f.label, f.field_type, f.required, f.enum_values, f.format, f.min_value, f.max_value, f.sort_order
+FROM (VALUES
+    ('academic', 'eventDate', 'Tanggal', 'date', FALSE, NULL::TEXT[], NULL, NULL::NUMERIC, NULL::NUMERIC, 1),
+    ('academic', 'organizer', 'Penyelenggara', 'string', FALSE, NULL, NULL, NULL, 200, 2),
+    ('academic', 'location', 'Lokasi', 'string', FALSE, NULL, NULL, NULL, 200, 3),
+    ('academic', 'score', 'Nilai', 'number', FALSE, NULL, NULL, 0, NULL, 4),
+
+    ('competition', 'competitionName', 'Nama Kompetisi', 'string', TRUE, NULL, NULL, NULL, 200, 1),
+    ('competition', 'competitionLevel', 'Tingkat', 'string', TRUE, ARRAY['international', 'national', 'regional', 'local'], NULL, NULL, NULL, 2),
+    ('competition', 'rank', 'Peringkat', 'integer', FALSE, NULL, NULL, 1, NULL, 3),
+    ('competition', 'medalType', 'Medali', 'string', FALSE, ARRAY['gold', 'silver', 'bronze'], NULL, NULL, NULL, 4),
+    ('competition', 'teamSize', 'Jumlah Anggota Tim', 'integer', FALSE, NULL, NULL, 1, NULL, 5),
+    ('competition', 'eventDate', 'Tanggal', 'date', FALSE, NULL, NULL, NULL, NULL, 6),
+    ('competition', 'location', 'Lokasi', 'string', FALSE, NULL, NULL, NULL, 200, 7),
+    ('competition', 'organizer', 'Penyelenggara', 'string', FALSE, NULL, NULL, NULL, 200, 8),
+
+    ('organization', 'organizationName', 'Nama Organisasi', 'string', TRUE, NULL, NULL, NULL, 200, 1),
+    ('organization', 'position', 'Jabatan', 'string', TRUE, NULL, NULL, NULL, 100, 2),
+    ('organization', 'period', 'Periode', 'period', TRUE, NULL, NULL, NULL, NULL, 3),
+
+    ('publication', 'publicationType', 'Jenis Publikasi', 'string', TRUE, ARRAY['journal', 'conference', 'book'], NULL, NULL, NULL, 1),
+    ('publication', 'publicationTitle', 'Judul Publikasi', 'string', TRUE, NULL, NULL, NULL, 300, 2),
+    ('publication', 'authors', 'Penulis', 'string[]', TRUE, NULL, NULL, 1, NULL, 3),
+    ('publication', 'publisher', 'Penerbit', 'string', FALSE, NULL, NULL, NULL, 200, 4),
+    ('publication', 'issn', 'ISSN', 'string', FALSE, NULL, 'issn', NULL, NULL, 5),
+    ('publication', 'eventDate', 'Tanggal Terbit', 'date', FALSE, NULL, NULL, NULL, NULL, 6),
+
+    ('certification', 'certificationName', 'Nama Sertifikasi', 'string', TRUE, NULL, NULL, NULL, 200, 1),
+    ('certification', 'issuedBy', 'Penerbit Sertifikat', 'string', TRUE, NULL, NULL, NULL, 200, 2),
+    ('certification', 'certificationNumber', 'Nomor Sertifikat', 'string', FALSE, NULL, NULL, NULL, 100, 3),
+    ('certification', 'validUntil', 'Berlaku Sampai', 'date', FALSE, NULL, NULL, NULL, NULL, 4),
+
+    ('other', 'eventDate', 'Tanggal', 'date', FALSE, NULL, NULL, NULL, NULL, 1),
+    ('other', 'organizer', 'Penyelenggara', 'string', FALSE, NULL, NULL, NULL, 200, 2),
+    ('other', 'location', 'Lokasi', 'string', FALSE, NULL, NULL, NULL, 200, 3)
+) AS f (achievement_type, name, label, field_type, required, enum_values, format, min_value, max_value, sort_order)
+WHERE NOT EXISTS (
+    SELECT 1 FROM achievement_type_schema_fields x WHERE x.achievement_type = f.achievement_type
+);
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), p.resource || ':' || p.action, p.resource, p.action, p.description
+FROM (VALUES
+    ('achievement_schema', 'manage', 'Kelola skema field per tipe prestasi')
+) AS p (resource, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions x WHERE x.name = p.resource || ':' || p.action);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'achievement_schema:manage'
+WHERE r.name = 'Admin'
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name = 'Admin';
@@ -0,0 +1,106 @@
+-- Master data untuk nilai yang sebelumnya berupa string bebas: tipe
+-- prestasi, tingkat kompetisi, jenis medali dan jenis publikasi. Nilai
+-- yang disimpan di MongoDB adalah code; aliases (huruf kecil) dipakai untuk
+-- menormalkan input dan data lama, misalnya "Nasional" -> national.
+CREATE TABLE IF NOT EXISTS catalog_items (
+    catalog    VARCHAR(30) NOT NULL CHECK (catalog IN ('achievement_type', 'competition_level', 'medal_type', 'publication_type')),
+    code       VARCHAR(50) NOT NULL,
+    aliases    TEXT[] NOT NULL DEFAULT '{}',
+    sort_order INT NOT NULL DEFAULT 0,
+    is_active  BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (catalog, code)
+);
+
+CREATE TABLE IF NOT EXISTS catalog_item_labels (
+    catalog VARCHAR(30) NOT NULL,
+    code    VARCHAR(50) NOT NULL,
+    locale  VARCHAR(10) NOT NULL,
+    label   VARCHAR(100) NOT NULL,
+    PRIMARY KEY (catalog, code, locale),
+    FOREIGN KEY (catalog, code) REFERENCES catalog_items (catalog, code) ON DELETE CASCADE
+);
+
+INSERT INTO catalog_items (catalog, code, aliases, sort_order)
+VALUES
+    ('achievement_type', 'academic', ARRAY['akademik', 'akademis'], 1),
+    ('achievement_type', 'competition', ARRAY['kompetisi', 'lomba'], 2),
+    ('achievement_type', 'organization', ARRAY['organisasi', 'organisation'], 3),
+    ('achievement_type', 'publication', ARRAY['publikasi'], 4),
+    ('achievement_type', 'certification', ARRAY['sertifikasi', 'sertifikat', 'certificate'], 5),
+    ('achievement_type', 'other', ARRAY['lainnya', 'lain-lain'], 6),
+
+    ('competition_level', 'international', ARRAY['internasional'], 1),
+    ('competition_level', 'national', ARRAY['nasional'], 2),
+    ('competition_level', 'regional', ARRAY['provinsi', 'wilayah'], 3),
+    ('competition_level', 'local', ARRAY['lokal', 'kampus', 'universitas'], 4),
+
+    ('medal_type', 'gold', ARRAY['emas'], 1),
+    ('medal_type', 'silver', ARRAY['perak'], 2),
+    ('medal_type', 'bronze', ARRAY['perunggu'], 3),
+
+    ('publication_type', 'journal', ARRAY['jurnal'], 1),
+    ('publication_type', 'conference', ARRAY['konferensi', 'prosiding', 'proceeding'], 2),
+    ('publication_type', 'book', ARRAY['buku'], 3)
+ON CONFLICT (catalog, code) DO NOTHING;
+
+INSERT INTO catalog_item_labels (catalog, code, locale, label)
+VALUES
+    ('achievement_type', 'academic', 'id', 'Akademik'),
+    ('achievement_type', 'academic', 'en', 'Academic'),
+    ('achievement_type', 'competition', 'id', 'Kompetisi'),
+    ('achievement_type', 'competition', 'en', 'Competition'),
+    ('achievement_type', 'organization', 'id', 'Organisasi'),
+    ('achievement_type', 'organization', 'en', 'Organization'),
+    ('achievement_type', 'publication', 'id', 'Publikasi'),
+    ('achievement_type', 'publication', 'en', 'Publication'),
+    ('achievement_type', 'certification', 'id', 'Sertifikasi'),
+    ('achievement_type', 'certification', 'en', 'Certification'),
+    ('achievement_type', 'other', 'id', 'Lainnya'),
+    ('achievement_type', 'other', 'en', 'Other'),
+
+    ('competition_level', 'international', 'id', 'Internasional'),
+    ('competition_level', 'international', 'en', 'International'),
+    ('competition_level', 'national', 'id', 'Nasional'),
+    ('competition_level', 'national', 'en', 'National'),
+    ('competition_level', 'regional', 'id', 'Regional'),
+    ('competition_level', 'regional', 'en', 'Regional'),
+    ('competition_level', 'local', 'id', 'Lokal'),
+    ('competition_level', 'local', 'en', 'Local'),
+
+    ('medal_type', 'gold', 'id', 'Emas'),
+    ('medal_type', 'gold', 'en', 'Gold'),
+    ('medal_type', 'silver', 'id', 'Perak'),
+    ('medal_type', 'silver', 'en', 'Silver'),
+    ('medal_type', 'bronze', 'id', 'Perunggu'),
+    ('medal_type', 'bronze', 'en', 'Bronze'),
+
+    ('publication_type', 'journal', 'id', 'Jurnal'),
+    ('publication_type', 'journal', 'en', 'Journal'),
+    ('publication_type', 'conference', 'id', 'Konferensi'),
+    ('publication_type', 'conference', 'en', 'Conference'),
+    ('publication_type', 'book', 'id', 'Buku'),
+    ('publication_type', 'book', 'en', 'Book')
+ON CONFLICT (catalog, code, locale) DO NOTHING;
+
+-- Nilai field ini sekarang divalidasi terhadap katalog, bukan enum skema.
+UPDATE achievement_type_schema_fields
+SET enum_values = NULL
+WHERE section = 'details'
+  AND name IN ('competitionLevel', 'medalType', 'publicationType');
+
+INSERT INTO permissions (id, name, resource, action, description)
+SELECT gen_random_uuid(), p.resource || ':' || p.action, p.resource, p.action, p.description
+FROM (VALUES
+    ('catalog', 'manage', 'Kelola master data tipe prestasi, tingkat kompetisi, medali dan publikasi')
+) AS p (resource, action, description)
+WHERE NOT EXISTS (SELECT 1 FROM permissions x WHERE x.name = p.resource || ':' || p.action);
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'catalog:manage'
+WHERE r.name = 'Admin'
+  AND NOT EXISTS (SELECT 1 FROM role_permissions x WHERE x.role_id = r.id AND x.permission_id = p.id);
+
+UPDATE roles SET permissions_version = permissions_version + 1 WHERE name = 'Admin';
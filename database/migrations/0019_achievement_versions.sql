@@ -0,0 +1,4 @@
+-- Nomor versi referensi prestasi untuk optimistic concurrency: naik setiap
+-- transisi status. Bersama field version dokumen MongoDB membentuk ETag
+-- prestasi; permintaan dengan If-Match yang sudah usang ditolak 412.
+ALTER TABLE achievement_references ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,13 @@
+-- Poin final ditulis ke MongoDB setelah verifikasi di Postgres di-commit.
+-- Baris di sini dibuat dalam transaksi verifikasi yang sama dan baru dihapus
+-- setelah penulisan ke MongoDB berhasil, sehingga kegagalan bisa diulang
+-- oleh job sinkronisasi tanpa kehilangan poin.
+CREATE TABLE IF NOT EXISTS achievement_points_sync (
+    achievement_reference_id UUID PRIMARY KEY REFERENCES achievement_references(id) ON DELETE CASCADE,
+    mongo_achievement_id     VARCHAR(24) NOT NULL,
+    points                   NUMERIC(10, 2) NOT NULL,
+    attempts                 INT NOT NULL DEFAULT 0,
+    last_error               TEXT,
+    created_at               TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at               TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,29 @@
+-- Status registrasi mandiri disimpan terpisah dari users.is_active agar akun
+-- yang sengaja dinonaktifkan admin tidak muncul lagi sebagai registrasi yang
+-- menunggu dan tidak bisa diaktifkan ulang lewat approve. NULL untuk akun
+-- yang tidak dibuat lewat registrasi mandiri.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS registration_status VARCHAR(20);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'users_registration_status_check') THEN
+        ALTER TABLE users
+            ADD CONSTRAINT users_registration_status_check
+            CHECK (registration_status IN ('pending', 'approved'));
+    END IF;
+END $$;
+
+-- Akun hasil registrasi yang sudah pernah aktif atau pernah disetujui
+-- dianggap approved; sisanya masih menunggu.
+UPDATE users u
+SET registration_status = CASE
+        WHEN u.is_active OR EXISTS (
+            SELECT 1 FROM security_events e
+            WHERE e.user_id = u.id AND e.event_type = 'registration_approved'
+        ) THEN 'approved'
+        ELSE 'pending'
+    END
+FROM registration_allowlist a
+WHERE a.registered_user_id = u.id
+  AND u.registration_status IS NULL;
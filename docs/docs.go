@@ -15,100 +15,74 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/achievements": {
+        "/.well-known/jwks.json": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a list of achievement references for the logged-in student",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Public keys used to verify access tokens. Tokens carry the key ID in the \"kid\" header; retired keys stay listed until their tokens expire.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Auth"
                 ],
-                "summary": "List Achievements",
+                "summary": "JSON Web Key Set",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.AchievementReference"
-                            }
+                            "$ref": "#/definitions/models.JWKSet"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/achievement-types": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new achievement record (Mahasiswa only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the field schema of every registered achievementType",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
-                ],
-                "summary": "Create Achievement",
-                "parameters": [
-                    {
-                        "description": "Achievement Data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Achievement"
-                        }
-                    }
+                    "Achievement Types"
                 ],
+                "summary": "List achievement type schemas",
                 "responses": {
-                    "201": {
-                        "description": "Returns Mongo ID",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AchievementTypeSchema"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/v1/achievements/{id}": {
+        "/api/v1/achievement-types/{type}/schema": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve full details of an achievement from MongoDB",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the required and allowed details/customFields of an achievementType, with types, enums and formats, for rendering forms",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Get Achievement Detail",
+                "summary": "Get achievement type schema",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Mongo Achievement ID",
-                        "name": "id",
+                        "description": "Achievement type",
+                        "name": "type",
                         "in": "path",
                         "required": true
                     }
@@ -117,7 +91,16 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Achievement"
+                            "$ref": "#/definitions/models.AchievementTypeSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement type schema not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
@@ -128,7 +111,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update draft achievement details (Mahasiswa only)",
+                "description": "Replace the whole schema of an achievementType. Details fields must be fields of the achievement details and take their type from it; customFields need an explicit type. Applies to achievements created or updated afterwards (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -136,24 +119,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Update Achievement",
+                "summary": "Create or replace achievement type schema",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Mongo Achievement ID",
-                        "name": "id",
+                        "description": "Achievement type",
+                        "name": "type",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Achievement Data",
+                        "description": "Field specifications",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Achievement"
+                            "$ref": "#/definitions/models.AchievementTypeSchemaRequest"
                         }
                     }
                 ],
@@ -161,7 +144,16 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.AchievementTypeSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid field specification",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
@@ -172,22 +164,19 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Soft delete achievement from system (Mahasiswa only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove an achievementType from the registry; new achievements of that type are rejected afterwards (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Delete Achievement",
+                "summary": "Delete achievement type schema",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Mongo Achievement ID",
-                        "name": "id",
+                        "description": "Achievement type",
+                        "name": "type",
                         "in": "path",
                         "required": true
                     }
@@ -196,22 +185,63 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement type schema not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/achievements/{id}/attachments": {
+        "/api/v1/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of achievement references for the logged-in student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "List Achievements",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AchievementReference"
+                            }
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Upload PDF evidence file for an achievement",
+                "description": "Create a new achievement record (Mahasiswa only)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -219,44 +249,45 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Add Attachment (Upload PDF)",
+                "summary": "Create Achievement",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Mongo Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "PDF File to upload",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Achievement Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Achievement"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "message \u0026 url",
+                        "description": "Returns Mongo ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "422": {
+                        "description": "Details or customFields do not match the achievementType schema",
+                        "schema": {
+                            "$ref": "#/definitions/models.SchemaValidationError"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/achievements/{id}/history": {
-            "get": {
+        "/api/v1/achievements/bulk-reject": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve status history and metadata of an achievement",
+                "description": "Reject many achievements at once, each with its own note. Each item is checked and transitioned on its own; the response reports ok or the error for every item; every item needs ifMatch (the ETag from GET) and fails with 428 without it, or 412 if the achievement changed since that ETag was read (Dosen Wali only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,34 +297,45 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get Achievement History",
+                "summary": "Bulk Reject Achievements",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Mongo Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Items to reject (max 200)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkRejectRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AchievementReference"
+                            "$ref": "#/definitions/models.BulkActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/achievements/{id}/reject": {
+        "/api/v1/achievements/bulk-verify": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Reject achievement with a note (Dosen Wali only)",
+                "description": "Approve many achievements at once with per-item points and notes. Each item is checked and transitioned on its own; the response reports ok or the error for every item; every item needs ifMatch (the ETag from GET) and fails with 428 without it, or 412 if the achievement changed since that ETag was read (Dosen Wali only). Items whose result has pointsSyncPending are verified; their points are retried in the background",
                 "consumes": [
                     "application/json"
                 ],
@@ -303,22 +345,15 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Reject Achievement",
+                "summary": "Bulk Verify Achievements",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Mongo Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Rejection note",
+                        "description": "Items to verify (max 200)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/models.BulkVerifyRequest"
                         }
                     }
                 ],
@@ -326,20 +361,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.BulkActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/achievements/{id}/submit": {
-            "post": {
+        "/api/v1/achievements/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Change status from Draft to Submitted for verification",
+                "description": "Retrieve full details of an achievement from MongoDB",
                 "consumes": [
                     "application/json"
                 ],
@@ -349,7 +393,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Submit Achievement",
+                "summary": "Get Achievement Detail",
                 "parameters": [
                     {
                         "type": "string",
@@ -357,28 +401,52 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response; 304 if unchanged",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Achievement"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current achievement version, for If-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/achievements/{id}/verify": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Approve achievement and assign points (Dosen Wali only)",
+                "description": "Partially update a draft or revision-requested achievement (Mahasiswa only). Send application/merge-patch+json (RFC 7396; plain application/json is treated the same) or application/json-patch+json (RFC 6902). points, studentId, status fields, attachments and system fields are read-only. The ETag from GET must be sent as If-Match; the update is rejected when the achievement changed in the meantime",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
                 ],
                 "produces": [
                     "application/json"
@@ -386,7 +454,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Verify Achievement",
+                "summary": "Update Achievement",
                 "parameters": [
                     {
                         "type": "string",
@@ -396,163 +464,97 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Points data",
+                        "description": "Merge patch object or array of JSON Patch operations",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Achievement"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New achievement version"
+                            }
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/auth/login": {
-            "post": {
-                "description": "Authenticate user and return access \u0026 refresh tokens",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "User Login",
-                "parameters": [
-                    {
-                        "description": "Login Credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "400": {
+                        "description": "Malformed patch or unknown field",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Not the owning student",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Invalid email or password",
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/auth/logout": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Invalidate the current refresh token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "User Logout",
-                "responses": {
-                    "200": {
-                        "description": "Logged out successfully",
+                    },
+                    "409": {
+                        "description": "Status does not allow this action, or a JSON Patch test operation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/auth/profile": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieve the logged-in user's profile information",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Get Current Profile",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
                         "schema": {
-                            "$ref": "#/definitions/models.UserData"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "415": {
+                        "description": "Unsupported Content-Type",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/auth/refresh": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a new access token using a valid refresh token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Refresh Token",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "422": {
+                        "description": "Read-only field changed, or details/customFields do not match the achievementType schema",
                         "schema": {
-                            "$ref": "#/definitions/models.RefreshTokenResponse"
+                            "$ref": "#/definitions/models.SchemaValidationError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "428": {
+                        "description": "If-Match header missing",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -561,47 +563,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/lecturers": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get a list of all lecturers in the system",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Students \u0026 Lecturers"
-                ],
-                "summary": "List All Lecturers",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.LecturerDetailResponse"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/lecturers/{id}/advisees": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all students assigned to a specific lecturer",
+                "description": "Soft delete achievement from system (Mahasiswa only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -609,13 +578,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students \u0026 Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Get Lecturer Advisees",
+                "summary": "Delete Achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer UUID",
+                        "description": "Mongo Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -625,56 +594,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.StudentDetailResponse"
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owning student",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/reports/statistics": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieve statistics based on role (Admin: Global, Dosen Wali: Advisees, Mahasiswa: Own).",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Reports"
-                ],
-                "summary": "Get Achievement Statistics",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start",
-                        "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
                         "schema": {
-                            "$ref": "#/definitions/models.AchievementStatisticsResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "403": {
-                        "description": "Invalid role or access denied",
+                    "409": {
+                        "description": "Status does not allow this action",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -683,93 +625,218 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/reports/student/{id}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get total points and student details (Accessible by Mhs for own, Dosen for advisees).",
+                "description": "Partially update a draft or revision-requested achievement (Mahasiswa only). Send application/merge-patch+json (RFC 7396; plain application/json is treated the same) or application/json-patch+json (RFC 6902). points, studentId, status fields, attachments and system fields are read-only. The ETag from GET must be sent as If-Match; the update is rejected when the achievement changed in the meantime",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Achievements"
                 ],
-                "summary": "Get Student Individual Report",
+                "summary": "Update Achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student UUID",
+                        "description": "Mongo Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Merge patch object or array of JSON Patch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StudentReportResponse"
+                            "$ref": "#/definitions/models.Achievement"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New achievement version"
+                            }
                         }
                     },
-                    "403": {
-                        "description": "Access denied",
+                    "400": {
+                        "description": "Malformed patch or unknown field",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
+                    },
+                    "403": {
+                        "description": "Not the owning student",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Status does not allow this action, or a JSON Patch test operation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Content-Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Read-only field changed, or details/customFields do not match the achievementType schema",
+                        "schema": {
+                            "$ref": "#/definitions/models.SchemaValidationError"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/students": {
-            "get": {
+        "/api/v1/achievements/{id}/attachments": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a list of all students with their advisors (Admin only)",
+                "description": "Upload PDF evidence file for an achievement",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students \u0026 Lecturers"
+                    "Achievements"
+                ],
+                "summary": "Add Attachment (Upload PDF)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Mongo Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "PDF File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "List All Students",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "message \u0026 url",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.StudentDetailResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owning student",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Status does not allow this action",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/v1/students/{id}": {
+        "/api/v1/achievements/{id}/history": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get detailed information of a student by their UUID",
+                "description": "Retrieve the achievement reference and its full status timeline (who changed what, when, with notes and points)",
                 "consumes": [
                     "application/json"
                 ],
@@ -777,13 +844,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students \u0026 Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Get Student Detail",
+                "summary": "Get Achievement History",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student UUID",
+                        "description": "Mongo Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -793,34 +860,40 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StudentDetailResponse"
+                            "$ref": "#/definitions/models.AchievementHistoryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/students/{id}/achievements": {
+        "/api/v1/achievements/{id}/points-suggestion": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all achievement status and points for a specific student",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Compute suggested points for an achievement from the rubric in effect when it was submitted (or now, if not yet submitted). points is null when no rubric rule matches",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students \u0026 Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Get Student Achievements",
+                "summary": "Get Points Suggestion",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student UUID",
+                        "description": "Mongo Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -830,23 +903,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.StudentAchievementResponse"
+                            "$ref": "#/definitions/models.PointsSuggestion"
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/api/v1/students/{id}/advisor": {
-            "put": {
+        "/api/v1/achievements/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Assign a lecturer as an advisor to a student (Admin only)",
+                "description": "Reject achievement with a note (Dosen Wali only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -854,30 +933,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students \u0026 Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Assign/Update Advisor",
+                "summary": "Reject Achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student UUID",
+                        "description": "Mongo Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Lecturer ID",
+                        "description": "Rejection note",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateAdvisorRequest"
+                            "type": "object"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being rejected",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: advisor updated successfully",
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an approver for the current step",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Status does not allow this action",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -888,14 +1016,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users": {
-            "get": {
+        "/api/v1/achievements/{id}/request-revision": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of all registered users",
+                "description": "Send a submitted achievement back to the student for changes; the student can edit and resubmit it (Dosen Wali only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -903,70 +1031,141 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "List all users",
+                "summary": "Request Achievement Revision",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Mongo Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Items per page (default: 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Revision note",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being sent back",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an approver for the current step",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Create a new user record in the system (Admin only)",
-                "consumes": [
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Status does not allow this action",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/achievements/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change status from Draft (or Revision Requested) to Submitted for verification",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Create a new user",
+                "summary": "Submit Achievement",
                 "parameters": [
                     {
-                        "description": "User Data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Mongo Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being submitted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Not the owning student",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found or outside the caller's scope",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -975,7 +1174,25 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Email/Username already taken",
+                        "description": "Status does not allow this action",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -986,14 +1203,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{id}": {
-            "get": {
+        "/api/v1/achievements/{id}/verify": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information of a specific user",
+                "description": "Approve the current approval step. Achievements without a configured approval workflow are verified in one step; otherwise points are finalized only when the last step approves (earlier steps record them as a proposal). Omitted points default to the rubric suggestion; points that differ from it require a justification and are recorded as an override. pointsSyncPending means the achievement is verified but its points are not yet written to the achievement document; the write is retried in the background",
                 "consumes": [
                     "application/json"
                 ],
@@ -1001,27 +1218,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Verify Achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UUID",
+                        "description": "Mongo Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Optional points, justification (required when overriding) and note",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being verified",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserResponse"
+                            "$ref": "#/definitions/models.ApprovalResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an approver for the current step",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Achievement not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Status does not allow this action",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1030,14 +1299,42 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/api/v1/approval-workflows": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all multi-level approval workflows with their steps (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approval Workflows"
+                ],
+                "summary": "List approval workflows",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ApprovalWorkflow"
+                            }
+                        }
+                    }
+                }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update user personal information",
+                "description": "Create an approval chain for an achievementType and/or details.competitionLevel; omitted keys match any value and the most specific workflow wins (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1045,30 +1342,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Approval Workflows"
                 ],
-                "summary": "Update user",
+                "summary": "Create approval workflow",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated User Data",
+                        "description": "Workflow and ordered steps",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateUserRequest"
+                            "$ref": "#/definitions/models.ApprovalWorkflowRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User updated successfully",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApprovalWorkflow"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid steps or unknown approver role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Workflow for this type and level already exists",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1077,28 +1382,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/approval-workflows/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Remove user from the system",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve an approval workflow with its steps (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Approval Workflows"
                 ],
-                "summary": "Delete user",
+                "summary": "Get approval workflow",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UUID",
+                        "description": "Approval workflow UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1106,7 +1410,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApprovalWorkflow"
+                        }
+                    },
+                    "404": {
+                        "description": "Approval workflow not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1115,16 +1425,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/users/{id}/role": {
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Change the access role of a user",
+                "description": "Replace an approval workflow and all of its steps. Steps (order, approver role or scope) cannot change while submitted achievements are in this workflow; name, matching keys and isActive only affect new submissions (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1132,30 +1440,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Approval Workflows"
                 ],
-                "summary": "Update user role",
+                "summary": "Replace approval workflow",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UUID",
+                        "description": "Approval workflow UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Role Data",
+                        "description": "Workflow and ordered steps",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateUserRoleRequest"
+                            "$ref": "#/definitions/models.ApprovalWorkflowRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User role updated successfully",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApprovalWorkflow"
+                        }
+                    },
+                    "404": {
+                        "description": "Approval workflow not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Workflow for this type and level already exists, or steps changed while in use",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1164,333 +1487,5119 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.Achievement": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an approval workflow. Refused while submitted achievements are in it; finished achievements keep their history (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approval Workflows"
+                ],
+                "summary": "Delete approval workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Approval workflow UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Approval workflow not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Workflow is in use by submitted achievements",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/forgot-password": {
+            "post": {
+                "description": "Send a one-time password reset link to the user's email. The response is the same whether or not the email is registered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Forgot Password",
+                "parameters": [
+                    {
+                        "description": "Registered email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/login": {
+            "post": {
+                "description": "Authenticate user and return access \u0026 refresh tokens. If the account uses MFA (or its role requires MFA), an MFA challenge token is returned instead; finish with /auth/mfa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User Login",
+                "parameters": [
+                    {
+                        "description": "Login Credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too many login attempts",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Invalidate the current refresh token and every token rotated from it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User Logout",
+                "responses": {
+                    "200": {
+                        "description": "Logged out successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke every active session of the current user, including access tokens already issued",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Log Out Everywhere",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Turn off MFA for the current user. Not allowed for roles where MFA is mandatory.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Disable MFA",
+                "parameters": [
+                    {
+                        "description": "TOTP or recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "MFA is mandatory for this role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/enable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Confirm enrollment with a code from the authenticator app and receive one-time recovery codes. The recovery codes are shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Enable MFA",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace all recovery codes with a new set. Requires a current TOTP code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Regenerate MFA Recovery Codes",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/setup": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generate a new TOTP secret and otpauth:// provisioning URI (render it as a QR code). Accepts an access token or the enrollment challenge token from login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Start MFA Enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MFASetupResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA already enabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/verify": {
+            "post": {
+                "description": "Complete a two-step login with the MFA challenge token and a TOTP or recovery code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify MFA Code",
+                "parameters": [
+                    {
+                        "description": "Challenge token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MFAVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid code or expired challenge",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "423": {
+                        "description": "Account temporarily locked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/oidc/authorize": {
+            "get": {
+                "description": "Start an OpenID Connect authorization code + PKCE login. Redirect the browser to authorizationUrl; the identity provider returns to the front end with code and state, which are then posted to /auth/oidc/callback. The state is also set in an HttpOnly cookie, so the callback must be sent from the same browser with credentials.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Start SSO login",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OIDCAuthorizeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "SSO login is disabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Identity provider unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/oidc/callback": {
+            "post": {
+                "description": "Exchange the authorization code from the identity provider for access \u0026 refresh tokens. The identity is linked to an existing account by NIM or verified email, or a Mahasiswa account is provisioned when enabled. Like /auth/login, an MFA challenge may be returned instead. The state must match the HttpOnly cookie set by /auth/oidc/authorize.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Finish SSO login",
+                "parameters": [
+                    {
+                        "description": "Code and state from the identity provider",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OIDCCallbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid state or SSO authentication failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "No account for this identity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Account already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/password-policy": {
+            "get": {
+                "description": "Rules that new passwords must satisfy, for display in sign-up and reset forms",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get password policy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PasswordPolicy"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the logged-in user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get Current Profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserData"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Exchange a valid refresh token for a new access token and a new refresh token. The old refresh token is retired; reusing it revokes the whole session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh Token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/register": {
+            "post": {
+                "description": "Self-registration for students whose NIM and program study are on the admin-uploaded allow-list. A verification link is emailed; the account stays inactive until the email is verified and an admin approves it. An expired link can be replaced via /auth/resend-verification. Only available when SELF_REGISTRATION_ENABLED is true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register (students)",
+                "parameters": [
+                    {
+                        "description": "Account and student data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data or password policy violations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "NIM not eligible",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Self-registration disabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Username, email or NIM already registered",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/resend-verification": {
+            "post": {
+                "description": "Send a new email verification link for a self-registration that is still waiting for email verification; earlier unused links stop working. The response is the same whether or not the email belongs to such a registration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Resend Verification Email",
+                "parameters": [
+                    {
+                        "description": "Registered email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Self-registration disabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/reset-password": {
+            "post": {
+                "description": "Set a new password using a reset token. The token can only be used once and all active sessions are revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset Password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired reset token, or password policy violations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List active sessions (one per login) of the current user with created time, last used time, IP and user agent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "List My Sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Session"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke one of the current user's sessions. Its access tokens are rejected immediately; other sessions of the user must refresh once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Revoke My Session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/verify-email": {
+            "post": {
+                "description": "Confirm the email address of a self-registered account using the emailed token. The account still needs admin approval.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify Email",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/catalogs/{catalog}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the values of a catalog (achievement_type, competition_level, medal_type, publication_type) in sort order, with labels in the requested locale. The locale comes from the locale query or Accept-Language and falls back to Indonesian",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogs"
+                ],
+                "summary": "Get master data catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Catalog name",
+                        "name": "catalog",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label locale, e.g. id or en",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deactivated values",
+                        "name": "includeInactive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CatalogItem"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catalog not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/catalogs/{catalog}/{code}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create or replace a catalog value with its localized labels and aliases. Aliases are matched case-insensitively when achievements are written and by the normalization command; set isActive to false to stop accepting a value (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogs"
+                ],
+                "summary": "Create or replace catalog value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Catalog name",
+                        "name": "catalog",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Value code stored on achievements",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Labels, aliases and sort order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code or alias already in use",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catalog not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lecturers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of all lecturers in the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students \u0026 Lecturers"
+                ],
+                "summary": "List All Lecturers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LecturerDetailResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lecturers/me/queue": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submitted achievements of the caller's advisees that are waiting for the caller's approval, with Mongo title and type, days waiting and an SLA overdue flag (Dosen Wali only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Advisor Verification Queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by achievementType",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by details.competitionLevel",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student UUID",
+                        "name": "studentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Waiting at least this many days",
+                        "name": "minDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Waiting at most this many days",
+                        "name": "maxDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "submittedAt (default), title, type or student",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VerificationQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lecturers/{id}/advisees": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all students assigned to a specific lecturer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students \u0026 Lecturers"
+                ],
+                "summary": "Get Lecturer Advisees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StudentDetailResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all permissions (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "List permissions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Permission"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new permission named \"resource:action\" (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Create permission",
+                "parameters": [
+                    {
+                        "description": "Permission Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Permission"
+                        }
+                    },
+                    "409": {
+                        "description": "Permission already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permissions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a single permission (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Get permission by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Permission"
+                        }
+                    },
+                    "404": {
+                        "description": "Permission not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update resource, action and description of a permission (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Update permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Permission Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Permission"
+                        }
+                    },
+                    "404": {
+                        "description": "Permission not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Permission already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a permission that is not assigned to any role (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Delete permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permission deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Permission not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Permission still assigned to roles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/points-rubrics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all points rubric versions, newest first, without their rules",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points Rubrics"
+                ],
+                "summary": "List points rubric versions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PointsRubric"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Publish a new rubric version with the next version number. It applies to achievements submitted from effectiveFrom until a later version takes effect. Rules with more criteria filled in take precedence (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points Rubrics"
+                ],
+                "summary": "Create points rubric version",
+                "parameters": [
+                    {
+                        "description": "Rubric rules and team factors",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePointsRubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PointsRubric"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rules or team factors",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/points-rubrics/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a points rubric version with its rules and team factors",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points Rubrics"
+                ],
+                "summary": "Get points rubric version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Points rubric UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PointsRubric"
+                        }
+                    },
+                    "404": {
+                        "description": "Points rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a rubric version that has not taken effect yet (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points Rubrics"
+                ],
+                "summary": "Delete points rubric version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Points rubric UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Points rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Rubric already in effect",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/registrations/allowlist": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List NIMs allowed to self-register and whether they have been used (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registration"
+                ],
+                "summary": "List registration allow-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RegistrationAllowlistEntry"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upload a CSV of \"nim,program_study[,academic_year]\" rows (header optional). Existing NIMs are updated; invalid rows are skipped and reported (Admin only)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registration"
+                ],
+                "summary": "Upload registration allow-list",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AllowlistUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/registrations/allowlist/{studentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a NIM so it can no longer be used to self-register (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registration"
+                ],
+                "summary": "Remove NIM from allow-list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "NIM",
+                        "name": "studentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/registrations/pending": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Self-registered accounts waiting for approval, with their email verification status (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registration"
+                ],
+                "summary": "List pending registrations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PendingRegistration"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/registrations/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Activate a self-registered account whose email has been verified (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registration"
+                ],
+                "summary": "Approve registration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No pending registration",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Email not verified yet",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/registrations/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a self-registered account that is waiting for approval and release its NIM, username and email so the student can register again (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Registration"
+                ],
+                "summary": "Reject registration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No pending registration",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve statistics based on role (Admin: Global, Dosen Wali: Advisees, Mahasiswa: Own).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Achievement Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AchievementStatisticsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid role or access denied",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/student/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get total points and student details (Accessible by Mhs for own, Dosen for advisees).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Student Individual Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StudentReportResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all roles with their permissions and number of users (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "List roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RoleResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new role (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create role",
+                "parameters": [
+                    {
+                        "description": "Role Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a role with its permissions (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get role by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update role name and description. Built-in roles cannot be renamed (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Name taken or built-in role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a role that is not assigned to any user. Built-in roles cannot be deleted (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Role in use or built-in role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/permissions": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the permission set of a role. An empty list removes all permissions (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Assign permissions to role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Permission IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AssignPermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown permission",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/service-accounts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all service accounts used by machine clients (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "List service accounts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServiceAccount"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a service account for a machine client such as the faculty dashboard or SIAKAD (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Create service account",
+                "parameters": [
+                    {
+                        "description": "Service account data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccount"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/service-accounts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a service account (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Get service account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccount"
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update description or deactivate a service account. Keys of an inactive account are rejected (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Update service account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Service account data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAccount"
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a service account and all of its API keys (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Delete service account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service account deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/service-accounts/{id}/keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List API keys of a service account with their scope, expiry and last use. Secrets are never returned (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "List API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIKey"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Issue an API key scoped to the given permissions, optionally expiring. Scopes are limited to read access (student:read, report:read, achievement:read) and keys are only accepted on endpoints that opt in. The key is shown only once; send it as \"X-API-Key\" or \"Authorization: Bearer\" (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Create API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Key name, permissions and expiry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown or disallowed permission, or invalid expiry",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/service-accounts/{id}/keys/{keyId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke an API key immediately (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key UUID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API key revoked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service account or key not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Already revoked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/students": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of all students with their advisors (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students \u0026 Lecturers"
+                ],
+                "summary": "List All Students",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StudentDetailResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get detailed information of a student by their UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students \u0026 Lecturers"
+                ],
+                "summary": "Get Student Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StudentDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/students/{id}/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all achievement status and points for a specific student. Students only see their own, advisors only their advisees; other students return 404.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students \u0026 Lecturers"
+                ],
+                "summary": "Get Student Achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StudentAchievementResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found or outside the caller's scope",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/students/{id}/advisor": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Assign a lecturer as an advisor to a student (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students \u0026 Lecturers"
+                ],
+                "summary": "Assign/Update Advisor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lecturer ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: advisor updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve a paginated list of all registered users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new user record in the system (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data or password policy violations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Email/Username already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information of a specific user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update user personal information. A new password must satisfy the password policy and must not repeat a recent password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated User Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Password policy violations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Would leave the system without an active Admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove user from the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Would leave the system without an active Admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mint a short-lived access token to act as another (non-admin) user. The token carries an \"act\" claim naming the admin, cannot be refreshed, and cannot verify, reject or delete. Every write made with it is recorded in the target's security events (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Impersonate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target user UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for impersonating",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ImpersonateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImpersonationResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Target is an admin or caller is already impersonating",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the access role of a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User role updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Would leave the system without an active Admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/security-events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve failed logins, lockouts and other security events of a user (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List user security events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SecurityEvent"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List active sessions of a user (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List user sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Session"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke every active session of a user (Admin only), including access tokens already issued",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Force logout user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/sessions/{sessionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Force logout one session of a user (Admin only). Its access tokens are rejected immediately; other sessions of the user must refresh once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke user session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Clear failed login attempts and remove a temporary lockout (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Unlock user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unlocked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "lastUsedIp": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "serviceAccountId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Achievement": {
+            "type": "object",
+            "required": [
+                "achievementType",
+                "studentId",
+                "title"
+            ],
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Attachment"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customFields": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "$ref": "#/definitions/models.DynamicDetails"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version naik setiap dokumen ditulis; ETag diisi service untuk header\nrespons dan tidak disimpan.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AchievementHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "achievement": {
+                    "$ref": "#/definitions/models.AchievementReference"
+                },
+                "pointsOverrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PointsOverride"
+                    }
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AchievementStatusHistory"
+                    }
+                }
+            }
+        },
+        "models.AchievementPeriodStat": {
+            "type": "object",
+            "properties": {
+                "period": {
+                    "description": "contoh: 2024-01",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AchievementReference": {
+            "type": "object",
+            "properties": {
+                "approvalWorkflowId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentStep": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mongoAchievementId": {
+                    "type": "string"
+                },
+                "rejectionNote": {
+                    "type": "string"
+                },
+                "revisionNote": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.AchievementStatus"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verifiedAt": {
+                    "type": "string"
+                },
+                "verifiedBy": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AchievementStatisticsResponse": {
+            "type": "object",
+            "properties": {
+                "byPeriod": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AchievementPeriodStat"
+                    }
+                },
+                "byType": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AchievementTypeStat"
+                    }
+                },
+                "competitionLevels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CompetitionLevelStat"
+                    }
+                },
+                "topStudents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TopStudentStat"
+                    }
+                }
+            }
+        },
+        "models.AchievementStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "submitted",
+                "verified",
+                "rejected",
+                "revision_requested",
+                "deleted"
+            ],
+            "x-enum-varnames": [
+                "StatusDraft",
+                "StatusSubmitted",
+                "StatusVerified",
+                "StatusRejected",
+                "StatusRevisionRequested",
+                "StatusDeleted"
+            ]
+        },
+        "models.AchievementStatusHistory": {
+            "type": "object",
+            "properties": {
+                "achievementReferenceId": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "type": "string"
+                },
+                "actorName": {
+                    "type": "string"
+                },
+                "actorRole": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fromStatus": {
+                    "$ref": "#/definitions/models.AchievementStatus"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "toStatus": {
+                    "$ref": "#/definitions/models.AchievementStatus"
+                }
+            }
+        },
+        "models.AchievementTypeSchema": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "allowUnknownCustomFields": {
+                    "type": "boolean"
+                },
+                "customFields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldSpec"
+                    }
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldSpec"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementTypeSchemaRequest": {
+            "type": "object",
+            "properties": {
+                "allowUnknownCustomFields": {
+                    "type": "boolean"
+                },
+                "customFields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldSpec"
+                    }
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldSpec"
+                    }
+                }
+            }
+        },
+        "models.AchievementTypeStat": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AllowlistUploadResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "imported": {
+                            "type": "integer"
+                        },
+                        "skipped": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApprovalResult": {
+            "type": "object",
+            "properties": {
+                "currentStep": {
+                    "type": "integer"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "nextStep": {
+                    "$ref": "#/definitions/models.ApprovalWorkflowStep"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "pointsSyncPending": {
+                    "description": "PointsSyncPending berarti prestasi sudah verified tetapi poin belum\ntertulis ke data prestasi; job sinkronisasi akan mengulanginya.",
+                    "type": "boolean"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.AchievementStatus"
+                },
+                "suggestion": {
+                    "$ref": "#/definitions/models.PointsSuggestion"
+                }
+            }
+        },
+        "models.ApprovalWorkflow": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "competitionLevel": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ApprovalWorkflowStep"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApprovalWorkflowRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "steps"
+            ],
+            "properties": {
+                "achievementType": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "competitionLevel": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "steps": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.ApprovalWorkflowStepRequest"
+                    }
+                }
+            }
+        },
+        "models.ApprovalWorkflowStep": {
+            "type": "object",
+            "properties": {
+                "approverRole": {
+                    "type": "string"
+                },
+                "approverScope": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "stepOrder": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ApprovalWorkflowStepRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "approverRole": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "approverScope": {
+                    "type": "string",
+                    "enum": [
+                        "advisor",
+                        "program"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "models.AssignPermissionsRequest": {
+            "type": "object",
+            "required": [
+                "permissionIds"
+            ],
+            "properties": {
+                "permissionIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Attachment": {
+            "type": "object",
+            "required": [
+                "fileName",
+                "fileUrl"
+            ],
+            "properties": {
+                "fileName": {
+                    "type": "string"
+                },
+                "fileType": {
+                    "type": "string"
+                },
+                "fileUrl": {
+                    "type": "string"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BulkActionResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkItemResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ok": {
+                    "type": "boolean"
+                },
+                "result": {
+                    "$ref": "#/definitions/models.ApprovalResult"
+                }
+            }
+        },
+        "models.BulkRejectItem": {
+            "type": "object",
+            "required": [
+                "id",
+                "note"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "ifMatch": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BulkRejectRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.BulkRejectItem"
+                    }
+                }
+            }
+        },
+        "models.BulkVerifyItem": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "ifMatch": {
+                    "type": "string"
+                },
+                "justification": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.BulkVerifyRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.BulkVerifyItem"
+                    }
+                }
+            }
+        },
+        "models.CatalogItem": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "catalog": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sortOrder": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CatalogItemRequest": {
+            "type": "object",
+            "required": [
+                "aliases",
+                "labels"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sortOrder": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CompetitionLevelStat": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "permissions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "apiKey": {
+                            "$ref": "#/definitions/models.APIKey"
+                        },
+                        "key": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreatePermissionRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "resource"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "description": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.CreatePointsRubricRequest": {
+            "type": "object",
+            "required": [
+                "effectiveFrom",
+                "name",
+                "rules"
+            ],
+            "properties": {
+                "effectiveFrom": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "rules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.PointsRubricRule"
+                    }
+                },
+                "teamFactors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamFactor"
+                    }
+                }
+            }
+        },
+        "models.CreateRoleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.CreateServiceAccountRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "models.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "fullName",
+                "password",
+                "roleId",
+                "username"
+            ],
+            "properties": {
+                "academicYear": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "fullName": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "lecturerId": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "programStudy": {
+                    "type": "string"
+                },
+                "roleId": {
+                    "type": "string"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.DynamicDetails": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "certificationName": {
+                    "type": "string"
+                },
+                "certificationNumber": {
+                    "type": "string"
+                },
+                "competitionLevel": {
+                    "type": "string"
+                },
+                "competitionName": {
+                    "type": "string"
+                },
+                "eventDate": {
+                    "type": "string"
+                },
+                "issn": {
+                    "type": "string"
+                },
+                "issuedBy": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "medalType": {
+                    "type": "string"
+                },
+                "organizationName": {
+                    "type": "string"
+                },
+                "organizer": {
+                    "type": "string"
+                },
+                "period": {
+                    "$ref": "#/definitions/models.Period"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "publicationTitle": {
+                    "type": "string"
+                },
+                "publicationType": {
+                    "type": "string"
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "teamSize": {
+                    "type": "integer"
+                },
+                "validUntil": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldSpec": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "url",
+                        "date",
+                        "issn"
+                    ]
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "integer",
+                        "number",
+                        "boolean",
+                        "date",
+                        "string[]",
+                        "period"
+                    ]
+                }
+            }
+        },
+        "models.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImpersonateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "expiresAt": {
+                            "type": "string"
+                        },
+                        "impersonator": {
+                            "$ref": "#/definitions/models.TokenActor"
+                        },
+                        "token": {
+                            "type": "string"
+                        },
+                        "user": {
+                            "$ref": "#/definitions/models.UserData"
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "OKP (Ed25519)",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JWK"
+                    }
+                }
+            }
+        },
+        "models.LecturerDetailResponse": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "description": "Diambil dari tabel users",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturerId": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "refreshToken": {
+                            "type": "string"
+                        },
+                        "token": {
+                            "type": "string"
+                        },
+                        "user": {
+                            "$ref": "#/definitions/models.UserData"
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFARecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.MFASetupResponse": {
+            "type": "object",
+            "properties": {
+                "provisioningUri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MFAVerifyRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfaToken"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Code berisi kode TOTP 6 digit atau salah satu kode pemulihan.",
+                    "type": "string"
+                },
+                "mfaToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OIDCAuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "authorizationUrl": {
+                            "type": "string"
+                        },
+                        "state": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OIDCCallbackRequest": {
             "type": "object",
             "required": [
-                "achievementType",
-                "studentId",
-                "title"
+                "code",
+                "state"
             ],
             "properties": {
-                "achievementType": {
+                "code": {
                     "type": "string"
                 },
-                "attachments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Attachment"
-                    }
-                },
-                "createdAt": {
+                "state": {
                     "type": "string"
+                }
+            }
+        },
+        "models.PasswordPolicy": {
+            "type": "object",
+            "properties": {
+                "historySize": {
+                    "type": "integer"
                 },
-                "customFields": {
-                    "type": "object",
-                    "additionalProperties": true
+                "maxLength": {
+                    "type": "integer"
                 },
-                "deletedAt": {
+                "minLength": {
+                    "type": "integer"
+                },
+                "requireDigit": {
+                    "type": "boolean"
+                },
+                "requireLowercase": {
+                    "type": "boolean"
+                },
+                "requireSymbol": {
+                    "type": "boolean"
+                },
+                "requireUppercase": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.PendingRegistration": {
+            "type": "object",
+            "properties": {
+                "academicYear": {
                     "type": "string"
                 },
-                "description": {
+                "createdAt": {
                     "type": "string"
                 },
-                "details": {
-                    "$ref": "#/definitions/models.DynamicDetails"
+                "email": {
+                    "type": "string"
                 },
-                "id": {
+                "emailVerifiedAt": {
                     "type": "string"
                 },
-                "is_deleted": {
-                    "type": "boolean"
+                "fullName": {
+                    "type": "string"
                 },
-                "points": {
-                    "type": "number"
+                "programStudy": {
+                    "type": "string"
                 },
                 "studentId": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "title": {
+                "userId": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "models.AchievementPeriodStat": {
+        "models.Period": {
             "type": "object",
             "properties": {
-                "period": {
-                    "description": "contoh: 2024-01",
+                "end": {
                     "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "start": {
+                    "type": "string"
                 }
             }
         },
-        "models.AchievementReference": {
+        "models.Permission": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "action": {
                     "type": "string"
                 },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "mongoAchievementId": {
+                "id": {
+                    "description": "UUID",
                     "type": "string"
                 },
-                "rejectionNote": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/models.AchievementStatus"
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PointsOverride": {
+            "type": "object",
+            "properties": {
+                "achievementReferenceId": {
+                    "type": "string"
                 },
-                "studentId": {
+                "actorId": {
                     "type": "string"
                 },
-                "submittedAt": {
+                "awardedPoints": {
+                    "type": "number"
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "id": {
                     "type": "string"
                 },
-                "verifiedAt": {
+                "justification": {
                     "type": "string"
                 },
-                "verifiedBy": {
+                "rubricId": {
                     "type": "string"
+                },
+                "suggestedPoints": {
+                    "type": "number"
                 }
             }
         },
-        "models.AchievementStatisticsResponse": {
+        "models.PointsRubric": {
             "type": "object",
             "properties": {
-                "byPeriod": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.AchievementPeriodStat"
-                    }
+                "createdAt": {
+                    "type": "string"
                 },
-                "byType": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.AchievementTypeStat"
-                    }
+                "createdBy": {
+                    "type": "string"
                 },
-                "competitionLevels": {
+                "effectiveFrom": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rules": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.CompetitionLevelStat"
+                        "$ref": "#/definitions/models.PointsRubricRule"
                     }
                 },
-                "topStudents": {
+                "teamFactors": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.TopStudentStat"
+                        "$ref": "#/definitions/models.TeamFactor"
                     }
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.AchievementStatus": {
-            "type": "string",
-            "enum": [
-                "draft",
-                "submitted",
-                "verified",
-                "rejected",
-                "deleted"
-            ],
-            "x-enum-varnames": [
-                "StatusDraft",
-                "StatusSubmitted",
-                "StatusVerified",
-                "StatusRejected",
-                "StatusDeleted"
-            ]
-        },
-        "models.AchievementTypeStat": {
+        "models.PointsRubricRule": {
             "type": "object",
+            "required": [
+                "achievementType"
+            ],
             "properties": {
                 "achievementType": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "competitionLevel": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "id": {
                     "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "medalType": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "points": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "publicationType": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "rank": {
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
-        "models.Attachment": {
+        "models.PointsSuggestion": {
             "type": "object",
-            "required": [
-                "fileName",
-                "fileUrl"
-            ],
             "properties": {
-                "fileName": {
-                    "type": "string"
+                "basePoints": {
+                    "type": "number"
                 },
-                "fileType": {
-                    "type": "string"
+                "multiplier": {
+                    "type": "number"
                 },
-                "fileUrl": {
+                "points": {
+                    "type": "number"
+                },
+                "rubricId": {
                     "type": "string"
                 },
-                "uploadedAt": {
+                "rubricVersion": {
+                    "type": "integer"
+                },
+                "ruleId": {
                     "type": "string"
+                },
+                "teamSize": {
+                    "type": "integer"
                 }
             }
         },
-        "models.CompetitionLevelStat": {
+        "models.RefreshTokenResponse": {
             "type": "object",
             "properties": {
-                "level": {
-                    "type": "string"
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "refreshToken": {
+                            "type": "string"
+                        },
+                        "token": {
+                            "type": "string"
+                        }
+                    }
                 },
-                "total": {
-                    "type": "integer"
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "models.CreateUserRequest": {
+        "models.RegisterRequest": {
             "type": "object",
             "required": [
                 "email",
                 "fullName",
                 "password",
-                "roleId",
+                "programStudy",
+                "studentId",
                 "username"
             ],
             "properties": {
                 "academicYear": {
-                    "type": "string"
-                },
-                "department": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 10
                 },
                 "email": {
-                    "type": "string",
-                    "maxLength": 100
+                    "type": "string"
                 },
                 "fullName": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "lecturerId": {
                     "type": "string"
                 },
                 "password": {
-                    "type": "string",
-                    "minLength": 6
-                },
-                "programStudy": {
                     "type": "string"
                 },
-                "roleId": {
-                    "type": "string"
+                "programStudy": {
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "studentId": {
-                    "type": "string"
+                    "description": "Dicocokkan dengan registration_allowlist.",
+                    "type": "string",
+                    "maxLength": 20
                 },
                 "username": {
-                    "type": "string",
-                    "maxLength": 50
+                    "type": "string"
                 }
             }
         },
-        "models.DynamicDetails": {
+        "models.RegisterResponse": {
             "type": "object",
             "properties": {
-                "authors": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "email": {
+                            "type": "string"
+                        },
+                        "username": {
+                            "type": "string"
+                        }
                     }
                 },
-                "certificationName": {
+                "status": {
                     "type": "string"
-                },
-                "certificationNumber": {
+                }
+            }
+        },
+        "models.RegistrationAllowlistEntry": {
+            "type": "object",
+            "properties": {
+                "academicYear": {
                     "type": "string"
                 },
-                "competitionLevel": {
+                "createdAt": {
                     "type": "string"
                 },
-                "competitionName": {
+                "programStudy": {
                     "type": "string"
                 },
-                "eventDate": {
+                "registeredUserId": {
                     "type": "string"
                 },
-                "issn": {
+                "studentId": {
                     "type": "string"
                 },
-                "issuedBy": {
+                "uploadedBy": {
                     "type": "string"
-                },
-                "location": {
+                }
+            }
+        },
+        "models.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
-                },
-                "medalType": {
+                }
+            }
+        },
+        "models.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "newPassword",
+                "token"
+            ],
+            "properties": {
+                "newPassword": {
                     "type": "string"
                 },
-                "organizationName": {
+                "token": {
                     "type": "string"
-                },
-                "organizer": {
+                }
+            }
+        },
+        "models.RoleResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "period": {
-                    "$ref": "#/definitions/models.Period"
-                },
-                "position": {
+                "description": {
                     "type": "string"
                 },
-                "publicationTitle": {
+                "id": {
                     "type": "string"
                 },
-                "publicationType": {
+                "name": {
                     "type": "string"
                 },
-                "publisher": {
-                    "type": "string"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Permission"
+                    }
                 },
-                "rank": {
+                "userCount": {
                     "type": "integer"
+                }
+            }
+        },
+        "models.SchemaValidationError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
                 },
-                "score": {
-                    "type": "number"
-                },
-                "validUntil": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "models.LecturerDetailResponse": {
+        "models.SecurityEvent": {
             "type": "object",
             "properties": {
-                "department": {
+                "createdAt": {
                     "type": "string"
                 },
-                "fullName": {
-                    "description": "Diambil dari tabel users",
+                "detail": {
+                    "type": "string"
+                },
+                "eventType": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "lecturerId": {
+                "ipAddress": {
                     "type": "string"
                 },
                 "userId": {
@@ -1498,69 +6607,54 @@ const docTemplate = `{
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.ServiceAccount": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "createdAt": {
                     "type": "string"
                 },
-                "username": {
+                "createdBy": {
                     "type": "string"
-                }
-            }
-        },
-        "models.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "refreshToken": {
-                            "type": "string"
-                        },
-                        "token": {
-                            "type": "string"
-                        },
-                        "user": {
-                            "$ref": "#/definitions/models.UserData"
-                        }
-                    }
                 },
-                "status": {
+                "description": {
                     "type": "string"
-                }
-            }
-        },
-        "models.Period": {
-            "type": "object",
-            "properties": {
-                "end": {
+                },
+                "id": {
                     "type": "string"
                 },
-                "start": {
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "models.RefreshTokenResponse": {
+        "models.Session": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "properties": {
-                        "refreshToken": {
-                            "type": "string"
-                        },
-                        "token": {
-                            "type": "string"
-                        }
-                    }
+                "createdAt": {
+                    "type": "string"
                 },
-                "status": {
+                "current": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ipAddress": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "userAgent": {
                     "type": "string"
                 }
             }
@@ -1622,6 +6716,30 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TeamFactor": {
+            "type": "object",
+            "properties": {
+                "minTeamSize": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "multiplier": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.TokenActor": {
+            "type": "object",
+            "properties": {
+                "sub": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "models.TopStudentStat": {
             "type": "object",
             "properties": {
@@ -1644,6 +6762,52 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdatePermissionRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "resource"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "description": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.UpdateRoleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.UpdateServiceAccountRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                }
+            }
+        },
         "models.UpdateUserRequest": {
             "type": "object",
             "properties": {
@@ -1658,6 +6822,9 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
+                "password": {
+                    "type": "string"
+                },
                 "roleId": {
                     "type": "string"
                 },
@@ -1707,6 +6874,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "failedLoginAttempts": {
+                    "type": "integer"
+                },
                 "fullName": {
                     "type": "string"
                 },
@@ -1716,6 +6886,9 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
+                "lockedUntil": {
+                    "type": "string"
+                },
                 "permissions": {
                     "type": "array",
                     "items": {
@@ -1729,6 +6902,75 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.VerificationQueueItem": {
+            "type": "object",
+            "properties": {
+                "achievementType": {
+                    "type": "string"
+                },
+                "competitionLevel": {
+                    "type": "string"
+                },
+                "currentStep": {
+                    "type": "integer"
+                },
+                "daysWaiting": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mongoAchievementId": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "studentName": {
+                    "type": "string"
+                },
+                "studentNim": {
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.VerificationQueueResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VerificationQueueItem"
+                    }
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "slaDays": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
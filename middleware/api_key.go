@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"backend/app/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeySource memeriksa API key service account (biasanya
+// service.ServiceAccountService).
+type APIKeySource interface {
+	Authenticate(ctx context.Context, rawKey string, ip string) (*models.JWTClaims, error)
+}
+
+var apiKeySource APIKeySource
+
+// UseAPIKeySource dipanggil sekali saat startup. Tanpa source, API key
+// tidak diterima.
+func UseAPIKeySource(source APIKeySource) {
+	apiKeySource = source
+}
+
+// JWTOrAPIKeyMiddleware menerima access token seperti JWTMiddleware, atau
+// API key service account lewat header X-API-Key maupun
+// "Authorization: Bearer spp_...". Permission diambil dari scope key.
+func JWTOrAPIKeyMiddleware() fiber.Handler {
+	jwtHandler := jwtMiddleware()
+
+	return func(c *fiber.Ctx) error {
+		rawKey := apiKeyFromRequest(c)
+		if rawKey == "" || apiKeySource == nil {
+			return jwtHandler(c)
+		}
+
+		claims, err := apiKeySource.Authenticate(c.UserContext(), rawKey, c.IP())
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":  "error",
+				"message": "invalid or expired api key",
+			})
+		}
+
+		c.Locals(UserIDKey, claims.UserID)
+		c.Locals(UsernameKey, claims.Username)
+		c.Locals(RoleKey, claims.Role)
+		c.Locals(PermissionsKey, claims.Permissions)
+		c.Locals(ClaimsKey, claims)
+
+		return c.Next()
+	}
+}
+
+func apiKeyFromRequest(c *fiber.Ctx) string {
+	if key := strings.TrimSpace(c.Get("X-API-Key")); key != "" {
+		return key
+	}
+
+	parts := strings.Fields(c.Get("Authorization"))
+	if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" && strings.HasPrefix(parts[1], models.APIKeyPrefix) {
+		return parts[1]
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"backend/app/models"
+
+	"github.com/google/uuid"
+)
+
+// AuthzSource menyediakan keadaan otorisasi terkini user (biasanya
+// service.AuthzService yang di-cache di memori).
+type AuthzSource interface {
+	Current(ctx context.Context, userID uuid.UUID) (*models.AuthzState, error)
+}
+
+var authzSource AuthzSource
+
+// UseAuthzSource dipanggil sekali saat startup. Tanpa source, claims di
+// token dipercaya apa adanya.
+func UseAuthzSource(source AuthzSource) {
+	authzSource = source
+}
+
+var (
+	errAccountInactive = errors.New("account is no longer active")
+	errTokenOutdated   = errors.New("token is outdated, please refresh")
+)
+
+// reconcileClaims mencocokkan versi di token dengan keadaan terkini. Jika
+// hanya permission role yang berubah, claims diperbarui di tempat; jika role
+// atau status user berubah, token ditolak agar klien melakukan refresh.
+func reconcileClaims(ctx context.Context, claims *models.JWTClaims) error {
+	if authzSource == nil {
+		return nil
+	}
+
+	state, err := authzSource.Current(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if state == nil || !state.IsActive {
+		return errAccountInactive
+	}
+
+	if claims.UserVersion != state.UserVersion || claims.Role != state.RoleName {
+		return errTokenOutdated
+	}
+
+	if claims.RoleVersion != state.RoleVersion {
+		claims.Permissions = state.Permissions
+		claims.RoleVersion = state.RoleVersion
+	}
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package middleware
 
 import (
-	"fmt"
+	"errors"
+	"log"
 	"strings"
 
+	"backend/app/models"
 	"backend/app/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,9 +16,24 @@ const (
 	UsernameKey    = "username"
 	RoleKey        = "role"
 	PermissionsKey = "permissions"
+	SessionIDKey   = "sessionID"
+	ClaimsKey      = "claims"
 )
 
 func JWTMiddleware() fiber.Handler {
+	return jwtMiddleware()
+}
+
+// MFAEnrollmentMiddleware menerima access token biasa atau token tantangan
+// enrollment MFA dari login, sehingga user yang wajib MFA dapat mendaftarkan
+// authenticator sebelum mendapatkan access token.
+func MFAEnrollmentMiddleware() fiber.Handler {
+	return jwtMiddleware(models.TokenPurposeMFAEnroll)
+}
+
+// jwtMiddleware memverifikasi bearer token. Token dengan purpose (refresh,
+// tantangan MFA) hanya diterima jika purpose tersebut ada di allowedPurposes.
+func jwtMiddleware(allowedPurposes ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 
 		authHeader := c.Get("Authorization")
@@ -39,18 +56,52 @@ func JWTMiddleware() fiber.Handler {
 
 		claims, err := utils.VerifyAccessToken(tokenString)
 		if err != nil {
-			fmt.Println("JWT VERIFY ERROR:", err.Error())
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"status":  "error",
 				"message": "invalid or expired token",
 			})
 		}
 
+		if claims.Purpose != "" && !hasPurpose(allowedPurposes, claims.Purpose) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":  "error",
+				"message": "invalid or expired token",
+			})
+		}
+
+		if claims.Purpose == "" {
+			if err := reconcileClaims(c.UserContext(), claims); err != nil {
+				// Hanya alasan yang memang untuk klien yang dikirim; error
+				// lain (mis. database) cukup dicatat di log.
+				message := "invalid or expired token"
+				if errors.Is(err, errAccountInactive) || errors.Is(err, errTokenOutdated) {
+					message = err.Error()
+				} else {
+					log.Println("❌ failed to reconcile token claims:", err)
+				}
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"status":  "error",
+					"message": message,
+				})
+			}
+		}
+
 		c.Locals(UserIDKey, claims.UserID)
 		c.Locals(UsernameKey, claims.Username)
 		c.Locals(RoleKey, claims.Role)
 		c.Locals(PermissionsKey, claims.Permissions)
+		c.Locals(SessionIDKey, claims.SessionID)
+		c.Locals(ClaimsKey, claims)
 
-		return c.Next()
+		return nextWithImpersonationAudit(c, claims)
+	}
+}
+
+func hasPurpose(allowed []string, purpose string) bool {
+	for _, p := range allowed {
+		if p == purpose {
+			return true
+		}
 	}
+	return false
 }
@@ -4,18 +4,18 @@ import (
 	"backend/app/models"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 )
 
+// getClaims membaca claims access token yang disimpan JWTMiddleware di
+// ClaimsKey.
 func getClaims(c *fiber.Ctx) (*models.JWTClaims, error) {
-	userToken := c.Locals("user")
-	if userToken == nil {
+	claims, ok := c.Locals(ClaimsKey).(*models.JWTClaims)
+	if !ok {
 		return nil, fiber.ErrUnauthorized
 	}
 
-	token := userToken.(*jwt.Token)
-	claims, ok := token.Claims.(*models.JWTClaims)
-	if !ok || !token.Valid {
+	// Refresh token dan token tantangan MFA bukan access token.
+	if claims.Purpose != "" {
 		return nil, fiber.ErrUnauthorized
 	}
 
@@ -37,6 +37,32 @@ func OnlyAdmin() fiber.Handler {
 			})
 		}
 
+		c.Locals("user_id", claims.UserID)
+
+		return c.Next()
+	}
+}
+
+// CurrentUser menaruh id user dari token ke c.Locals("user_id") untuk
+// handler yang mencatat pelaku aksi (admin, verifikator). Service account
+// ditolak karena pelaku harus user.
+func CurrentUser() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, err := getClaims(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"message": "Unauthorized",
+			})
+		}
+
+		if claims.APIKeyID != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Service accounts cannot perform this action",
+			})
+		}
+
+		c.Locals("user_id", claims.UserID)
+
 		return c.Next()
 	}
 }
@@ -2,17 +2,91 @@ package routes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"backend/app/models"
+	"backend/app/repository"
 	"backend/app/service"
+	"backend/app/utils"
+	"backend/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// achievementErrorStatus memetakan transisi status yang tidak diizinkan,
+// langkah persetujuan yang sudah berubah, workflow yang sudah hilang dan
+// operasi test JSON Patch yang gagal ke 409 Conflict, If-Match yang sudah
+// usang ke 412 dan yang tidak dikirim ke 428, penyetuju yang bukan gilirannya
+// dan bukan pemilik prestasi ke 403, prestasi di luar scope ke 404; error
+// lain memakai status fallback.
+func achievementErrorStatus(err error, fallback int) int {
+	var transitionErr *models.AchievementTransitionError
+	switch {
+	case errors.Is(err, repository.ErrVersionConflict):
+		return fiber.StatusPreconditionFailed
+	case errors.Is(err, service.ErrIfMatchRequired):
+		return fiber.StatusPreconditionRequired
+	case errors.As(err, &transitionErr), errors.Is(err, repository.ErrApprovalStepChanged), errors.Is(err, utils.ErrPatchTestFailed),
+		errors.Is(err, service.ErrApprovalWorkflowMissing):
+		return fiber.StatusConflict
+	case errors.Is(err, service.ErrNotStepApprover), errors.Is(err, service.ErrNotAchievementOwner):
+		return fiber.StatusForbidden
+	case errors.Is(err, service.ErrAchievementNotFound):
+		return fiber.StatusNotFound
+	}
+	return fallback
+}
+
+func achievementError(err error, fallback int) error {
+	return fiber.NewError(achievementErrorStatus(err, fallback), err.Error())
+}
+
+// achievementWriteError mengirim pelanggaran skema tipe prestasi sebagai 422
+// beserta daftar error per field; error lain diteruskan ke achievementError.
+func achievementWriteError(c *fiber.Ctx, err error, fallback int) error {
+	var schemaErr *models.SchemaValidationError
+	if errors.As(err, &schemaErr) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(schemaErr)
+	}
+	return achievementError(err, fallback)
+}
+
+// bulkResponse mengisi kode dan pesan error tiap item lalu menghitung
+// ringkasannya.
+func bulkResponse(results []models.BulkItemResult) models.BulkActionResponse {
+	resp := models.BulkActionResponse{Results: results}
+	for i := range results {
+		if results[i].Err == nil {
+			resp.Succeeded++
+			continue
+		}
+		results[i].Code = achievementErrorStatus(results[i].Err, fiber.StatusBadRequest)
+		results[i].Error = results[i].Err.Error()
+		resp.Failed++
+	}
+	return resp
+}
+
+// achievementActor membaca pemanggil dari claims token untuk pengecekan
+// scope prestasi di service.
+func achievementActor(c *fiber.Ctx) models.AchievementActor {
+	claims, _ := c.Locals(middleware.ClaimsKey).(*models.JWTClaims)
+	if claims == nil {
+		return models.AchievementActor{}
+	}
+	return models.AchievementActor{
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		StudentID: claims.StudentID,
+	}
+}
+
 // listAchievements godoc
 // @Summary      List Achievements
 // @Description  Get a list of achievement references for the logged-in student
@@ -50,7 +124,11 @@ func listAchievements(
 // @Produce      json
 // @Param        id   path      string  true  "Mongo Achievement ID"
 // @Security     ApiKeyAuth
+// @Param        If-None-Match  header  string  false  "ETag from a previous response; 304 if unchanged"
 // @Success      200  {object}  models.Achievement
+// @Header       200  {string}  ETag  "Current achievement version, for If-Match"
+// @Success      304  "Not modified"
+// @Failure      404  {object}  map[string]string "Achievement not found or outside the caller's scope"
 // @Router       /api/v1/achievements/{id} [get]
 func getAchievementDetail(
 	achievementService service.AchievementService,
@@ -58,11 +136,16 @@ func getAchievementDetail(
 	return func(c *fiber.Ctx) error {
 		id := c.Params("id")
 
-		data, err := achievementService.GetByID(context.Background(), id)
+		data, err := achievementService.GetByID(context.Background(), id, achievementActor(c))
 		if err != nil {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		}
 
+		c.Set(fiber.HeaderETag, data.ETag)
+		if c.Get(fiber.HeaderIfNoneMatch) == data.ETag {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
 		return c.JSON(data)
 	}
 }
@@ -76,6 +159,7 @@ func getAchievementDetail(
 // @Param        request  body      models.Achievement  true  "Achievement Data"
 // @Security     ApiKeyAuth
 // @Success      201      {object}  map[string]string "Returns Mongo ID"
+// @Failure      422      {object}  models.SchemaValidationError "Details or customFields do not match the achievementType schema"
 // @Router       /api/v1/achievements [post]
 func createAchievement(
 	achievementService service.AchievementService,
@@ -89,6 +173,7 @@ func createAchievement(
 		}
 
 		studentID := c.Locals("student_id").(uuid.UUID)
+		actorID := c.Locals("user_id").(uuid.UUID)
 		payload.StudentID = studentID.String()
 
 		mongoID, err := achievementService.Create(
@@ -96,13 +181,14 @@ func createAchievement(
 			&payload,
 		)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return achievementWriteError(c, err, fiber.StatusBadRequest)
 		}
 
 		if _, err := refService.Create(
 			context.Background(),
 			studentID,
 			mongoID,
+			actorID,
 		); err != nil {
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
@@ -115,35 +201,58 @@ func createAchievement(
 
 // updateAchievement godoc
 // @Summary      Update Achievement
-// @Description  Update draft achievement details (Mahasiswa only)
+// @Description  Partially update a draft or revision-requested achievement (Mahasiswa only). Send application/merge-patch+json (RFC 7396; plain application/json is treated the same) or application/json-patch+json (RFC 6902). points, studentId, status fields, attachments and system fields are read-only. The ETag from GET must be sent as If-Match; the update is rejected when the achievement changed in the meantime
 // @Tags         Achievements
 // @Accept       json
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
 // @Produce      json
-// @Param        id       path      string             true  "Mongo Achievement ID"
-// @Param        request  body      models.Achievement true  "Updated Achievement Data"
+// @Param        id       path      string  true  "Mongo Achievement ID"
+// @Param        request  body      object  true  "Merge patch object or array of JSON Patch operations"
+// @Param        If-Match  header   string  true   "ETag of the version being updated"
 // @Security     ApiKeyAuth
-// @Success      200      {string}  string  "OK"
+// @Success      200      {object}  models.Achievement
+// @Header       200      {string}  ETag  "New achievement version"
+// @Failure      400      {object}  map[string]string "Malformed patch or unknown field"
+// @Failure      404      {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Failure      403      {object}  map[string]string "Not the owning student"
+// @Failure      409      {object}  map[string]string "Status does not allow this action, or a JSON Patch test operation failed"
+// @Failure      412      {object}  map[string]string "If-Match does not match the current version"
+// @Failure      428      {object}  map[string]string "If-Match header missing"
+// @Failure      415      {object}  map[string]string "Unsupported Content-Type"
+// @Failure      422      {object}  models.SchemaValidationError "Read-only field changed, or details/customFields do not match the achievementType schema"
 // @Router       /api/v1/achievements/{id} [put]
+// @Router       /api/v1/achievements/{id} [patch]
 func updateAchievement(
 	achievementService service.AchievementService,
 ) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		id := c.Params("id")
 
-		var payload models.Achievement
-		if err := c.BodyParser(&payload); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		var kind string
+		switch mediaType := strings.ToLower(strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0])); mediaType {
+		case fiber.MIMEApplicationJSON, "application/merge-patch+json":
+			kind = models.PatchMerge
+		case "application/json-patch+json":
+			kind = models.PatchJSON
+		default:
+			return fiber.NewError(fiber.StatusUnsupportedMediaType, "Content-Type must be application/merge-patch+json or application/json-patch+json")
 		}
 
-		if err := achievementService.Update(
-			context.Background(),
+		achievement, err := achievementService.Patch(
+			c.Context(),
 			id,
-			&payload,
-		); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			kind,
+			c.Body(),
+			achievementActor(c),
+			c.Get(fiber.HeaderIfMatch),
+		)
+		if err != nil {
+			return achievementWriteError(c, err, fiber.StatusBadRequest)
 		}
 
-		return c.SendStatus(fiber.StatusOK)
+		c.Set(fiber.HeaderETag, achievement.ETag)
+		return c.JSON(achievement)
 	}
 }
 
@@ -156,6 +265,9 @@ func updateAchievement(
 // @Param        id   path      string  true  "Mongo Achievement ID"
 // @Security     ApiKeyAuth
 // @Success      200  {string}  string  "OK"
+// @Failure      404  {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Failure      403  {object}  map[string]string "Not the owning student"
+// @Failure      409  {object}  map[string]string "Status does not allow this action"
 // @Router       /api/v1/achievements/{id} [delete]
 func deleteAchievement(
 	refService service.AchievementReferenceService,
@@ -166,8 +278,9 @@ func deleteAchievement(
 		if err := refService.Delete(
 			context.Background(),
 			id,
+			achievementActor(c),
 		); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return achievementError(err, fiber.StatusBadRequest)
 		}
 
 		return c.SendStatus(fiber.StatusOK)
@@ -176,13 +289,19 @@ func deleteAchievement(
 
 // submitAchievement godoc
 // @Summary      Submit Achievement
-// @Description  Change status from Draft to Submitted for verification
+// @Description  Change status from Draft (or Revision Requested) to Submitted for verification
 // @Tags         Achievements
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Mongo Achievement ID"
+// @Param        id        path      string  true   "Mongo Achievement ID"
+// @Param        If-Match  header    string  true   "ETag of the version being submitted"
 // @Security     ApiKeyAuth
 // @Success      200  {string}  string  "OK"
+// @Failure      404  {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Failure      403  {object}  map[string]string "Not the owning student"
+// @Failure      409  {object}  map[string]string "Status does not allow this action"
+// @Failure      412  {object}  map[string]string "If-Match does not match the current version"
+// @Failure      428  {object}  map[string]string "If-Match header missing"
 // @Router       /api/v1/achievements/{id}/submit [post]
 func submitAchievement(
 	refService service.AchievementReferenceService,
@@ -190,8 +309,8 @@ func submitAchievement(
 	return func(c *fiber.Ctx) error {
 		id := c.Params("id")
 
-		if err := refService.Submit(context.Background(), id); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		if err := refService.Submit(context.Background(), id, achievementActor(c), c.Get(fiber.HeaderIfMatch)); err != nil {
+			return achievementError(err, fiber.StatusBadRequest)
 		}
 
 		return c.SendStatus(fiber.StatusOK)
@@ -200,39 +319,73 @@ func submitAchievement(
 
 // verifyAchievement godoc
 // @Summary      Verify Achievement
-// @Description  Approve achievement and assign points (Dosen Wali only)
+// @Description  Approve the current approval step. Achievements without a configured approval workflow are verified in one step; otherwise points are finalized only when the last step approves (earlier steps record them as a proposal). Omitted points default to the rubric suggestion; points that differ from it require a justification and are recorded as an override. pointsSyncPending means the achievement is verified but its points are not yet written to the achievement document; the write is retried in the background
 // @Tags         Achievements
 // @Accept       json
 // @Produce      json
 // @Param        id       path      string  true  "Mongo Achievement ID"
-// @Param        request  body      object  true  "Points data"
+// @Param        request  body      object  true  "Optional points, justification (required when overriding) and note"
+// @Param        If-Match  header   string  true   "ETag of the version being verified"
 // @Security     ApiKeyAuth
-// @Success      200      {string}  string  "OK"
+// @Success      200      {object}  models.ApprovalResult
+// @Failure      404      {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Failure      403      {object}  map[string]string "Not an approver for the current step"
+// @Failure      409      {object}  map[string]string "Status does not allow this action"
+// @Failure      412      {object}  map[string]string "If-Match does not match the current version"
+// @Failure      428      {object}  map[string]string "If-Match header missing"
 // @Router       /api/v1/achievements/{id}/verify [post]
 func verifyAchievement(
 	refService service.AchievementReferenceService,
 ) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		id := c.Params("id")
-		verifierID := c.Locals("user_id").(uuid.UUID)
 
 		var body struct {
-			Points float64 `json:"points"`
+			Points        *float64 `json:"points"`
+			Justification string   `json:"justification"`
+			Note          string   `json:"note"`
 		}
 		if err := c.BodyParser(&body); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, "Invalid points format")
 		}
 
-		if err := refService.Verify(
+		result, err := refService.Verify(
 			c.Context(),
 			id,
-			verifierID,
+			achievementActor(c),
 			body.Points,
-		); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			body.Justification,
+			body.Note,
+			c.Get(fiber.HeaderIfMatch),
+		)
+		if err != nil {
+			return achievementError(err, fiber.StatusBadRequest)
 		}
 
-		return c.SendStatus(fiber.StatusOK)
+		return c.JSON(result)
+	}
+}
+
+// getAchievementPointsSuggestion godoc
+// @Summary      Get Points Suggestion
+// @Description  Compute suggested points for an achievement from the rubric in effect when it was submitted (or now, if not yet submitted). points is null when no rubric rule matches
+// @Tags         Achievements
+// @Produce      json
+// @Param        id   path      string  true  "Mongo Achievement ID"
+// @Security     ApiKeyAuth
+// @Success      200  {object}  models.PointsSuggestion
+// @Failure      404  {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Router       /api/v1/achievements/{id}/points-suggestion [get]
+func getAchievementPointsSuggestion(
+	refService service.AchievementReferenceService,
+) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		suggestion, err := refService.SuggestPoints(c.Context(), c.Params("id"), achievementActor(c))
+		if err != nil {
+			return achievementError(err, fiber.StatusInternalServerError)
+		}
+
+		return c.JSON(suggestion)
 	}
 }
 
@@ -244,8 +397,14 @@ func verifyAchievement(
 // @Produce      json
 // @Param        id       path      string  true  "Mongo Achievement ID"
 // @Param        request  body      object  true  "Rejection note"
+// @Param        If-Match  header   string  true   "ETag of the version being rejected"
 // @Security     ApiKeyAuth
 // @Success      200      {string}  string  "OK"
+// @Failure      404      {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Failure      403      {object}  map[string]string "Not an approver for the current step"
+// @Failure      409      {object}  map[string]string "Status does not allow this action"
+// @Failure      412      {object}  map[string]string "If-Match does not match the current version"
+// @Failure      428      {object}  map[string]string "If-Match header missing"
 // @Router       /api/v1/achievements/{id}/reject [post]
 func rejectAchievement(
 	refService service.AchievementReferenceService,
@@ -264,23 +423,128 @@ func rejectAchievement(
 			context.Background(),
 			id,
 			body.Note,
+			achievementActor(c),
+			c.Get(fiber.HeaderIfMatch),
 		); err != nil {
+			return achievementError(err, fiber.StatusBadRequest)
+		}
+
+		return c.SendStatus(fiber.StatusOK)
+	}
+}
+
+// requestAchievementRevision godoc
+// @Summary      Request Achievement Revision
+// @Description  Send a submitted achievement back to the student for changes; the student can edit and resubmit it (Dosen Wali only)
+// @Tags         Achievements
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string  true  "Mongo Achievement ID"
+// @Param        request  body      object  true  "Revision note"
+// @Param        If-Match  header   string  true   "ETag of the version being sent back"
+// @Security     ApiKeyAuth
+// @Success      200      {string}  string  "OK"
+// @Failure      404      {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Failure      403      {object}  map[string]string "Not an approver for the current step"
+// @Failure      409      {object}  map[string]string "Status does not allow this action"
+// @Failure      412      {object}  map[string]string "If-Match does not match the current version"
+// @Failure      428      {object}  map[string]string "If-Match header missing"
+// @Router       /api/v1/achievements/{id}/request-revision [post]
+func requestAchievementRevision(
+	refService service.AchievementReferenceService,
+) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("id")
+
+		var body struct {
+			Note string `json:"note"`
+		}
+		if err := c.BodyParser(&body); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 
+		if err := refService.RequestRevision(
+			context.Background(),
+			id,
+			body.Note,
+			achievementActor(c),
+			c.Get(fiber.HeaderIfMatch),
+		); err != nil {
+			return achievementError(err, fiber.StatusBadRequest)
+		}
+
 		return c.SendStatus(fiber.StatusOK)
 	}
 }
 
+// bulkVerifyAchievements godoc
+// @Summary      Bulk Verify Achievements
+// @Description  Approve many achievements at once with per-item points and notes. Each item is checked and transitioned on its own; the response reports ok or the error for every item; every item needs ifMatch (the ETag from GET) and fails with 428 without it, or 412 if the achievement changed since that ETag was read (Dosen Wali only). Items whose result has pointsSyncPending are verified; their points are retried in the background
+// @Tags         Achievements
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.BulkVerifyRequest  true  "Items to verify (max 200)"
+// @Security     ApiKeyAuth
+// @Success      200      {object}  models.BulkActionResponse
+// @Failure      400      {object}  map[string]string "Invalid request body"
+// @Router       /api/v1/achievements/bulk-verify [post]
+func bulkVerifyAchievements(
+	refService service.AchievementReferenceService,
+) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body models.BulkVerifyRequest
+		if err := c.BodyParser(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		if err := validate.Struct(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		results := refService.BulkVerify(c.Context(), achievementActor(c), body.Items)
+
+		return c.JSON(bulkResponse(results))
+	}
+}
+
+// bulkRejectAchievements godoc
+// @Summary      Bulk Reject Achievements
+// @Description  Reject many achievements at once, each with its own note. Each item is checked and transitioned on its own; the response reports ok or the error for every item; every item needs ifMatch (the ETag from GET) and fails with 428 without it, or 412 if the achievement changed since that ETag was read (Dosen Wali only)
+// @Tags         Achievements
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.BulkRejectRequest  true  "Items to reject (max 200)"
+// @Security     ApiKeyAuth
+// @Success      200      {object}  models.BulkActionResponse
+// @Failure      400      {object}  map[string]string "Invalid request body"
+// @Router       /api/v1/achievements/bulk-reject [post]
+func bulkRejectAchievements(
+	refService service.AchievementReferenceService,
+) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body models.BulkRejectRequest
+		if err := c.BodyParser(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		if err := validate.Struct(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		results := refService.BulkReject(c.Context(), achievementActor(c), body.Items)
+
+		return c.JSON(bulkResponse(results))
+	}
+}
+
 // achievementHistory godoc
 // @Summary      Get Achievement History
-// @Description  Retrieve status history and metadata of an achievement
+// @Description  Retrieve the achievement reference and its full status timeline (who changed what, when, with notes and points)
 // @Tags         Achievements
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Mongo Achievement ID"
 // @Security     ApiKeyAuth
-// @Success      200  {object}  models.AchievementReference
+// @Success      200  {object}  models.AchievementHistoryResponse
+// @Failure      404  {object}  map[string]string "Achievement not found or outside the caller's scope"
 // @Router       /api/v1/achievements/{id}/history [get]
 func achievementHistory(
 	refService service.AchievementReferenceService,
@@ -288,7 +552,7 @@ func achievementHistory(
 	return func(c *fiber.Ctx) error {
 		id := c.Params("id")
 
-		data, err := refService.GetByMongoID(context.Background(), id)
+		data, err := refService.GetHistory(context.Background(), id, achievementActor(c))
 		if err != nil {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		}
@@ -297,6 +561,82 @@ func achievementHistory(
 	}
 }
 
+// verificationQueue godoc
+// @Summary      Advisor Verification Queue
+// @Description  Submitted achievements of the caller's advisees that are waiting for the caller's approval, with Mongo title and type, days waiting and an SLA overdue flag (Dosen Wali only)
+// @Tags         Achievements
+// @Produce      json
+// @Param        type       query     string  false  "Filter by achievementType"
+// @Param        level      query     string  false  "Filter by details.competitionLevel"
+// @Param        studentId  query     string  false  "Filter by student UUID"
+// @Param        minDays    query     int     false  "Waiting at least this many days"
+// @Param        maxDays    query     int     false  "Waiting at most this many days"
+// @Param        sort       query     string  false  "submittedAt (default), title, type or student"
+// @Param        order      query     string  false  "asc (default) or desc"
+// @Param        cursor     query     string  false  "nextCursor from the previous page"
+// @Param        limit      query     int     false  "Page size (default 20, max 100)"
+// @Security     ApiKeyAuth
+// @Success      200  {object}  models.VerificationQueueResponse
+// @Failure      400  {object}  map[string]string "Invalid filter, sort or cursor"
+// @Router       /api/v1/lecturers/me/queue [get]
+func verificationQueue(
+	refService service.AchievementReferenceService,
+) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		filter := models.VerificationQueueFilter{
+			AchievementType:  c.Query("type"),
+			CompetitionLevel: c.Query("level"),
+			Sort:             c.Query("sort"),
+			Cursor:           c.Query("cursor"),
+		}
+
+		switch c.Query("order", "asc") {
+		case "asc":
+		case "desc":
+			filter.Desc = true
+		default:
+			return fiber.NewError(fiber.StatusBadRequest, "order must be asc or desc")
+		}
+
+		if v := c.Query("studentId"); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "Invalid studentId")
+			}
+			filter.StudentID = &id
+		}
+
+		for name, dst := range map[string]**int{"minDays": &filter.MinDays, "maxDays": &filter.MaxDays} {
+			if v := c.Query(name); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 0 {
+					return fiber.NewError(fiber.StatusBadRequest, name+" must be a non-negative integer")
+				}
+				*dst = &n
+			}
+		}
+
+		if v := c.Query("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return fiber.NewError(fiber.StatusBadRequest, "limit must be a positive integer")
+			}
+			filter.Limit = n
+		}
+
+		data, err := refService.GetVerificationQueue(c.Context(), achievementActor(c), filter)
+		if err != nil {
+			switch err.Error() {
+			case "invalid sort field", "invalid cursor":
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		return c.JSON(data)
+	}
+}
+
 // addAttachment godoc
 // @Summary      Add Attachment (Upload PDF)
 // @Description  Upload PDF evidence file for an achievement
@@ -307,41 +647,56 @@ func achievementHistory(
 // @Param        file  formData  file    true  "PDF File to upload"
 // @Security     ApiKeyAuth
 // @Success      201   {object}  map[string]string "message & url"
+// @Failure      404   {object}  map[string]string "Achievement not found or outside the caller's scope"
+// @Failure      403   {object}  map[string]string "Not the owning student"
+// @Failure      409  {object}  map[string]string "Status does not allow this action"
 // @Router       /api/v1/achievements/{id}/attachments [post]
 func addAttachment(
 	achievementService service.AchievementService,
 ) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		id := c.Params("id")
+		actor := achievementActor(c)
 
 		file, err := c.FormFile("file")
 		if err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, "Upload gagal: Key 'file' tidak ditemukan atau file kosong")
 		}
 
+		// Otorisasi dulu sebelum menyentuh disk.
+		if err := achievementService.AuthorizeAttachment(c.Context(), id, actor); err != nil {
+			return achievementError(err, fiber.StatusBadRequest)
+		}
+
 		uploadDir := "./uploads"
-		if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-			os.Mkdir(uploadDir, 0755)
+		if err := os.MkdirAll(uploadDir, 0755); err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Gagal menyimpan file ke server")
 		}
 
-		filePath := fmt.Sprintf("%s/%s_%s", uploadDir, id, file.Filename)
+		// Nama file dibuat server agar upload tidak bisa menimpa file lain;
+		// nama asli hanya disimpan sebagai metadata.
+		originalName := filepath.Base(file.Filename)
+		ext := strings.ToLower(filepath.Ext(originalName))
+		filePath := fmt.Sprintf("%s/%s%s", uploadDir, uuid.New().String(), ext)
 		if err := c.SaveFile(file, filePath); err != nil {
 			return fiber.NewError(fiber.StatusInternalServerError, "Gagal menyimpan file ke server")
 		}
 
 		attachment := models.Attachment{
-			FileURL:  filePath,                    // Path file di server
-			FileName: file.Filename,               // Nama asli file
-			FileType: filepath.Ext(file.Filename), // Ekstensi (.pdf)
+			FileURL:  filePath,     // Path file di server
+			FileName: originalName, // Nama asli file
+			FileType: ext,          // Ekstensi (.pdf)
 		}
 
 		if err := achievementService.AddAttachment(
 			c.Context(),
 			id,
 			attachment,
+			actor,
 		); err != nil {
+			// filePath dibuat di request ini, jadi aman dihapus.
 			os.Remove(filePath)
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return achievementError(err, fiber.StatusBadRequest)
 		}
 
 		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -0,0 +1,117 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processGetAllAchievementTypeSchemas godoc
+// @Summary      List achievement type schemas
+// @Description  Retrieve the field schema of every registered achievementType
+// @Tags         Achievement Types
+// @Produce      json
+// @Success      200  {array}   models.AchievementTypeSchema
+// @Security     ApiKeyAuth
+// @Router       /api/v1/achievement-types [get]
+func processGetAllAchievementTypeSchemas(s service.AchievementTypeSchemaService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		schemas, err := s.GetAll(c.Context())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": schemas})
+	}
+}
+
+// processGetAchievementTypeSchema godoc
+// @Summary      Get achievement type schema
+// @Description  Retrieve the required and allowed details/customFields of an achievementType, with types, enums and formats, for rendering forms
+// @Tags         Achievement Types
+// @Produce      json
+// @Param        type  path      string  true  "Achievement type"
+// @Success      200   {object}  models.AchievementTypeSchema
+// @Failure      404   {object}  map[string]string "Achievement type schema not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/achievement-types/{type}/schema [get]
+func processGetAchievementTypeSchema(s service.AchievementTypeSchemaService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		schema, err := s.GetByType(c.Context(), c.Params("type"))
+		if err != nil {
+			return achievementTypeSchemaErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": schema})
+	}
+}
+
+// processSaveAchievementTypeSchema godoc
+// @Summary      Create or replace achievement type schema
+// @Description  Replace the whole schema of an achievementType. Details fields must be fields of the achievement details and take their type from it; customFields need an explicit type. Applies to achievements created or updated afterwards (Admin only)
+// @Tags         Achievement Types
+// @Accept       json
+// @Produce      json
+// @Param        type     path      string                               true  "Achievement type"
+// @Param        request  body      models.AchievementTypeSchemaRequest  true  "Field specifications"
+// @Success      200      {object}  models.AchievementTypeSchema
+// @Failure      400      {object}  map[string]string "Invalid field specification"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/achievement-types/{type}/schema [put]
+func processSaveAchievementTypeSchema(s service.AchievementTypeSchemaService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.AchievementTypeSchemaRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		schema, err := s.Save(c.Context(), c.Params("type"), req, c.Locals("user_id").(uuid.UUID))
+		if err != nil {
+			return achievementTypeSchemaErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": schema})
+	}
+}
+
+// processDeleteAchievementTypeSchema godoc
+// @Summary      Delete achievement type schema
+// @Description  Remove an achievementType from the registry; new achievements of that type are rejected afterwards (Admin only)
+// @Tags         Achievement Types
+// @Produce      json
+// @Param        type  path      string  true  "Achievement type"
+// @Success      200   {object}  map[string]string
+// @Failure      404   {object}  map[string]string "Achievement type schema not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/achievement-types/{type}/schema [delete]
+func processDeleteAchievementTypeSchema(s service.AchievementTypeSchemaService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := s.Delete(c.Context(), c.Params("type")); err != nil {
+			return achievementTypeSchemaErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Achievement type schema deleted"})
+	}
+}
+
+func achievementTypeSchemaErrorResponse(c *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case msg == "achievement type schema not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": msg})
+	case msg == "invalid achievement type",
+		strings.HasPrefix(msg, "duplicate field "),
+		strings.HasPrefix(msg, "unknown details field "),
+		strings.HasPrefix(msg, "field "):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": msg})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": msg})
+}
@@ -0,0 +1,162 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processGetAllApprovalWorkflows godoc
+// @Summary      List approval workflows
+// @Description  Retrieve all multi-level approval workflows with their steps (Admin only)
+// @Tags         Approval Workflows
+// @Produce      json
+// @Success      200  {array}   models.ApprovalWorkflow
+// @Security     ApiKeyAuth
+// @Router       /api/v1/approval-workflows [get]
+func processGetAllApprovalWorkflows(s service.ApprovalWorkflowService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		workflows, err := s.GetAll(c.Context())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": workflows})
+	}
+}
+
+// processGetApprovalWorkflowByID godoc
+// @Summary      Get approval workflow
+// @Description  Retrieve an approval workflow with its steps (Admin only)
+// @Tags         Approval Workflows
+// @Produce      json
+// @Param        id   path      string  true  "Approval workflow UUID"
+// @Success      200  {object}  models.ApprovalWorkflow
+// @Failure      404  {object}  map[string]string "Approval workflow not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/approval-workflows/{id} [get]
+func processGetApprovalWorkflowByID(s service.ApprovalWorkflowService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		workflow, err := s.GetByID(c.Context(), id)
+		if err != nil {
+			return approvalWorkflowErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": workflow})
+	}
+}
+
+// processCreateApprovalWorkflow godoc
+// @Summary      Create approval workflow
+// @Description  Create an approval chain for an achievementType and/or details.competitionLevel; omitted keys match any value and the most specific workflow wins (Admin only)
+// @Tags         Approval Workflows
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ApprovalWorkflowRequest  true  "Workflow and ordered steps"
+// @Success      201      {object}  models.ApprovalWorkflow
+// @Failure      400      {object}  map[string]string "Invalid steps or unknown approver role"
+// @Failure      409      {object}  map[string]string "Workflow for this type and level already exists"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/approval-workflows [post]
+func processCreateApprovalWorkflow(s service.ApprovalWorkflowService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.ApprovalWorkflowRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		workflow, err := s.Create(c.Context(), req)
+		if err != nil {
+			return approvalWorkflowErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": workflow})
+	}
+}
+
+// processUpdateApprovalWorkflow godoc
+// @Summary      Replace approval workflow
+// @Description  Replace an approval workflow and all of its steps. Steps (order, approver role or scope) cannot change while submitted achievements are in this workflow; name, matching keys and isActive only affect new submissions (Admin only)
+// @Tags         Approval Workflows
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                          true  "Approval workflow UUID"
+// @Param        request  body      models.ApprovalWorkflowRequest  true  "Workflow and ordered steps"
+// @Success      200      {object}  models.ApprovalWorkflow
+// @Failure      404      {object}  map[string]string "Approval workflow not found"
+// @Failure      409      {object}  map[string]string "Workflow for this type and level already exists, or steps changed while in use"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/approval-workflows/{id} [put]
+func processUpdateApprovalWorkflow(s service.ApprovalWorkflowService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		req := new(models.ApprovalWorkflowRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		workflow, err := s.Update(c.Context(), id, req)
+		if err != nil {
+			return approvalWorkflowErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": workflow})
+	}
+}
+
+// processDeleteApprovalWorkflow godoc
+// @Summary      Delete approval workflow
+// @Description  Delete an approval workflow. Refused while submitted achievements are in it; finished achievements keep their history (Admin only)
+// @Tags         Approval Workflows
+// @Produce      json
+// @Param        id   path      string  true  "Approval workflow UUID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string "Approval workflow not found"
+// @Failure      409  {object}  map[string]string "Workflow is in use by submitted achievements"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/approval-workflows/{id} [delete]
+func processDeleteApprovalWorkflow(s service.ApprovalWorkflowService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		if err := s.Delete(c.Context(), id); err != nil {
+			return approvalWorkflowErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Approval workflow deleted"})
+	}
+}
+
+func approvalWorkflowErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "approval workflow not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "approval workflow for this type and level already exists", "approval workflow is in use by submitted achievements":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "approver role not found", "each step needs an approver role or scope":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
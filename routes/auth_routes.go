@@ -1,6 +1,9 @@
 package routes
 
 import (
+	"errors"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,13 +16,15 @@ import (
 
 // processLogin godoc
 // @Summary      User Login
-// @Description  Authenticate user and return access & refresh tokens
+// @Description  Authenticate user and return access & refresh tokens. If the account uses MFA (or its role requires MFA), an MFA challenge token is returned instead; finish with /auth/mfa/verify.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
 // @Param        request  body      models.LoginRequest  true  "Login Credentials"
 // @Success      200      {object}  models.LoginResponse
 // @Failure      401      {object}  map[string]string "Invalid email or password"
+// @Failure      423      {object}  map[string]interface{} "Account temporarily locked"
+// @Failure      429      {object}  map[string]interface{} "Too many login attempts"
 // @Router       /api/v1/auth/login [post]
 func processLogin(s service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -33,21 +38,39 @@ func processLogin(s service.AuthService) fiber.Handler {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
 		}
 
-		authResponse, err := s.Login(c.Context(), *req)
+		req.IPAddress = c.IP()
+		req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+		authResponse, challenge, err := s.Login(c.Context(), *req)
 		if err != nil {
+			var throttled *service.LoginThrottleError
+			if errors.As(err, &throttled) {
+				retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+				c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+				status := fiber.StatusTooManyRequests
+				if throttled.Locked {
+					status = fiber.StatusLocked
+				}
+				return c.Status(status).JSON(fiber.Map{"status": "fail", "message": err.Error(), "retryAfter": retryAfter})
+			}
 			if err.Error() == "invalid credentials" {
 				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": "Invalid email or password"})
 			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
 		}
 
+		if challenge != nil {
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": challenge})
+		}
+
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": authResponse})
 	}
 }
 
 // processRefreshToken godoc
 // @Summary      Refresh Token
-// @Description  Get a new access token using a valid refresh token
+// @Description  Exchange a valid refresh token for a new access token and a new refresh token. The old refresh token is retired; reusing it revokes the whole session.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
@@ -76,7 +99,7 @@ func processRefreshToken(s service.AuthService) fiber.Handler {
 
 		refreshToken := parts[1]
 
-		resp, err := s.RefreshToken(c.Context(), refreshToken)
+		resp, err := s.RefreshToken(c.Context(), refreshToken, c.IP(), c.Get(fiber.HeaderUserAgent))
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"status":  "error",
@@ -90,12 +113,13 @@ func processRefreshToken(s service.AuthService) fiber.Handler {
 
 // processLogout godoc
 // @Summary      User Logout
-// @Description  Invalidate the current refresh token
+// @Description  Invalidate the current refresh token and every token rotated from it
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
 // @Success      200      {object}  map[string]string "Logged out successfully"
+// @Failure      401      {object}  map[string]string
 // @Router       /api/v1/auth/logout [post]
 func processLogout(s service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -121,6 +145,12 @@ func processLogout(s service.AuthService) fiber.Handler {
 		}
 
 		if err := s.Logout(c.Context(), req); err != nil {
+			if err.Error() == "invalid refresh token" {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"status":  "error",
+					"message": err.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"status":  "error",
 				"message": err.Error(),
@@ -134,6 +164,78 @@ func processLogout(s service.AuthService) fiber.Handler {
 	}
 }
 
+// processForgotPassword godoc
+// @Summary      Forgot Password
+// @Description  Send a one-time password reset link to the user's email. The response is the same whether or not the email is registered.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ForgotPasswordRequest  true  "Registered email"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Router       /api/v1/auth/forgot-password [post]
+func processForgotPassword(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.ForgotPasswordRequest)
+
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		if err := s.ForgotPassword(c.Context(), *req); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":  "success",
+			"message": "If the email is registered, a password reset link has been sent",
+		})
+	}
+}
+
+// processResetPassword godoc
+// @Summary      Reset Password
+// @Description  Set a new password using a reset token. The token can only be used once and all active sessions are revoked.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ResetPasswordRequest  true  "Reset token and new password"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]interface{} "Invalid or expired reset token, or password policy violations"
+// @Router       /api/v1/auth/reset-password [post]
+func processResetPassword(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.ResetPasswordRequest)
+
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		if err := s.ResetPassword(c.Context(), *req); err != nil {
+			if ok, resp := passwordPolicyResponse(c, err); ok {
+				return resp
+			}
+			if err.Error() == "invalid or expired reset token" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Password has been reset, please log in again",
+		})
+	}
+}
+
 // processGetProfile godoc
 // @Summary      Get Current Profile
 // @Description  Retrieve the logged-in user's profile information
@@ -175,3 +277,121 @@ func processGetProfile(s service.UserService) fiber.Handler {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": user})
 	}
 }
+
+// processRegister godoc
+// @Summary      Register (students)
+// @Description  Self-registration for students whose NIM and program study are on the admin-uploaded allow-list. A verification link is emailed; the account stays inactive until the email is verified and an admin approves it. An expired link can be replaced via /auth/resend-verification. Only available when SELF_REGISTRATION_ENABLED is true.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.RegisterRequest  true  "Account and student data"
+// @Success      201      {object}  models.RegisterResponse
+// @Failure      400      {object}  map[string]interface{} "Invalid data or password policy violations"
+// @Failure      403      {object}  map[string]string "NIM not eligible"
+// @Failure      404      {object}  map[string]string "Self-registration disabled"
+// @Failure      409      {object}  map[string]string "Username, email or NIM already registered"
+// @Router       /api/v1/auth/register [post]
+func processRegister(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.RegisterRequest)
+
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		resp, err := s.Register(c.Context(), *req)
+		if err != nil {
+			if ok, resp := passwordPolicyResponse(c, err); ok {
+				return resp
+			}
+			switch err.Error() {
+			case "self-registration is disabled":
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			case "student id is not eligible for registration":
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			case "student id already registered", "username already taken", "email already registered":
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(resp)
+	}
+}
+
+// processVerifyEmail godoc
+// @Summary      Verify Email
+// @Description  Confirm the email address of a self-registered account using the emailed token. The account still needs admin approval.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.VerifyEmailRequest  true  "Verification token"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string "Invalid or expired token"
+// @Router       /api/v1/auth/verify-email [post]
+func processVerifyEmail(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.VerifyEmailRequest)
+
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		if err := s.VerifyEmail(c.Context(), *req); err != nil {
+			if err.Error() == "invalid or expired verification token" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Email verified, waiting for admin approval",
+		})
+	}
+}
+
+// processResendVerification godoc
+// @Summary      Resend Verification Email
+// @Description  Send a new email verification link for a self-registration that is still waiting for email verification; earlier unused links stop working. The response is the same whether or not the email belongs to such a registration.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ResendVerificationRequest  true  "Registered email"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string "Self-registration disabled"
+// @Router       /api/v1/auth/resend-verification [post]
+func processResendVerification(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.ResendVerificationRequest)
+
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		if err := s.ResendVerification(c.Context(), *req); err != nil {
+			if err.Error() == "self-registration is disabled" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":  "success",
+			"message": "If the registration is waiting for email verification, a new link has been sent",
+		})
+	}
+}
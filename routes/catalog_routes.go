@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processGetCatalog godoc
+// @Summary      Get master data catalog
+// @Description  List the values of a catalog (achievement_type, competition_level, medal_type, publication_type) in sort order, with labels in the requested locale. The locale comes from the locale query or Accept-Language and falls back to Indonesian
+// @Tags         Catalogs
+// @Produce      json
+// @Param        catalog          path      string  true   "Catalog name"
+// @Param        locale           query     string  false  "Label locale, e.g. id or en"
+// @Param        includeInactive  query     bool    false  "Include deactivated values"
+// @Success      200  {array}   models.CatalogItem
+// @Failure      404  {object}  map[string]string "Catalog not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/catalogs/{catalog} [get]
+func processGetCatalog(s service.CatalogService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		items, err := s.GetCatalog(c.Context(), c.Params("catalog"), requestLocale(c), c.QueryBool("includeInactive"))
+		if err != nil {
+			return catalogErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": items})
+	}
+}
+
+// processSaveCatalogItem godoc
+// @Summary      Create or replace catalog value
+// @Description  Create or replace a catalog value with its localized labels and aliases. Aliases are matched case-insensitively when achievements are written and by the normalization command; set isActive to false to stop accepting a value (Admin only)
+// @Tags         Catalogs
+// @Accept       json
+// @Produce      json
+// @Param        catalog  path      string                     true  "Catalog name"
+// @Param        code     path      string                     true  "Value code stored on achievements"
+// @Param        request  body      models.CatalogItemRequest  true  "Labels, aliases and sort order"
+// @Success      200      {object}  models.CatalogItem
+// @Failure      400      {object}  map[string]string "Invalid code or alias already in use"
+// @Failure      404      {object}  map[string]string "Catalog not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/catalogs/{catalog}/{code} [put]
+func processSaveCatalogItem(s service.CatalogService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.CatalogItemRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		item, err := s.SaveItem(c.Context(), c.Params("catalog"), c.Params("code"), req)
+		if err != nil {
+			return catalogErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": item})
+	}
+}
+
+// requestLocale membaca locale dari query, lalu dari bahasa pertama di
+// Accept-Language ("en-US,en;q=0.9" -> "en").
+func requestLocale(c *fiber.Ctx) string {
+	if locale := c.Query("locale"); locale != "" {
+		return strings.ToLower(locale)
+	}
+
+	lang := strings.Split(c.Get(fiber.HeaderAcceptLanguage), ",")[0]
+	lang = strings.Split(strings.Split(lang, ";")[0], "-")[0]
+	if lang = strings.ToLower(strings.TrimSpace(lang)); lang != "" && lang != "*" {
+		return lang
+	}
+	return models.DefaultCatalogLocale
+}
+
+func catalogErrorResponse(c *fiber.Ctx, err error) error {
+	msg := err.Error()
+	switch {
+	case msg == "catalog not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": msg})
+	case msg == "invalid catalog code", strings.HasPrefix(msg, "catalog code "), strings.HasPrefix(msg, "catalog alias "):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": msg})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": msg})
+}
@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processImpersonateUser godoc
+// @Summary      Impersonate user
+// @Description  Mint a short-lived access token to act as another (non-admin) user. The token carries an "act" claim naming the admin, cannot be refreshed, and cannot verify, reject or delete. Every write made with it is recorded in the target's security events (Admin only)
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                     true  "Target user UUID"
+// @Param        request  body      models.ImpersonateRequest  true  "Reason for impersonating"
+// @Success      200      {object}  models.ImpersonationResponse
+// @Failure      403      {object}  map[string]string "Target is an admin or caller is already impersonating"
+// @Failure      404      {object}  map[string]string "User not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/users/{id}/impersonate [post]
+func processImpersonateUser(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		targetID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		req := new(models.ImpersonateRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+		req.IPAddress = c.IP()
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		resp, err := s.Impersonate(c.Context(), adminID, targetID, *req)
+		if err != nil {
+			switch err.Error() {
+			case "user not found":
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			case "cannot impersonate an admin", "user is inactive":
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
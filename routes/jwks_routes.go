@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"backend/app/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// processGetJWKS godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys used to verify access tokens. Tokens carry the key ID in the "kid" header; retired keys stay listed until their tokens expire.
+// @Tags         Auth
+// @Produce      json
+// @Success      200  {object}  models.JWKSet
+// @Router       /.well-known/jwks.json [get]
+func processGetJWKS() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(utils.JWKS())
+	}
+}
@@ -0,0 +1,194 @@
+package routes
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+	"backend/middleware"
+)
+
+// processVerifyMFA godoc
+// @Summary      Verify MFA Code
+// @Description  Complete a two-step login with the MFA challenge token and a TOTP or recovery code
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFAVerifyRequest  true  "Challenge token and code"
+// @Success      200      {object}  models.LoginResponse
+// @Failure      401      {object}  map[string]string "Invalid code or expired challenge"
+// @Failure      423      {object}  map[string]interface{} "Account temporarily locked"
+// @Router       /api/v1/auth/mfa/verify [post]
+func processVerifyMFA(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.MFAVerifyRequest)
+
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		req.IPAddress = c.IP()
+		req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+		authResponse, err := s.VerifyMFA(c.Context(), *req)
+		if err != nil {
+			var throttled *service.LoginThrottleError
+			if errors.As(err, &throttled) {
+				retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+				c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return c.Status(fiber.StatusLocked).JSON(fiber.Map{"status": "fail", "message": err.Error(), "retryAfter": retryAfter})
+			}
+			switch err.Error() {
+			case "invalid mfa code", "invalid or expired mfa token", "mfa is not enabled":
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": authResponse})
+	}
+}
+
+// processSetupMFA godoc
+// @Summary      Start MFA Enrollment
+// @Description  Generate a new TOTP secret and otpauth:// provisioning URI (render it as a QR code). Accepts an access token or the enrollment challenge token from login.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200      {object}  models.MFASetupResponse
+// @Failure      409      {object}  map[string]string "MFA already enabled"
+// @Router       /api/v1/auth/mfa/setup [post]
+func processSetupMFA(s service.MFAService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
+		username := c.Locals(middleware.UsernameKey).(string)
+
+		setup, err := s.Setup(c.Context(), userID, username)
+		if err != nil {
+			return mfaErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": setup})
+	}
+}
+
+// processEnableMFA godoc
+// @Summary      Enable MFA
+// @Description  Confirm enrollment with a code from the authenticator app and receive one-time recovery codes. The recovery codes are shown only once.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFACodeRequest  true  "TOTP code"
+// @Security     ApiKeyAuth
+// @Success      200      {object}  models.MFARecoveryCodesResponse
+// @Failure      400      {object}  map[string]string
+// @Router       /api/v1/auth/mfa/enable [post]
+func processEnableMFA(s service.MFAService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.MFACodeRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
+
+		codes, err := s.Enable(c.Context(), userID, req.Code)
+		if err != nil {
+			return mfaErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": codes})
+	}
+}
+
+// processDisableMFA godoc
+// @Summary      Disable MFA
+// @Description  Turn off MFA for the current user. Not allowed for roles where MFA is mandatory.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFACodeRequest  true  "TOTP or recovery code"
+// @Security     ApiKeyAuth
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      403      {object}  map[string]string "MFA is mandatory for this role"
+// @Router       /api/v1/auth/mfa/disable [post]
+func processDisableMFA(s service.MFAService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.MFACodeRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
+		role := c.Locals(middleware.RoleKey).(string)
+
+		if err := s.Disable(c.Context(), userID, role, req.Code); err != nil {
+			return mfaErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "MFA disabled"})
+	}
+}
+
+// processRegenerateRecoveryCodes godoc
+// @Summary      Regenerate MFA Recovery Codes
+// @Description  Replace all recovery codes with a new set. Requires a current TOTP code.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MFACodeRequest  true  "TOTP code"
+// @Security     ApiKeyAuth
+// @Success      200      {object}  models.MFARecoveryCodesResponse
+// @Failure      400      {object}  map[string]string
+// @Router       /api/v1/auth/mfa/recovery-codes [post]
+func processRegenerateRecoveryCodes(s service.MFAService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.MFACodeRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
+
+		codes, err := s.RegenerateRecoveryCodes(c.Context(), userID, req.Code)
+		if err != nil {
+			return mfaErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": codes})
+	}
+}
+
+func mfaErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "mfa already enabled":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "invalid mfa code", "mfa setup not started", "mfa is not enabled":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	if strings.HasPrefix(err.Error(), "mfa is mandatory") {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
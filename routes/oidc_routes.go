@@ -0,0 +1,108 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// oidcStateCookie mengikat state SSO ke browser yang memulai login; callback
+// dengan state yang tidak sama dengan cookie ini ditolak.
+const oidcStateCookie = "oidc_state"
+
+func setOIDCStateCookie(c *fiber.Ctx, value string, expires time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     "/api/v1/auth/oidc",
+		Expires:  expires,
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+// processOIDCAuthorize godoc
+// @Summary      Start SSO login
+// @Description  Start an OpenID Connect authorization code + PKCE login. Redirect the browser to authorizationUrl; the identity provider returns to the front end with code and state, which are then posted to /auth/oidc/callback. The state is also set in an HttpOnly cookie, so the callback must be sent from the same browser with credentials.
+// @Tags         Authentication
+// @Produce      json
+// @Success      200  {object}  models.OIDCAuthorizeResponse
+// @Failure      404  {object}  map[string]string "SSO login is disabled"
+// @Failure      503  {object}  map[string]string "Identity provider unavailable"
+// @Router       /api/v1/auth/oidc/authorize [get]
+func processOIDCAuthorize(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		resp, err := s.OIDCAuthorize(c.Context())
+		if err != nil {
+			return oidcErrorResponse(c, err)
+		}
+
+		setOIDCStateCookie(c, resp.Data.State, resp.StateExpiresAt)
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// processOIDCCallback godoc
+// @Summary      Finish SSO login
+// @Description  Exchange the authorization code from the identity provider for access & refresh tokens. The identity is linked to an existing account by NIM or verified email, or a Mahasiswa account is provisioned when enabled. Like /auth/login, an MFA challenge may be returned instead. The state must match the HttpOnly cookie set by /auth/oidc/authorize.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.OIDCCallbackRequest  true  "Code and state from the identity provider"
+// @Success      200      {object}  models.LoginResponse
+// @Failure      401      {object}  map[string]string "Invalid state or SSO authentication failed"
+// @Failure      403      {object}  map[string]string "No account for this identity"
+// @Failure      409      {object}  map[string]string "Account already exists"
+// @Router       /api/v1/auth/oidc/callback [post]
+func processOIDCCallback(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.OIDCCallbackRequest)
+
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		req.BrowserState = c.Cookies(oidcStateCookie)
+		req.IPAddress = c.IP()
+		req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+		// State hanya bisa dipakai sekali, jadi cookie-nya langsung dihapus.
+		setOIDCStateCookie(c, "", time.Unix(0, 0))
+
+		authResponse, challenge, err := s.OIDCLogin(c.Context(), *req)
+		if err != nil {
+			return oidcErrorResponse(c, err)
+		}
+
+		if challenge != nil {
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": challenge})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": authResponse})
+	}
+}
+
+func oidcErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "sso login is disabled":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "sso provider unavailable":
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "error", "message": err.Error()})
+	case "invalid or expired sso state", "sso authentication failed", "account is not active":
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "no account is linked to this sso identity", "sso identity is missing required student claims":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "an account with this username or email already exists":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
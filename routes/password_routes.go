@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/app/service"
+)
+
+// processGetPasswordPolicy godoc
+// @Summary      Get password policy
+// @Description  Rules that new passwords must satisfy, for display in sign-up and reset forms
+// @Tags         Authentication
+// @Produce      json
+// @Success      200  {object}  models.PasswordPolicy
+// @Router       /api/v1/auth/password-policy [get]
+func processGetPasswordPolicy(s service.PasswordPolicyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": s.Policy()})
+	}
+}
+
+// passwordPolicyResponse menjawab 400 dengan daftar aturan yang dilanggar
+// jika err adalah service.PasswordPolicyError; ok bernilai false jika bukan.
+func passwordPolicyResponse(c *fiber.Ctx, err error) (ok bool, resp error) {
+	var policyErr *service.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false, nil
+	}
+	return true, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"status":     "fail",
+		"message":    "password does not meet policy",
+		"violations": policyErr.Violations,
+	})
+}
@@ -0,0 +1,164 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processGetAllPermissions godoc
+// @Summary      List permissions
+// @Description  Retrieve all permissions (Admin only)
+// @Tags         Permissions
+// @Produce      json
+// @Success      200  {array}   models.Permission
+// @Security     ApiKeyAuth
+// @Router       /api/v1/permissions [get]
+func processGetAllPermissions(s service.PermissionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		permissions, err := s.GetAllPermissions(c.Context())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": permissions})
+	}
+}
+
+// processGetPermissionByID godoc
+// @Summary      Get permission by ID
+// @Description  Retrieve a single permission (Admin only)
+// @Tags         Permissions
+// @Produce      json
+// @Param        id   path      string  true  "Permission UUID"
+// @Success      200  {object}  models.Permission
+// @Failure      404  {object}  map[string]string "Permission not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/permissions/{id} [get]
+func processGetPermissionByID(s service.PermissionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		permissionID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		permission, err := s.GetPermissionByID(c.Context(), permissionID)
+		if err != nil {
+			return permissionErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": permission})
+	}
+}
+
+// processCreatePermission godoc
+// @Summary      Create permission
+// @Description  Create a new permission named "resource:action" (Admin only)
+// @Tags         Permissions
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreatePermissionRequest  true  "Permission Data"
+// @Success      201      {object}  models.Permission
+// @Failure      409      {object}  map[string]string "Permission already exists"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/permissions [post]
+func processCreatePermission(s service.PermissionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.CreatePermissionRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		permission, err := s.CreatePermission(c.Context(), req)
+		if err != nil {
+			return permissionErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": permission})
+	}
+}
+
+// processUpdatePermission godoc
+// @Summary      Update permission
+// @Description  Update resource, action and description of a permission (Admin only)
+// @Tags         Permissions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                          true  "Permission UUID"
+// @Param        request  body      models.UpdatePermissionRequest  true  "Permission Data"
+// @Success      200      {object}  models.Permission
+// @Failure      404      {object}  map[string]string "Permission not found"
+// @Failure      409      {object}  map[string]string "Permission already exists"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/permissions/{id} [put]
+func processUpdatePermission(s service.PermissionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		permissionID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		req := new(models.UpdatePermissionRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		permission, err := s.UpdatePermission(c.Context(), permissionID, req)
+		if err != nil {
+			return permissionErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": permission})
+	}
+}
+
+// processDeletePermission godoc
+// @Summary      Delete permission
+// @Description  Delete a permission that is not assigned to any role (Admin only)
+// @Tags         Permissions
+// @Produce      json
+// @Param        id   path      string  true  "Permission UUID"
+// @Success      200  {object}  map[string]string "Permission deleted successfully"
+// @Failure      404  {object}  map[string]string "Permission not found"
+// @Failure      409  {object}  map[string]string "Permission still assigned to roles"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/permissions/{id} [delete]
+func processDeletePermission(s service.PermissionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		permissionID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		if err := s.DeletePermission(c.Context(), permissionID); err != nil {
+			return permissionErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Permission deleted successfully"})
+	}
+}
+
+func permissionErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "permission not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "permission already exists", "permission is still assigned to roles":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	if strings.HasPrefix(err.Error(), "resource and action") {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
@@ -0,0 +1,123 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processGetAllPointsRubrics godoc
+// @Summary      List points rubric versions
+// @Description  Retrieve all points rubric versions, newest first, without their rules
+// @Tags         Points Rubrics
+// @Produce      json
+// @Success      200  {array}   models.PointsRubric
+// @Security     ApiKeyAuth
+// @Router       /api/v1/points-rubrics [get]
+func processGetAllPointsRubrics(s service.PointsRubricService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rubrics, err := s.GetAll(c.Context())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": rubrics})
+	}
+}
+
+// processGetPointsRubricByID godoc
+// @Summary      Get points rubric version
+// @Description  Retrieve a points rubric version with its rules and team factors
+// @Tags         Points Rubrics
+// @Produce      json
+// @Param        id   path      string  true  "Points rubric UUID"
+// @Success      200  {object}  models.PointsRubric
+// @Failure      404  {object}  map[string]string "Points rubric not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/points-rubrics/{id} [get]
+func processGetPointsRubricByID(s service.PointsRubricService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		rubric, err := s.GetByID(c.Context(), id)
+		if err != nil {
+			return pointsRubricErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": rubric})
+	}
+}
+
+// processCreatePointsRubric godoc
+// @Summary      Create points rubric version
+// @Description  Publish a new rubric version with the next version number. It applies to achievements submitted from effectiveFrom until a later version takes effect. Rules with more criteria filled in take precedence (Admin only)
+// @Tags         Points Rubrics
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreatePointsRubricRequest  true  "Rubric rules and team factors"
+// @Success      201      {object}  models.PointsRubric
+// @Failure      400      {object}  map[string]string "Invalid rules or team factors"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/points-rubrics [post]
+func processCreatePointsRubric(s service.PointsRubricService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.CreatePointsRubricRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		rubric, err := s.Create(c.Context(), req, c.Locals("user_id").(uuid.UUID))
+		if err != nil {
+			return pointsRubricErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": rubric})
+	}
+}
+
+// processDeletePointsRubric godoc
+// @Summary      Delete points rubric version
+// @Description  Delete a rubric version that has not taken effect yet (Admin only)
+// @Tags         Points Rubrics
+// @Produce      json
+// @Param        id   path      string  true  "Points rubric UUID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string "Points rubric not found"
+// @Failure      409  {object}  map[string]string "Rubric already in effect"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/points-rubrics/{id} [delete]
+func processDeletePointsRubric(s service.PointsRubricService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		if err := s.Delete(c.Context(), id); err != nil {
+			return pointsRubricErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Points rubric deleted"})
+	}
+}
+
+func pointsRubricErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "points rubric not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "points rubric already in effect cannot be deleted":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "duplicate team factor for the same team size":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
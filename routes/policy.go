@@ -0,0 +1,200 @@
+package routes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"backend/app/models"
+	"backend/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Setiap endpoint didaftarkan lewat tabel route dengan policy eksplisit.
+// SetupRoutes gagal jika ada route yang terdaftar tanpa policy, sehingga
+// endpoint baru tidak bisa lupa diberi permission.
+
+type policyKind int
+
+const (
+	policyNone policyKind = iota
+	policyPublic
+	policyAuthenticated
+	policyMFAEnrollment
+	policyPermission
+)
+
+type routePolicy struct {
+	kind       policyKind
+	permission string
+
+	// denyImpersonation menolak token impersonasi admin.
+	denyImpersonation bool
+
+	// apiKey menerima API key service account selain access token.
+	apiKey bool
+}
+
+// public: tanpa autentikasi.
+func public() routePolicy {
+	return routePolicy{kind: policyPublic}
+}
+
+// authenticated: cukup access token yang valid, untuk endpoint milik user
+// sendiri (profil, sesi, MFA).
+func authenticated() routePolicy {
+	return routePolicy{kind: policyAuthenticated}
+}
+
+// mfaEnrollment: access token atau token tantangan enrollment MFA.
+func mfaEnrollment() routePolicy {
+	return routePolicy{kind: policyMFAEnrollment}
+}
+
+// permission: access token yang memuat permission "resource:action".
+func permission(name string) routePolicy {
+	return routePolicy{kind: policyPermission, permission: name}
+}
+
+// allowAPIKey juga menerima API key service account yang scope-nya memuat
+// permission route. Hanya untuk endpoint baca yang memang dibuka untuk
+// integrasi.
+func (p routePolicy) allowAPIKey() routePolicy {
+	p.apiKey = true
+	return p
+}
+
+// notImpersonated menandai aksi yang tidak boleh dilakukan dengan token
+// impersonasi (aksi destruktif, pengaturan keamanan akun).
+func (p routePolicy) notImpersonated() routePolicy {
+	p.denyImpersonation = true
+	return p
+}
+
+func (p routePolicy) String() string {
+	switch p.kind {
+	case policyPublic:
+		return "public"
+	case policyAuthenticated:
+		return "authenticated"
+	case policyMFAEnrollment:
+		return "mfa-enrollment"
+	case policyPermission:
+		return p.permission
+	}
+	return "none"
+}
+
+func (p routePolicy) middleware() []fiber.Handler {
+	var handlers []fiber.Handler
+	switch p.kind {
+	case policyAuthenticated:
+		handlers = []fiber.Handler{middleware.JWTMiddleware()}
+	case policyMFAEnrollment:
+		handlers = []fiber.Handler{middleware.MFAEnrollmentMiddleware()}
+	case policyPermission:
+		auth := middleware.JWTMiddleware()
+		if p.apiKey {
+			auth = middleware.JWTOrAPIKeyMiddleware()
+		}
+		handlers = []fiber.Handler{auth, middleware.RequirePermission(p.permission)}
+	}
+
+	if p.denyImpersonation && len(handlers) > 0 {
+		handlers = append(handlers, middleware.DenyImpersonation())
+	}
+	return handlers
+}
+
+type route struct {
+	method   string
+	path     string
+	policy   routePolicy
+	handlers []fiber.Handler
+}
+
+func handle(method, path string, policy routePolicy, handlers ...fiber.Handler) route {
+	return route{method: method, path: path, policy: policy, handlers: handlers}
+}
+
+var permissionPattern = regexp.MustCompile(`^[a-z_]+:[a-z_]+$`)
+
+func validateRoute(r route) error {
+	switch r.policy.kind {
+	case policyNone:
+		return fmt.Errorf("route %s %s has no policy", r.method, r.path)
+	case policyPermission:
+		if !permissionPattern.MatchString(r.policy.permission) {
+			return fmt.Errorf("route %s %s has invalid permission %q (want resource:action)",
+				r.method, r.path, r.policy.permission)
+		}
+	}
+	if r.policy.denyImpersonation && r.policy.kind == policyPublic {
+		return fmt.Errorf("route %s %s is public and cannot deny impersonation", r.method, r.path)
+	}
+	if r.policy.apiKey {
+		if r.policy.kind != policyPermission || r.policy.denyImpersonation || r.method != fiber.MethodGet {
+			return fmt.Errorf("route %s %s cannot accept api keys (only GET permission routes)", r.method, r.path)
+		}
+		if !models.APIKeyScopes[r.policy.permission] {
+			return fmt.Errorf("route %s %s accepts api keys but %q is not an api key scope",
+				r.method, r.path, r.policy.permission)
+		}
+	}
+	if len(r.handlers) == 0 {
+		return fmt.Errorf("route %s %s has no handler", r.method, r.path)
+	}
+	return nil
+}
+
+// registerRoutes memvalidasi lalu mendaftarkan tabel route ke app.
+func registerRoutes(app *fiber.App, table []route) error {
+	seen := map[string]bool{}
+	for _, r := range table {
+		if err := validateRoute(r); err != nil {
+			return err
+		}
+
+		key := r.method + " " + r.path
+		if seen[key] {
+			return fmt.Errorf("route %s registered twice", key)
+		}
+		seen[key] = true
+
+		handlers := append(r.policy.middleware(), r.handlers...)
+		if r.method == fiber.MethodGet {
+			app.Get(r.path, handlers...)
+		} else {
+			app.Add(r.method, r.path, handlers...)
+		}
+	}
+	return nil
+}
+
+// checkRoutePolicies memastikan tidak ada route di app yang didaftarkan di
+// luar tabel (dan karenanya tanpa policy).
+func checkRoutePolicies(app *fiber.App, table []route) error {
+	known := map[string]bool{}
+	for _, r := range table {
+		known[r.method+" "+r.path] = true
+		if r.method == fiber.MethodGet {
+			known[fiber.MethodHead+" "+r.path] = true
+		}
+	}
+
+	var missing []string
+	for _, r := range app.GetRoutes(true) {
+		key := r.Method + " " + r.Path
+		if !known[key] {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("routes without policy: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
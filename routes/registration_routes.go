@@ -0,0 +1,196 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processUploadAllowlist godoc
+// @Summary      Upload registration allow-list
+// @Description  Upload a CSV of "nim,program_study[,academic_year]" rows (header optional). Existing NIMs are updated; invalid rows are skipped and reported (Admin only)
+// @Tags         Registration
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "CSV file"
+// @Success      200   {object}  models.AllowlistUploadResponse
+// @Failure      400   {object}  map[string]string
+// @Security     ApiKeyAuth
+// @Router       /api/v1/registrations/allowlist [post]
+func processUploadAllowlist(s service.RegistrationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "CSV file is required in field 'file'"})
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+		defer file.Close()
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		imported, skipped, err := s.ImportAllowlistCSV(c.Context(), file, adminID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		resp := models.AllowlistUploadResponse{Status: "success"}
+		resp.Data.Imported = imported
+		resp.Data.Skipped = skipped
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// processListAllowlist godoc
+// @Summary      List registration allow-list
+// @Description  List NIMs allowed to self-register and whether they have been used (Admin only)
+// @Tags         Registration
+// @Produce      json
+// @Param        page   query     int  false  "Page number (default: 1)"
+// @Param        limit  query     int  false  "Items per page (default: 50)"
+// @Success      200    {array}   models.RegistrationAllowlistEntry
+// @Security     ApiKeyAuth
+// @Router       /api/v1/registrations/allowlist [get]
+func processListAllowlist(s service.RegistrationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page := c.QueryInt("page", 1)
+		limit := c.QueryInt("limit", 50)
+
+		entries, err := s.ListAllowlist(c.Context(), page, limit)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status": "success",
+			"data":   entries,
+			"meta": fiber.Map{
+				"page":  page,
+				"limit": limit,
+			},
+		})
+	}
+}
+
+// processDeleteAllowlistEntry godoc
+// @Summary      Remove NIM from allow-list
+// @Description  Remove a NIM so it can no longer be used to self-register (Admin only)
+// @Tags         Registration
+// @Produce      json
+// @Param        studentId  path      string  true  "NIM"
+// @Success      200        {object}  map[string]string
+// @Failure      404        {object}  map[string]string "Entry not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/registrations/allowlist/{studentId} [delete]
+func processDeleteAllowlistEntry(s service.RegistrationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := s.DeleteAllowlistEntry(c.Context(), c.Params("studentId")); err != nil {
+			if err.Error() == "allowlist entry not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Allowlist entry deleted successfully"})
+	}
+}
+
+// processListPendingRegistrations godoc
+// @Summary      List pending registrations
+// @Description  Self-registered accounts waiting for approval, with their email verification status (Admin only)
+// @Tags         Registration
+// @Produce      json
+// @Param        page   query     int  false  "Page number (default: 1)"
+// @Param        limit  query     int  false  "Items per page (default: 20)"
+// @Success      200    {array}   models.PendingRegistration
+// @Security     ApiKeyAuth
+// @Router       /api/v1/registrations/pending [get]
+func processListPendingRegistrations(s service.RegistrationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page := c.QueryInt("page", 1)
+		limit := c.QueryInt("limit", 20)
+
+		pending, err := s.ListPending(c.Context(), page, limit)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status": "success",
+			"data":   pending,
+			"meta": fiber.Map{
+				"page":  page,
+				"limit": limit,
+			},
+		})
+	}
+}
+
+// processApproveRegistration godoc
+// @Summary      Approve registration
+// @Description  Activate a self-registered account whose email has been verified (Admin only)
+// @Tags         Registration
+// @Produce      json
+// @Param        id   path      string  true  "User UUID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string "No pending registration"
+// @Failure      409  {object}  map[string]string "Email not verified yet"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/registrations/{id}/approve [post]
+func processApproveRegistration(s service.RegistrationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		if err := s.Approve(c.Context(), userID, adminID); err != nil {
+			switch err.Error() {
+			case "registration not found":
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			case "email not verified":
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Registration approved"})
+	}
+}
+
+// processRejectRegistration godoc
+// @Summary      Reject registration
+// @Description  Delete a self-registered account that is waiting for approval and release its NIM, username and email so the student can register again (Admin only)
+// @Tags         Registration
+// @Produce      json
+// @Param        id   path      string  true  "User UUID"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string "No pending registration"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/registrations/{id}/reject [post]
+func processRejectRegistration(s service.RegistrationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		if err := s.Reject(c.Context(), userID, adminID); err != nil {
+			if err.Error() == "registration not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Registration rejected"})
+	}
+}
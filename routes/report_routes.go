@@ -2,6 +2,8 @@ package routes
 
 import (
 	"backend/app/service"
+	"backend/middleware"
+	"errors"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,9 +24,8 @@ import (
 // @Router       /api/v1/reports/statistics [get]
 func handleGetStatistics(s service.ReportService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userRole := c.Locals("role").(string) 
-		userIDStr := c.Locals("userId").(string)
-		userID, _ := uuid.Parse(userIDStr)
+		userRole := c.Locals(middleware.RoleKey).(string)
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
 
 		startStr := c.Query("start")
 		endStr := c.Query("end")
@@ -38,7 +39,11 @@ func handleGetStatistics(s service.ReportService) fiber.Handler {
 
 		stats, err := s.GetStatistics(c.Context(), userRole, userID, start, end)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{
+			status := 500
+			if errors.Is(err, service.ErrReportAccessDenied) || errors.Is(err, service.ErrInvalidReportRole) {
+				status = 403
+			}
+			return c.Status(status).JSON(fiber.Map{
 				"status":  "error",
 				"message": err.Error(),
 			})
@@ -64,9 +69,8 @@ func handleGetStatistics(s service.ReportService) fiber.Handler {
 // @Router       /api/v1/reports/student/{id} [get]
 func handleGetStudentReport(s service.ReportService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userRole := c.Locals("role").(string)
-		userIDStr := c.Locals("userId").(string)
-		requesterID, _ := uuid.Parse(userIDStr)
+		userRole := c.Locals(middleware.RoleKey).(string)
+		requesterID := c.Locals(middleware.UserIDKey).(uuid.UUID)
 		targetID, err := uuid.Parse(c.Params("id"))
 		if err != nil {
 			return c.Status(400).JSON(fiber.Map{"status": "error", "message": "invalid student id"})
@@ -74,7 +78,11 @@ func handleGetStudentReport(s service.ReportService) fiber.Handler {
 
 		report, err := s.GetStudentReport(c.Context(), userRole, requesterID, targetID)
 		if err != nil {
-			return c.Status(403).JSON(fiber.Map{
+			status := 500
+			if errors.Is(err, service.ErrReportAccessDenied) {
+				status = 403
+			}
+			return c.Status(status).JSON(fiber.Map{
 				"status":  "error",
 				"message": err.Error(),
 			})
@@ -0,0 +1,200 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processGetAllRoles godoc
+// @Summary      List roles
+// @Description  Retrieve all roles with their permissions and number of users (Admin only)
+// @Tags         Roles
+// @Produce      json
+// @Success      200  {array}   models.RoleResponse
+// @Security     ApiKeyAuth
+// @Router       /api/v1/roles [get]
+func processGetAllRoles(s service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roles, err := s.GetAllRoles(c.Context())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": roles})
+	}
+}
+
+// processGetRoleByID godoc
+// @Summary      Get role by ID
+// @Description  Retrieve a role with its permissions (Admin only)
+// @Tags         Roles
+// @Produce      json
+// @Param        id   path      string  true  "Role UUID"
+// @Success      200  {object}  models.RoleResponse
+// @Failure      404  {object}  map[string]string "Role not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/roles/{id} [get]
+func processGetRoleByID(s service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		role, err := s.GetRoleByID(c.Context(), roleID)
+		if err != nil {
+			return roleErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": role})
+	}
+}
+
+// processCreateRole godoc
+// @Summary      Create role
+// @Description  Create a new role (Admin only)
+// @Tags         Roles
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreateRoleRequest  true  "Role Data"
+// @Success      201      {object}  models.RoleResponse
+// @Failure      409      {object}  map[string]string "Role name already exists"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/roles [post]
+func processCreateRole(s service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.CreateRoleRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		role, err := s.CreateRole(c.Context(), req)
+		if err != nil {
+			return roleErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": role})
+	}
+}
+
+// processUpdateRole godoc
+// @Summary      Update role
+// @Description  Update role name and description. Built-in roles cannot be renamed (Admin only)
+// @Tags         Roles
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Role UUID"
+// @Param        request  body      models.UpdateRoleRequest  true  "Role Data"
+// @Success      200      {object}  models.RoleResponse
+// @Failure      404      {object}  map[string]string "Role not found"
+// @Failure      409      {object}  map[string]string "Name taken or built-in role"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/roles/{id} [put]
+func processUpdateRole(s service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		req := new(models.UpdateRoleRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		role, err := s.UpdateRole(c.Context(), roleID, req)
+		if err != nil {
+			return roleErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": role})
+	}
+}
+
+// processDeleteRole godoc
+// @Summary      Delete role
+// @Description  Delete a role that is not assigned to any user. Built-in roles cannot be deleted (Admin only)
+// @Tags         Roles
+// @Produce      json
+// @Param        id   path      string  true  "Role UUID"
+// @Success      200  {object}  map[string]string "Role deleted successfully"
+// @Failure      404  {object}  map[string]string "Role not found"
+// @Failure      409  {object}  map[string]string "Role in use or built-in role"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/roles/{id} [delete]
+func processDeleteRole(s service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		if err := s.DeleteRole(c.Context(), roleID); err != nil {
+			return roleErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Role deleted successfully"})
+	}
+}
+
+// processAssignRolePermissions godoc
+// @Summary      Assign permissions to role
+// @Description  Replace the permission set of a role. An empty list removes all permissions (Admin only)
+// @Tags         Roles
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                           true  "Role UUID"
+// @Param        request  body      models.AssignPermissionsRequest  true  "Permission IDs"
+// @Success      200      {object}  models.RoleResponse
+// @Failure      400      {object}  map[string]string "Unknown permission"
+// @Failure      404      {object}  map[string]string "Role not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/roles/{id}/permissions [put]
+func processAssignRolePermissions(s service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		req := new(models.AssignPermissionsRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		role, err := s.AssignPermissions(c.Context(), roleID, req)
+		if err != nil {
+			return roleErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": role})
+	}
+}
+
+func roleErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "role not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "role name already exists", "built-in role cannot be renamed",
+		"built-in role cannot be deleted", "role is still assigned to users":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "one or more permissions not found":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
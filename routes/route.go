@@ -1,9 +1,6 @@
 package routes
 
 import (
-	"os"
-
-	"backend/app/models"
 	"backend/app/service"
 	"backend/middleware"
 
@@ -11,68 +8,148 @@ import (
 
 	"github.com/gofiber/swagger"
 
-	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 )
 
 func SetupRoutes(app *fiber.App, userService service.UserService, authService service.AuthService, achievementService service.AchievementService,
-	referenceService service.AchievementReferenceService, studentLecturerService service.StudentLecturerService, reportService service.ReportService) {
-
-	app.Get("/swagger/*", swagger.HandlerDefault)
-	api := app.Group("/api/v1")
-	auth := api.Group("/auth")
-	auth.Post("/login", processLogin(authService))
-	auth.Post("/refresh", processRefreshToken(authService))
-	auth.Post("/logout", processLogout(authService))
-	auth.Get("/profile", middleware.JWTMiddleware(), processGetProfile(userService))
-
-	users := api.Group("/users")
-	users.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{
-			Key: []byte(os.Getenv("JWT_SECRET")),
-		},
-		Claims: &models.JWTClaims{},
-	}))
-
-	users.Use(middleware.OnlyAdmin())
-	users.Get("/", processGetAllUsers(userService))
-	users.Post("/", processCreateUser(userService))
-	users.Get("/:id", processGetUserByID(userService))
-	users.Put("/:id", processUpdateUser(userService))
-	users.Delete("/:id", processDeleteUser(userService))
-	users.Put("/:id/role", processUpdateUserRole(userService))
-
-	achievements := api.Group("/achievements")
-	achievements.Use(jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{
-			Key: []byte(os.Getenv("JWT_SECRET")),
-		},
-		Claims: &models.JWTClaims{},
-	}))
-
-	achievements.Get("/", middleware.OnlyMahasiswa(), listAchievements(referenceService))
-	achievements.Get("/:id", middleware.OnlyMahasiswa(), getAchievementDetail(achievementService))
-	achievements.Post("/", middleware.OnlyMahasiswa(), createAchievement(achievementService, referenceService))
-	achievements.Put("/:id", middleware.OnlyMahasiswa(), updateAchievement(achievementService))
-	achievements.Delete("/:id", middleware.OnlyMahasiswa(), deleteAchievement(referenceService))
-	achievements.Post("/:id/submit", middleware.OnlyMahasiswa(), submitAchievement(referenceService))
-	achievements.Post("/:id/attachments", middleware.OnlyMahasiswa(), addAttachment(achievementService))
-
-	achievements.Post("/:id/verify", middleware.OnlyDosenWali(), verifyAchievement(referenceService))
-	achievements.Post("/:id/reject", middleware.OnlyDosenWali(), rejectAchievement(referenceService))
-	achievements.Get("/:id/history", middleware.OnlyDosenWali(), achievementHistory(referenceService))
-
-	students := api.Group("/students")
-	students.Get("/", StudentList(studentLecturerService))
-	students.Get("/:id", StudentGetByID(studentLecturerService))
-	students.Put("/:id/advisor", StudentUpdateAdvisor(studentLecturerService))
-
-	lecturers := api.Group("/lecturers")
-	lecturers.Get("/", LecturerList(studentLecturerService))
-	lecturers.Get("/:id/advisees", LecturerAdvisees(studentLecturerService))
-
-	reports := api.Group("/reports", middleware.JWTMiddleware())
-	reports.Get("/statistics", handleGetStatistics(reportService))
-	reports.Get("/student/:id", handleGetStudentReport(reportService))
-
+	referenceService service.AchievementReferenceService, studentLecturerService service.StudentLecturerService, reportService service.ReportService,
+	mfaService service.MFAService, roleService service.RoleService, permissionService service.PermissionService,
+	registrationService service.RegistrationService, serviceAccountService service.ServiceAccountService,
+	passwordPolicyService service.PasswordPolicyService, approvalWorkflowService service.ApprovalWorkflowService,
+	pointsRubricService service.PointsRubricService, achievementTypeSchemaService service.AchievementTypeSchemaService,
+	catalogService service.CatalogService) error {
+
+	const api = "/api/v1"
+
+	table := []route{
+		handle(fiber.MethodGet, "/swagger/*", public(), swagger.HandlerDefault),
+		handle(fiber.MethodGet, "/.well-known/jwks.json", public(), processGetJWKS()),
+
+		// Auth
+		handle(fiber.MethodPost, api+"/auth/login", public(), processLogin(authService)),
+		handle(fiber.MethodPost, api+"/auth/refresh", public(), processRefreshToken(authService)),
+		handle(fiber.MethodPost, api+"/auth/logout", public(), processLogout(authService)),
+		handle(fiber.MethodPost, api+"/auth/forgot-password", public(), processForgotPassword(authService)),
+		handle(fiber.MethodPost, api+"/auth/reset-password", public(), processResetPassword(authService)),
+		handle(fiber.MethodGet, api+"/auth/password-policy", public(), processGetPasswordPolicy(passwordPolicyService)),
+		handle(fiber.MethodPost, api+"/auth/register", public(), processRegister(authService)),
+		handle(fiber.MethodPost, api+"/auth/verify-email", public(), processVerifyEmail(authService)),
+		handle(fiber.MethodPost, api+"/auth/resend-verification", public(), processResendVerification(authService)),
+		handle(fiber.MethodGet, api+"/auth/oidc/authorize", public(), processOIDCAuthorize(authService)),
+		handle(fiber.MethodPost, api+"/auth/oidc/callback", public(), processOIDCCallback(authService)),
+		handle(fiber.MethodGet, api+"/auth/profile", authenticated(), processGetProfile(userService)),
+		handle(fiber.MethodGet, api+"/auth/sessions", authenticated(), processListSessions(authService)),
+		handle(fiber.MethodDelete, api+"/auth/sessions/:id", authenticated().notImpersonated(), processRevokeSession(authService)),
+		handle(fiber.MethodPost, api+"/auth/logout-all", authenticated().notImpersonated(), processLogoutAll(authService)),
+
+		handle(fiber.MethodPost, api+"/auth/mfa/verify", public(), processVerifyMFA(authService)),
+		handle(fiber.MethodPost, api+"/auth/mfa/setup", mfaEnrollment().notImpersonated(), processSetupMFA(mfaService)),
+		handle(fiber.MethodPost, api+"/auth/mfa/enable", mfaEnrollment().notImpersonated(), processEnableMFA(mfaService)),
+		handle(fiber.MethodPost, api+"/auth/mfa/disable", authenticated().notImpersonated(), processDisableMFA(mfaService)),
+		handle(fiber.MethodPost, api+"/auth/mfa/recovery-codes", authenticated().notImpersonated(), processRegenerateRecoveryCodes(mfaService)),
+
+		// Users
+		handle(fiber.MethodGet, api+"/users", permission("user:read"), processGetAllUsers(userService)),
+		handle(fiber.MethodPost, api+"/users", permission("user:create"), processCreateUser(userService)),
+		handle(fiber.MethodGet, api+"/users/:id", permission("user:read"), processGetUserByID(userService)),
+		handle(fiber.MethodPut, api+"/users/:id", permission("user:update"), processUpdateUser(userService)),
+		handle(fiber.MethodDelete, api+"/users/:id", permission("user:delete").notImpersonated(), processDeleteUser(userService)),
+		handle(fiber.MethodPut, api+"/users/:id/role", permission("user:assign_role"), processUpdateUserRole(userService)),
+		handle(fiber.MethodPost, api+"/users/:id/unlock", permission("user:unlock"), middleware.CurrentUser(), processUnlockUser(userService)),
+		handle(fiber.MethodPost, api+"/users/:id/impersonate", permission("user:impersonate").notImpersonated(), middleware.CurrentUser(), processImpersonateUser(authService)),
+		handle(fiber.MethodGet, api+"/users/:id/security-events", permission("security_event:read"), processGetUserSecurityEvents(userService)),
+		handle(fiber.MethodGet, api+"/users/:id/sessions", permission("session:read"), processGetUserSessions(authService)),
+		handle(fiber.MethodDelete, api+"/users/:id/sessions", permission("session:revoke"), middleware.CurrentUser(), processRevokeUserSessions(authService)),
+		handle(fiber.MethodDelete, api+"/users/:id/sessions/:sessionId", permission("session:revoke"), middleware.CurrentUser(), processRevokeUserSession(authService)),
+
+		// Roles & permissions
+		handle(fiber.MethodGet, api+"/roles", permission("role:read"), processGetAllRoles(roleService)),
+		handle(fiber.MethodPost, api+"/roles", permission("role:create"), processCreateRole(roleService)),
+		handle(fiber.MethodGet, api+"/roles/:id", permission("role:read"), processGetRoleByID(roleService)),
+		handle(fiber.MethodPut, api+"/roles/:id", permission("role:update"), processUpdateRole(roleService)),
+		handle(fiber.MethodDelete, api+"/roles/:id", permission("role:delete"), processDeleteRole(roleService)),
+		handle(fiber.MethodPut, api+"/roles/:id/permissions", permission("role:assign_permission"), processAssignRolePermissions(roleService)),
+
+		handle(fiber.MethodGet, api+"/permissions", permission("permission:read"), processGetAllPermissions(permissionService)),
+		handle(fiber.MethodPost, api+"/permissions", permission("permission:create"), processCreatePermission(permissionService)),
+		handle(fiber.MethodGet, api+"/permissions/:id", permission("permission:read"), processGetPermissionByID(permissionService)),
+		handle(fiber.MethodPut, api+"/permissions/:id", permission("permission:update"), processUpdatePermission(permissionService)),
+		handle(fiber.MethodDelete, api+"/permissions/:id", permission("permission:delete"), processDeletePermission(permissionService)),
+
+		// Service accounts
+		handle(fiber.MethodGet, api+"/service-accounts", permission("service_account:read"), processGetAllServiceAccounts(serviceAccountService)),
+		handle(fiber.MethodPost, api+"/service-accounts", permission("service_account:manage").notImpersonated(), middleware.CurrentUser(), processCreateServiceAccount(serviceAccountService)),
+		handle(fiber.MethodGet, api+"/service-accounts/:id", permission("service_account:read"), processGetServiceAccountByID(serviceAccountService)),
+		handle(fiber.MethodPut, api+"/service-accounts/:id", permission("service_account:manage").notImpersonated(), processUpdateServiceAccount(serviceAccountService)),
+		handle(fiber.MethodDelete, api+"/service-accounts/:id", permission("service_account:manage").notImpersonated(), processDeleteServiceAccount(serviceAccountService)),
+		handle(fiber.MethodGet, api+"/service-accounts/:id/keys", permission("service_account:read"), processListAPIKeys(serviceAccountService)),
+		handle(fiber.MethodPost, api+"/service-accounts/:id/keys", permission("service_account:manage").notImpersonated(), middleware.CurrentUser(), processCreateAPIKey(serviceAccountService)),
+		handle(fiber.MethodDelete, api+"/service-accounts/:id/keys/:keyId", permission("service_account:manage").notImpersonated(), middleware.CurrentUser(), processRevokeAPIKey(serviceAccountService)),
+
+		// Self-registration
+		handle(fiber.MethodGet, api+"/registrations/allowlist", permission("registration:read"), processListAllowlist(registrationService)),
+		handle(fiber.MethodPost, api+"/registrations/allowlist", permission("registration:manage"), middleware.CurrentUser(), processUploadAllowlist(registrationService)),
+		handle(fiber.MethodDelete, api+"/registrations/allowlist/:studentId", permission("registration:manage"), processDeleteAllowlistEntry(registrationService)),
+		handle(fiber.MethodGet, api+"/registrations/pending", permission("registration:read"), processListPendingRegistrations(registrationService)),
+		handle(fiber.MethodPost, api+"/registrations/:id/approve", permission("registration:manage").notImpersonated(), middleware.CurrentUser(), processApproveRegistration(registrationService)),
+		handle(fiber.MethodPost, api+"/registrations/:id/reject", permission("registration:manage").notImpersonated(), middleware.CurrentUser(), processRejectRegistration(registrationService)),
+
+		// Approval workflows
+		handle(fiber.MethodGet, api+"/approval-workflows", permission("approval_workflow:read"), processGetAllApprovalWorkflows(approvalWorkflowService)),
+		handle(fiber.MethodPost, api+"/approval-workflows", permission("approval_workflow:manage").notImpersonated(), processCreateApprovalWorkflow(approvalWorkflowService)),
+		handle(fiber.MethodGet, api+"/approval-workflows/:id", permission("approval_workflow:read"), processGetApprovalWorkflowByID(approvalWorkflowService)),
+		handle(fiber.MethodPut, api+"/approval-workflows/:id", permission("approval_workflow:manage").notImpersonated(), processUpdateApprovalWorkflow(approvalWorkflowService)),
+		handle(fiber.MethodDelete, api+"/approval-workflows/:id", permission("approval_workflow:manage").notImpersonated(), processDeleteApprovalWorkflow(approvalWorkflowService)),
+
+		// Achievement type schemas
+		handle(fiber.MethodGet, api+"/achievement-types", permission("achievement:read"), processGetAllAchievementTypeSchemas(achievementTypeSchemaService)),
+		handle(fiber.MethodGet, api+"/achievement-types/:type/schema", permission("achievement:read"), processGetAchievementTypeSchema(achievementTypeSchemaService)),
+		handle(fiber.MethodPut, api+"/achievement-types/:type/schema", permission("achievement_schema:manage").notImpersonated(), middleware.CurrentUser(), processSaveAchievementTypeSchema(achievementTypeSchemaService)),
+		handle(fiber.MethodDelete, api+"/achievement-types/:type/schema", permission("achievement_schema:manage").notImpersonated(), processDeleteAchievementTypeSchema(achievementTypeSchemaService)),
+
+		// Master data catalogs
+		handle(fiber.MethodGet, api+"/catalogs/:catalog", permission("achievement:read"), processGetCatalog(catalogService)),
+		handle(fiber.MethodPut, api+"/catalogs/:catalog/:code", permission("catalog:manage").notImpersonated(), processSaveCatalogItem(catalogService)),
+
+		// Points rubrics
+		handle(fiber.MethodGet, api+"/points-rubrics", permission("points_rubric:read"), processGetAllPointsRubrics(pointsRubricService)),
+		handle(fiber.MethodPost, api+"/points-rubrics", permission("points_rubric:manage").notImpersonated(), middleware.CurrentUser(), processCreatePointsRubric(pointsRubricService)),
+		handle(fiber.MethodGet, api+"/points-rubrics/:id", permission("points_rubric:read"), processGetPointsRubricByID(pointsRubricService)),
+		handle(fiber.MethodDelete, api+"/points-rubrics/:id", permission("points_rubric:manage").notImpersonated(), processDeletePointsRubric(pointsRubricService)),
+
+		// Achievements
+		handle(fiber.MethodGet, api+"/achievements", permission("achievement:read"), middleware.OnlyMahasiswa(), listAchievements(referenceService)),
+		handle(fiber.MethodGet, api+"/achievements/:id", permission("achievement:read"), getAchievementDetail(achievementService)),
+		handle(fiber.MethodPost, api+"/achievements", permission("achievement:create"), middleware.OnlyMahasiswa(), middleware.CurrentUser(), createAchievement(achievementService, referenceService)),
+		handle(fiber.MethodPut, api+"/achievements/:id", permission("achievement:update"), updateAchievement(achievementService)),
+		handle(fiber.MethodPatch, api+"/achievements/:id", permission("achievement:update"), updateAchievement(achievementService)),
+		handle(fiber.MethodDelete, api+"/achievements/:id", permission("achievement:delete").notImpersonated(), middleware.CurrentUser(), deleteAchievement(referenceService)),
+		handle(fiber.MethodPost, api+"/achievements/:id/submit", permission("achievement:submit"), middleware.CurrentUser(), submitAchievement(referenceService)),
+		handle(fiber.MethodPost, api+"/achievements/:id/attachments", permission("achievement:update"), addAttachment(achievementService)),
+		handle(fiber.MethodPost, api+"/achievements/:id/verify", permission("achievement:verify").notImpersonated(), middleware.CurrentUser(), verifyAchievement(referenceService)),
+		handle(fiber.MethodPost, api+"/achievements/:id/reject", permission("achievement:reject").notImpersonated(), middleware.CurrentUser(), rejectAchievement(referenceService)),
+		handle(fiber.MethodPost, api+"/achievements/bulk-verify", permission("achievement:verify").notImpersonated(), middleware.CurrentUser(), bulkVerifyAchievements(referenceService)),
+		handle(fiber.MethodPost, api+"/achievements/bulk-reject", permission("achievement:reject").notImpersonated(), middleware.CurrentUser(), bulkRejectAchievements(referenceService)),
+		handle(fiber.MethodPost, api+"/achievements/:id/request-revision", permission("achievement:request_revision").notImpersonated(), middleware.CurrentUser(), requestAchievementRevision(referenceService)),
+		handle(fiber.MethodGet, api+"/achievements/:id/points-suggestion", permission("points_rubric:read"), getAchievementPointsSuggestion(referenceService)),
+		handle(fiber.MethodGet, api+"/achievements/:id/history", permission("achievement:read"), achievementHistory(referenceService)),
+
+		// Students & lecturers
+		handle(fiber.MethodGet, api+"/students", permission("student:read").allowAPIKey(), StudentList(studentLecturerService)),
+		handle(fiber.MethodGet, api+"/students/:id", permission("student:read").allowAPIKey(), StudentGetByID(studentLecturerService)),
+		handle(fiber.MethodGet, api+"/students/:id/achievements", permission("achievement:read").allowAPIKey(), StudentAchievements(studentLecturerService)),
+		handle(fiber.MethodPut, api+"/students/:id/advisor", permission("student:assign_advisor"), StudentUpdateAdvisor(studentLecturerService)),
+		handle(fiber.MethodGet, api+"/lecturers", permission("lecturer:read"), LecturerList(studentLecturerService)),
+		handle(fiber.MethodGet, api+"/lecturers/me/queue", permission("achievement:verify"), middleware.OnlyDosenWali(), verificationQueue(referenceService)),
+		handle(fiber.MethodGet, api+"/lecturers/:id/advisees", permission("lecturer:read"), LecturerAdvisees(studentLecturerService)),
+
+		// Reports
+		handle(fiber.MethodGet, api+"/reports/statistics", permission("report:read").allowAPIKey(), handleGetStatistics(reportService)),
+		handle(fiber.MethodGet, api+"/reports/student/:id", permission("report:read").allowAPIKey(), handleGetStudentReport(reportService)),
+	}
+
+	if err := registerRoutes(app, table); err != nil {
+		return err
+	}
+	return checkRoutePolicies(app, table)
 }
@@ -0,0 +1,260 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/models"
+	"backend/app/service"
+)
+
+// processGetAllServiceAccounts godoc
+// @Summary      List service accounts
+// @Description  Retrieve all service accounts used by machine clients (Admin only)
+// @Tags         Service Accounts
+// @Produce      json
+// @Success      200  {array}   models.ServiceAccount
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts [get]
+func processGetAllServiceAccounts(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		accounts, err := s.ListServiceAccounts(c.Context())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": accounts})
+	}
+}
+
+// processGetServiceAccountByID godoc
+// @Summary      Get service account
+// @Description  Retrieve a service account (Admin only)
+// @Tags         Service Accounts
+// @Produce      json
+// @Param        id   path      string  true  "Service account UUID"
+// @Success      200  {object}  models.ServiceAccount
+// @Failure      404  {object}  map[string]string "Service account not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts/{id} [get]
+func processGetServiceAccountByID(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		account, err := s.GetServiceAccount(c.Context(), id)
+		if err != nil {
+			return serviceAccountErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": account})
+	}
+}
+
+// processCreateServiceAccount godoc
+// @Summary      Create service account
+// @Description  Create a service account for a machine client such as the faculty dashboard or SIAKAD (Admin only)
+// @Tags         Service Accounts
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreateServiceAccountRequest  true  "Service account data"
+// @Success      201      {object}  models.ServiceAccount
+// @Failure      409      {object}  map[string]string "Name already exists"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts [post]
+func processCreateServiceAccount(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(models.CreateServiceAccountRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		account, err := s.CreateServiceAccount(c.Context(), req, adminID)
+		if err != nil {
+			return serviceAccountErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": "success", "data": account})
+	}
+}
+
+// processUpdateServiceAccount godoc
+// @Summary      Update service account
+// @Description  Update description or deactivate a service account. Keys of an inactive account are rejected (Admin only)
+// @Tags         Service Accounts
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                              true  "Service account UUID"
+// @Param        request  body      models.UpdateServiceAccountRequest  true  "Service account data"
+// @Success      200      {object}  models.ServiceAccount
+// @Failure      404      {object}  map[string]string "Service account not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts/{id} [put]
+func processUpdateServiceAccount(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		req := new(models.UpdateServiceAccountRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		account, err := s.UpdateServiceAccount(c.Context(), id, req)
+		if err != nil {
+			return serviceAccountErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": account})
+	}
+}
+
+// processDeleteServiceAccount godoc
+// @Summary      Delete service account
+// @Description  Delete a service account and all of its API keys (Admin only)
+// @Tags         Service Accounts
+// @Produce      json
+// @Param        id   path      string  true  "Service account UUID"
+// @Success      200  {object}  map[string]string "Service account deleted successfully"
+// @Failure      404  {object}  map[string]string "Service account not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts/{id} [delete]
+func processDeleteServiceAccount(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		if err := s.DeleteServiceAccount(c.Context(), id); err != nil {
+			return serviceAccountErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Service account deleted successfully"})
+	}
+}
+
+// processListAPIKeys godoc
+// @Summary      List API keys
+// @Description  List API keys of a service account with their scope, expiry and last use. Secrets are never returned (Admin only)
+// @Tags         Service Accounts
+// @Produce      json
+// @Param        id   path      string  true  "Service account UUID"
+// @Success      200  {array}   models.APIKey
+// @Failure      404  {object}  map[string]string "Service account not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts/{id}/keys [get]
+func processListAPIKeys(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		keys, err := s.ListAPIKeys(c.Context(), id)
+		if err != nil {
+			return serviceAccountErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": keys})
+	}
+}
+
+// processCreateAPIKey godoc
+// @Summary      Create API key
+// @Description  Issue an API key scoped to the given permissions, optionally expiring. Scopes are limited to read access (student:read, report:read, achievement:read) and keys are only accepted on endpoints that opt in. The key is shown only once; send it as "X-API-Key" or "Authorization: Bearer" (Admin only)
+// @Tags         Service Accounts
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                      true  "Service account UUID"
+// @Param        request  body      models.CreateAPIKeyRequest  true  "Key name, permissions and expiry"
+// @Success      201      {object}  models.CreateAPIKeyResponse
+// @Failure      400      {object}  map[string]string "Unknown or disallowed permission, or invalid expiry"
+// @Failure      404      {object}  map[string]string "Service account not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts/{id}/keys [post]
+func processCreateAPIKey(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		req := new(models.CreateAPIKeyRequest)
+		if err := c.BodyParser(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid request body"})
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		rawKey, key, err := s.CreateAPIKey(c.Context(), id, req, adminID)
+		if err != nil {
+			return serviceAccountErrorResponse(c, err)
+		}
+
+		resp := models.CreateAPIKeyResponse{Status: "success"}
+		resp.Data.Key = rawKey
+		resp.Data.APIKey = *key
+		return c.Status(fiber.StatusCreated).JSON(resp)
+	}
+}
+
+// processRevokeAPIKey godoc
+// @Summary      Revoke API key
+// @Description  Revoke an API key immediately (Admin only)
+// @Tags         Service Accounts
+// @Produce      json
+// @Param        id     path      string  true  "Service account UUID"
+// @Param        keyId  path      string  true  "API key UUID"
+// @Success      200    {object}  map[string]string "API key revoked successfully"
+// @Failure      404    {object}  map[string]string "Service account or key not found"
+// @Failure      409    {object}  map[string]string "Already revoked"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/service-accounts/{id}/keys/{keyId} [delete]
+func processRevokeAPIKey(s service.ServiceAccountService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		keyID, err := uuid.Parse(c.Params("keyId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		if err := s.RevokeAPIKey(c.Context(), id, keyID, adminID); err != nil {
+			return serviceAccountErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "API key revoked successfully"})
+	}
+}
+
+func serviceAccountErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "service account not found", "api key not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "service account name already exists", "api key already revoked", "service account is inactive":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	case "one or more permissions not found", "permission not allowed for api keys", "expiresAt must be in the future":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
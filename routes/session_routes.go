@@ -0,0 +1,174 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"backend/app/service"
+	"backend/middleware"
+)
+
+// processListSessions godoc
+// @Summary      List My Sessions
+// @Description  List active sessions (one per login) of the current user with created time, last used time, IP and user agent
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {array}   models.Session
+// @Router       /api/v1/auth/sessions [get]
+func processListSessions(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
+		currentSessionID, _ := c.Locals(middleware.SessionIDKey).(*uuid.UUID)
+
+		sessions, err := s.ListSessions(c.Context(), userID, currentSessionID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": sessions})
+	}
+}
+
+// processRevokeSession godoc
+// @Summary      Revoke My Session
+// @Description  Revoke one of the current user's sessions. Its access tokens are rejected immediately; other sessions of the user must refresh once.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Session ID"
+// @Security     ApiKeyAuth
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string "Session not found"
+// @Router       /api/v1/auth/sessions/{id} [delete]
+func processRevokeSession(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		sessionID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
+
+		if err := s.RevokeSession(c.Context(), userID, sessionID, userID); err != nil {
+			return sessionErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Session revoked"})
+	}
+}
+
+// processLogoutAll godoc
+// @Summary      Log Out Everywhere
+// @Description  Revoke every active session of the current user, including access tokens already issued
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  map[string]string
+// @Router       /api/v1/auth/logout-all [post]
+func processLogoutAll(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := c.Locals(middleware.UserIDKey).(uuid.UUID)
+
+		if err := s.RevokeAllSessions(c.Context(), userID, userID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Logged out from all sessions"})
+	}
+}
+
+// processGetUserSessions godoc
+// @Summary      List user sessions
+// @Description  List active sessions of a user (Admin only)
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "User UUID"
+// @Security     ApiKeyAuth
+// @Success      200  {array}   models.Session
+// @Router       /api/v1/users/{id}/sessions [get]
+func processGetUserSessions(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		sessions, err := s.ListSessions(c.Context(), userID, nil)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "data": sessions})
+	}
+}
+
+// processRevokeUserSession godoc
+// @Summary      Revoke user session
+// @Description  Force logout one session of a user (Admin only). Its access tokens are rejected immediately; other sessions of the user must refresh once.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true  "User UUID"
+// @Param        sessionId  path      string  true  "Session ID"
+// @Security     ApiKeyAuth
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  map[string]string "Session not found"
+// @Router       /api/v1/users/{id}/sessions/{sessionId} [delete]
+func processRevokeUserSession(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+		sessionID, err := uuid.Parse(c.Params("sessionId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		if err := s.RevokeSession(c.Context(), userID, sessionID, adminID); err != nil {
+			return sessionErrorResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Session revoked"})
+	}
+}
+
+// processRevokeUserSessions godoc
+// @Summary      Force logout user
+// @Description  Revoke every active session of a user (Admin only), including access tokens already issued
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "User UUID"
+// @Security     ApiKeyAuth
+// @Success      200  {object}  map[string]string
+// @Router       /api/v1/users/{id}/sessions [delete]
+func processRevokeUserSessions(s service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		if err := s.RevokeAllSessions(c.Context(), userID, adminID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "All sessions revoked"})
+	}
+}
+
+func sessionErrorResponse(c *fiber.Ctx, err error) error {
+	if err.Error() == "session not found" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+}
@@ -54,13 +54,14 @@ func StudentGetByID(svc service.StudentLecturerService) fiber.Handler {
 
 // StudentAchievements godoc
 // @Summary      Get Student Achievements
-// @Description  List all achievement status and points for a specific student
+// @Description  List all achievement status and points for a specific student. Students only see their own, advisors only their advisees; other students return 404.
 // @Tags         Students & Lecturers
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Student UUID"
 // @Security     ApiKeyAuth
 // @Success      200  {array}   models.StudentAchievementResponse
+// @Failure      404  {object}  map[string]string "Student not found or outside the caller's scope"
 // @Router       /api/v1/students/{id}/achievements [get]
 func StudentAchievements(svc service.StudentLecturerService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -69,7 +70,7 @@ func StudentAchievements(svc service.StudentLecturerService) fiber.Handler {
 			return fiber.ErrBadRequest
 		}
 
-		data, err := svc.GetStudentAchievements(c.Context(), id)
+		data, err := svc.GetStudentAchievements(c.Context(), id, achievementActor(c))
 		if err != nil {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		}
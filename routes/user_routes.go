@@ -19,7 +19,7 @@ var validate = validator.New()
 // @Produce      json
 // @Param        request  body      models.CreateUserRequest  true  "User Data"
 // @Success      201      {object}  models.UserResponse
-// @Failure      400      {object}  map[string]string
+// @Failure      400      {object}  map[string]interface{} "Invalid data or password policy violations"
 // @Failure      409      {object}  map[string]string "Email/Username already taken"
 // @Security     ApiKeyAuth
 // @Router       /api/v1/users [post]
@@ -36,6 +36,9 @@ func processCreateUser(s service.UserService) fiber.Handler {
 
 		createdUser, err := s.CreateUser(c.Context(), req)
 		if err != nil {
+			if ok, resp := passwordPolicyResponse(c, err); ok {
+				return resp
+			}
 			if err.Error() == "email already registered" || err.Error() == "username already taken" {
 				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
 			}
@@ -76,13 +79,15 @@ func processGetUserByID(s service.UserService) fiber.Handler {
 
 // processUpdateUser godoc
 // @Summary      Update user
-// @Description  Update user personal information
+// @Description  Update user personal information. A new password must satisfy the password policy and must not repeat a recent password
 // @Tags         Users
 // @Accept       json
 // @Produce      json
 // @Param        id       path      string                    true  "User UUID"
 // @Param        request  body      models.UpdateUserRequest  true  "Updated User Data"
 // @Success      200      {object}  map[string]string         "User updated successfully"
+// @Failure      400      {object}  map[string]interface{}    "Password policy violations"
+// @Failure      409      {object}  map[string]string         "Would leave the system without an active Admin"
 // @Security     ApiKeyAuth
 // @Router       /api/v1/users/{id} [put]
 func processUpdateUser(s service.UserService) fiber.Handler {
@@ -99,6 +104,12 @@ func processUpdateUser(s service.UserService) fiber.Handler {
 		}
 
 		if err := s.UpdateUser(c.Context(), userID, req); err != nil {
+			if ok, resp := passwordPolicyResponse(c, err); ok {
+				return resp
+			}
+			if err.Error() == "cannot remove the last active admin" {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
 		}
 
@@ -114,6 +125,7 @@ func processUpdateUser(s service.UserService) fiber.Handler {
 // @Produce      json
 // @Param        id   path      string             true  "User UUID"
 // @Success      200  {object}  map[string]string  "User deleted successfully"
+// @Failure      409  {object}  map[string]string  "Would leave the system without an active Admin"
 // @Security     ApiKeyAuth
 // @Router       /api/v1/users/{id} [delete]
 func processDeleteUser(s service.UserService) fiber.Handler {
@@ -125,6 +137,9 @@ func processDeleteUser(s service.UserService) fiber.Handler {
 		}
 
 		if err := s.DeleteUser(c.Context(), userID); err != nil {
+			if err.Error() == "cannot remove the last active admin" {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
 		}
 
@@ -176,6 +191,7 @@ func processGetAllUsers(s service.UserService) fiber.Handler {
 // @Param        id       path      string                        true  "User UUID"
 // @Param        request  body      models.UpdateUserRoleRequest  true  "Role Data"
 // @Success      200      {object}  map[string]string             "User role updated successfully"
+// @Failure      409      {object}  map[string]string             "Would leave the system without an active Admin"
 // @Security     ApiKeyAuth
 // @Router       /api/v1/users/{id}/role [put]
 func processUpdateUserRole(s service.UserService) fiber.Handler {
@@ -196,9 +212,84 @@ func processUpdateUserRole(s service.UserService) fiber.Handler {
 		}
 
 		if err := s.UpdateUserRole(c.Context(), userID, req); err != nil {
+			if err.Error() == "cannot remove the last active admin" {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
 		}
 
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "User role updated successfully"})
 	}
 }
+
+// processUnlockUser godoc
+// @Summary      Unlock user account
+// @Description  Clear failed login attempts and remove a temporary lockout (Admin only)
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string             true  "User UUID"
+// @Success      200  {object}  map[string]string  "User unlocked successfully"
+// @Failure      404  {object}  map[string]string  "User not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/users/{id}/unlock [post]
+func processUnlockUser(s service.UserService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		idParam := c.Params("id")
+		userID, err := uuid.Parse(idParam)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		adminID := c.Locals("user_id").(uuid.UUID)
+
+		if err := s.UnlockUser(c.Context(), userID, adminID); err != nil {
+			if err.Error() == "user not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "User unlocked successfully"})
+	}
+}
+
+// processGetUserSecurityEvents godoc
+// @Summary      List user security events
+// @Description  Retrieve failed logins, lockouts and other security events of a user (Admin only)
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true   "User UUID"
+// @Param        page   query     int     false  "Page number (default: 1)"
+// @Param        limit  query     int     false  "Items per page (default: 20)"
+// @Success      200    {array}   models.SecurityEvent
+// @Failure      404    {object}  map[string]string "User not found"
+// @Security     ApiKeyAuth
+// @Router       /api/v1/users/{id}/security-events [get]
+func processGetUserSecurityEvents(s service.UserService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		idParam := c.Params("id")
+		userID, err := uuid.Parse(idParam)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid UUID format"})
+		}
+
+		page := c.QueryInt("page", 1)
+		limit := c.QueryInt("limit", 20)
+
+		events, err := s.GetSecurityEvents(c.Context(), userID, page, limit)
+		if err != nil {
+			if err.Error() == "user not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "fail", "message": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": err.Error()})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status": "success",
+			"data":   events,
+			"meta":   fiber.Map{"page": page, "limit": limit},
+		})
+	}
+}